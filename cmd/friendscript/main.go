@@ -39,6 +39,10 @@ func main() {
 			Name:  `execute, c`,
 			Usage: `Execute commands provided as command line arguments.`,
 		},
+		cli.BoolFlag{
+			Name:  `strict`,
+			Usage: `Treat references to undefined variables in strings as errors.`,
+		},
 	}
 
 	app.Before = func(c *cli.Context) error {
@@ -47,14 +51,15 @@ func main() {
 	}
 
 	app.Action = func(c *cli.Context) {
-		// go handleSignals(func() {
-		// 	for _, closer := range closables {
-		// 		closer.Close()
-		// 	}
-		// })
-
 		// evaluate Friendscript / run the REPL
 		var script = friendscript.NewEnvironment(nil)
+		script.SetStrict(c.Bool(`strict`))
+
+		go handleSignals(script.Interrupt, func() {
+			for _, closer := range closables {
+				closer.Close()
+			}
+		})
 
 		// pre-populate initial variables
 		for _, pair := range c.StringSlice(`var`) {
@@ -94,7 +99,15 @@ func main() {
 			}
 		}
 
-		if scope, err := script.EvaluateReader(input); err == nil {
+		var scope, err = script.EvaluateReader(input)
+		var exit *friendscript.ExitError
+
+		// scripts that stop themselves with "exit" set the exit status of the process
+		if errors.As(err, &exit) {
+			err = nil
+		}
+
+		if err == nil {
 			if prints := c.StringSlice(`print-var`); len(prints) > 0 {
 				var out = make(map[string]any)
 
@@ -113,19 +126,25 @@ func main() {
 		} else {
 			log.Fatal(err)
 		}
+
+		if exit != nil {
+			os.Exit(exit.Code)
+		}
 	}
 
 	app.Run(os.Args)
 }
 
-func handleSignals(handler func()) {
+// The first interrupt stops the running script (giving it the chance to clean up after itself), and a
+// second one exits immediately.
+func handleSignals(interrupt func(), exit func()) {
 	var signalChan = make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt)
 
-	for range signalChan {
-		handler()
-		break
-	}
+	<-signalChan
+	interrupt()
 
-	os.Exit(0)
+	<-signalChan
+	exit()
+	os.Exit(130)
 }
@@ -2,6 +2,7 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -162,7 +163,8 @@ type RunArgs struct {
 // script (if available) will always be checked first.
 //
 // Returns: The value of the variable named by result_key at the end of the
-// evaluated script's execution.
+// evaluated script's execution, or if no result_key is given, the value the
+// script gave to "return" (if any).
 func (self *Commands) Run(filename string, args *RunArgs) (any, error) {
 	if self.env == nil {
 		return nil, fmt.Errorf("no environment found")
@@ -190,6 +192,11 @@ func (self *Commands) Run(filename string, args *RunArgs) (any, error) {
 
 // Pauses execution of the current script for the given duration.
 func (self *Commands) Wait(delay any) error {
+	return self.WaitContext(context.Background(), delay)
+}
+
+// Same as Wait, but stops waiting early if the given context is cancelled.
+func (self *Commands) WaitContext(ctx context.Context, delay any) error {
 	var duration time.Duration
 
 	if delayD, ok := delay.(time.Duration); ok {
@@ -203,6 +210,12 @@ func (self *Commands) Wait(delay any) error {
 	}
 
 	log.Debugf("Waiting for %v", duration)
-	time.Sleep(duration)
-	return nil
+
+	// waiting stops early if the script is interrupted (e.g.: by a "timeout" block)
+	select {
+	case <-time.After(duration):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
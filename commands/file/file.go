@@ -218,3 +218,26 @@ func (self *Commands) Exists(fileOrReader any) (bool, error) {
 		return false, nil
 	}
 }
+
+// Remove the given file, which can be a path or a file (e.g.: one created with file::temp.)  Files that
+// are open are closed first.  Removing a file that does not exist is not an error, so that files can be
+// cleaned up (e.g.: "defer file::remove $tmp") without checking whether they are still there.
+func (self *Commands) Remove(file any) error {
+	var path string
+
+	switch f := file.(type) {
+	case *os.File:
+		f.Close()
+		path = f.Name()
+	case string:
+		path = f
+	default:
+		return fmt.Errorf("Cannot remove %T: must be a path or a file", file)
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -23,6 +24,9 @@ import (
 	defaults "github.com/mcuadros/go-defaults"
 )
 
+// The name of the event emitted after each HTTP response is received.
+const ResponseEvent = `http.response`
+
 type Commands struct {
 	utils.Module
 	env      utils.Runtime
@@ -181,35 +185,66 @@ func (self *Commands) Defaults(args *RequestArgs) error {
 
 // Perform an HTTP GET request.
 func (self *Commands) Get(url string, args *RequestArgs) (*HttpResponse, error) {
-	return self.request(`GET`, url, args)
+	return self.GetContext(context.Background(), url, args)
+}
+
+// Same as Get, but the request is cancelled if the given context is.
+func (self *Commands) GetContext(ctx context.Context, url string, args *RequestArgs) (*HttpResponse, error) {
+	return self.request(ctx, `GET`, url, args)
 }
 
 // Perform an HTTP POST request.
 func (self *Commands) Post(url string, args *RequestArgs) (*HttpResponse, error) {
-	return self.request(`POST`, url, args)
+	return self.PostContext(context.Background(), url, args)
+}
+
+// Same as Post, but the request is cancelled if the given context is.
+func (self *Commands) PostContext(ctx context.Context, url string, args *RequestArgs) (*HttpResponse, error) {
+	return self.request(ctx, `POST`, url, args)
 }
 
 // Perform an HTTP PUT request.
 func (self *Commands) Put(url string, args *RequestArgs) (*HttpResponse, error) {
-	return self.request(`PUT`, url, args)
+	return self.PutContext(context.Background(), url, args)
+}
+
+// Same as Put, but the request is cancelled if the given context is.
+func (self *Commands) PutContext(ctx context.Context, url string, args *RequestArgs) (*HttpResponse, error) {
+	return self.request(ctx, `PUT`, url, args)
 }
 
 // Perform an HTTP DELETE request.
 func (self *Commands) Delete(url string, args *RequestArgs) (*HttpResponse, error) {
-	return self.request(`DELETE`, url, args)
+	return self.DeleteContext(context.Background(), url, args)
+}
+
+// Same as Delete, but the request is cancelled if the given context is.
+func (self *Commands) DeleteContext(ctx context.Context, url string, args *RequestArgs) (*HttpResponse, error) {
+	return self.request(ctx, `DELETE`, url, args)
 }
 
 // Perform an HTTP OPTIONS request.
 func (self *Commands) Options(url string, args *RequestArgs) (*HttpResponse, error) {
-	return self.request(`OPTIONS`, url, args)
+	return self.OptionsContext(context.Background(), url, args)
+}
+
+// Same as Options, but the request is cancelled if the given context is.
+func (self *Commands) OptionsContext(ctx context.Context, url string, args *RequestArgs) (*HttpResponse, error) {
+	return self.request(ctx, `OPTIONS`, url, args)
 }
 
 // Perform an HTTP HEAD request.
 func (self *Commands) Head(url string, args *RequestArgs) (*HttpResponse, error) {
-	return self.request(`HEAD`, url, args)
+	return self.HeadContext(context.Background(), url, args)
 }
 
-func (self *Commands) request(method string, url string, args *RequestArgs) (*HttpResponse, error) {
+// Same as Head, but the request is cancelled if the given context is.
+func (self *Commands) HeadContext(ctx context.Context, url string, args *RequestArgs) (*HttpResponse, error) {
+	return self.request(ctx, `HEAD`, url, args)
+}
+
+// Performs the request, which is cancelled if the given context is (e.g.: by a "timeout" block.)
+func (self *Commands) request(ctx context.Context, method string, url string, args *RequestArgs) (*HttpResponse, error) {
 	// this is the bit that takes any defaults set via http::defaults and overlays the per-request values
 	var reqargs = self.defaults.Merge(args)
 
@@ -234,7 +269,7 @@ func (self *Commands) request(method string, url string, args *RequestArgs) (*Ht
 	// encode the body (if any) in preparation for sending in the request
 	if body, contentType, err := encodeBody(reqargs.RequestType, reqargs.Body); err == nil {
 		// get a new request
-		if req, err := http.NewRequest(method, url, body); err == nil {
+		if req, err := http.NewRequestWithContext(ctx, method, url, body); err == nil {
 			// set query string parameters
 			if len(reqargs.Params) > 0 {
 				for k, v := range reqargs.Params {
@@ -316,6 +351,12 @@ func (self *Commands) request(method string, url string, args *RequestArgs) (*Ht
 						res.Error = true
 					} else {
 						log.Debugf("friendscript/http: <- Request error: %v", response.Status)
+						res.Error = true
+
+						if err := self.emitResponse(req, res); err != nil {
+							return nil, err
+						}
+
 						return nil, fmt.Errorf("HTTP %v", response.Status)
 					}
 				}
@@ -368,6 +409,10 @@ func (self *Commands) request(method string, url string, args *RequestArgs) (*Ht
 					}
 				}
 
+				if err := self.emitResponse(req, res); err != nil {
+					return nil, err
+				}
+
 				return res, nil
 			} else {
 				log.Debugf("friendscript/http: <- Request failed: %v", err)
@@ -381,6 +426,34 @@ func (self *Commands) request(method string, url string, args *RequestArgs) (*Ht
 	}
 }
 
+// Notify the runtime that a response was received.  Raw (streaming) response bodies are not
+// included in the event, since reading them would consume the body before the caller can.
+func (self *Commands) emitResponse(req *http.Request, res *HttpResponse) error {
+	if self.env == nil {
+		return nil
+	}
+
+	var event = map[string]any{
+		`method`:      req.Method,
+		`url`:         req.URL.String(),
+		`status`:      res.Status,
+		`status_text`: res.StatusText,
+		`took`:        res.Took,
+		`headers`:     res.Headers,
+		`type`:        res.ContentType,
+		`length`:      res.Length,
+		`error`:       res.Error,
+	}
+
+	if _, ok := res.Body.(io.Reader); !ok {
+		event[`body`] = res.Body
+	}
+
+	// events are emitted on the call chain that made the request, so that handlers which make requests
+	// of their own are not called recursively
+	return utils.EmitContext(req.Context(), self.env, ResponseEvent, event)
+}
+
 func encodeBody(enctype string, body any) (io.Reader, string, error) {
 	var reader io.Reader
 	var contentType string = `application/octet-stream`
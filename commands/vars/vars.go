@@ -6,6 +6,7 @@ import (
 	"sort"
 
 	"github.com/ghetzel/friendscript/utils"
+	"github.com/ghetzel/go-stockutil/log"
 	"github.com/ghetzel/go-stockutil/maputil"
 	"github.com/ghetzel/go-stockutil/sliceutil"
 	"github.com/ghetzel/go-stockutil/typeutil"
@@ -38,9 +39,12 @@ func (self *Commands) Keys() ([]string, error) {
 	}
 }
 
-// Unset the value at the given key.
+// Unset the value at the given key, removing it from the scope that defines it.  Keys that cannot be
+// unset (e.g.: constants) are logged and otherwise ignored.
 func (self *Commands) Clear(key string) {
-	self.env.Scope().Set(key, nil)
+	if err := self.env.Scope().Unset(key); err != nil {
+		log.Warningf("friendscript: %v", err)
+	}
 }
 
 type GetArgs struct {
@@ -64,7 +68,7 @@ type InterpolateArgs struct {
 
 // Return a value interpolated with values from a scope or ones that are explicitly provided.
 func (self *Commands) Interpolate(format string, args *InterpolateArgs) (string, error) {
-	return self.env.Scope().Interpolate(format), nil
+	return self.env.Scope().Expand(format)
 }
 
 type SetArgs struct {
@@ -89,7 +93,10 @@ func (self *Commands) Set(key string, args *SetArgs) (any, error) {
 		}
 	}
 
-	self.env.Scope().Set(key, args.Value)
+	if err := self.env.Scope().Assign(key, args.Value); err != nil {
+		return nil, err
+	}
+
 	return args.Value, nil
 }
 
@@ -137,8 +144,7 @@ func (self *Commands) Push(key string, args *PushArgs) error {
 		newValue = sliceutil.Sliceify(args.Value)
 	}
 
-	self.env.Scope().Set(key, newValue)
-	return nil
+	return self.env.Scope().Assign(key, newValue)
 }
 
 // Take the last value from the array at key.  If key is an array, the last value of
@@ -150,18 +156,22 @@ func (self *Commands) Pop(key string) (any, error) {
 	if existing := self.env.Scope().Get(key); existing != nil {
 		values := sliceutil.Sliceify(existing)
 
+		var remaining any
+
 		switch len(values) {
 		case 0:
 			// clear key, return nil
-			self.env.Scope().Set(key, nil)
 		case 1:
 			// clear key, return only value
 			value = values[0]
-			self.env.Scope().Set(key, nil)
 		default:
 			// set existing array to all but last item, return last item
 			value = values[0]
-			self.env.Scope().Set(key, values[1:])
+			remaining = values[1:]
+		}
+
+		if err := self.env.Scope().Assign(key, remaining); err != nil {
+			return nil, err
 		}
 	}
 
@@ -1,6 +1,8 @@
 package friendscript
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -52,12 +54,27 @@ type Environment struct {
 	modules         map[string]Module
 	script          *scripting.Friendscript
 	stack           []*scripting.Scope
+	stacklock       sync.RWMutex
 	replHandlers    map[string]InteractiveHandlerFunc
 	contextHandlers []ContextHandlerFunc
 	chlock          sync.Mutex
 	filterCommands  map[string]bool
 	pathWriters     []utils.PathWriterFunc
 	pathReaders     []utils.PathReaderFunc
+	includes        map[string]*scripting.Friendscript
+	inclock         sync.Mutex
+	includeChain    []string
+	eventHandlers   []*eventHandler
+	functions       *functionModule
+	ehlock          sync.Mutex
+	evaldepth       int
+	calldepth       int
+	parent          *Environment
+	branch          string
+	runctx          context.Context
+	defers          [][]*deferred
+	transactions    []*transaction
+	interrupt       context.CancelCauseFunc
 }
 
 // Create a new scripting environment.
@@ -69,8 +86,11 @@ func NewEnvironment(data ...map[string]any) *Environment {
 		filterCommands: make(map[string]bool),
 		pathWriters:    make([]utils.PathWriterFunc, 0),
 		pathReaders:    make([]utils.PathReaderFunc, 0),
+		includes:       make(map[string]*scripting.Friendscript),
 	}
 
+	environment.functions = newFunctionModule(environment)
+
 	environment.pushScope(scripting.NewScope(nil))
 
 	for _, d := range data {
@@ -87,6 +107,7 @@ func NewEnvironment(data ...map[string]any) *Environment {
 	environment.RegisterModule(`url`, cmdurl.New(environment))
 	environment.RegisterModule(`utils`, cmdutils.New(environment))
 	environment.RegisterModule(`vars`, cmdvars.New(environment))
+	environment.RegisterModule(ScriptModuleName, environment.functions)
 
 	// use the "http" module (and its 🔔bells🔔 & whistles) to retrieve HTTP(S) links
 	environment.RegisterPathReader(func(path string) (io.ReadCloser, error) {
@@ -266,8 +287,27 @@ func (self *Environment) EvaluateString(data string, scope ...*scripting.Scope)
 	}
 }
 
+// The error returned by Evaluate when a script stops itself with the "exit" statement.  Scripts that
+// run other scripts (see Run) stop when the script they run exits.
+type ExitError struct {
+	Code int
+}
+
+func (self *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", self.Code)
+}
+
 func (self *Environment) Evaluate(script *scripting.Friendscript, scope ...*scripting.Scope) (*scripting.Scope, error) {
+	var rootScope, _, err = self.evaluateScript(script, scope...)
+	return rootScope, err
+}
+
+// Evaluates the given script, returning the scope it was evaluated in and the value it returned (if it
+// used the "return" statement.)  Once it finishes, the script that was being evaluated before it (if any)
+// continues in the scope it was using.
+func (self *Environment) evaluateScript(script *scripting.Friendscript, scope ...*scripting.Scope) (*scripting.Scope, *scripting.FlowControlErr, error) {
 	var rootScope *scripting.Scope
+	var returned *scripting.FlowControlErr
 
 	if len(scope) > 0 && scope[0] != nil {
 		rootScope = scope[0]
@@ -275,47 +315,88 @@ func (self *Environment) Evaluate(script *scripting.Friendscript, scope ...*scri
 		rootScope = self.Scope()
 	}
 
+	var parentScript = self.script
+	var pushed = (rootScope != self.Scope())
+
 	rootScope.Environment = self
 	self.script = script
 	self.pushScope(rootScope)
 
-	for _, block := range script.Blocks() {
-		if err := self.evaluateBlock(block); err != nil {
-			return self.Scope(), err
+	defer func() {
+		if pushed {
+			self.popScope()
 		}
+
+		self.script = parentScript
+
+		if parentScript != nil {
+			parentScript.SetScope(self.Scope())
+		}
+	}()
+
+	// the outermost script being evaluated can be interrupted (see Interrupt)
+	if self.evaldepth == 0 {
+		var outer = self.runctx
+		var ctx, cancel = context.WithCancelCause(self.context())
+
+		self.stacklock.Lock()
+		self.runctx = ctx
+		self.interrupt = cancel
+		self.stacklock.Unlock()
+
+		defer func() {
+			cancel(nil)
+
+			self.stacklock.Lock()
+			self.runctx = outer
+			self.interrupt = nil
+			self.stacklock.Unlock()
+		}()
 	}
 
-	return self.Scope(), nil
-}
+	self.evaldepth += 1
 
-func (self *Environment) Run(scriptName string, options *utils.RunOptions) (any, error) {
-	var fsp = os.Getenv(`FRIENDSCRIPT_PATH`)
-	var searchPaths = sliceutil.CompactString(strings.Split(fsp, `:`))
+	var err = self.evaluateBlocks(script.Blocks())
 
-	scriptName = strings.TrimSuffix(scriptName, `.fs`)
+	if err != nil && context.Cause(self.context()) == ErrInterrupted {
+		err = ErrInterrupted
+	}
 
-	if options == nil {
-		options = &utils.RunOptions{
-			Isolated: true,
-			BasePath: `.`,
+	self.evaldepth -= 1
+
+	// "return" ends the script successfully, and "exit" ends it (and any scripts running it)
+	if fc, ok := err.(*scripting.FlowControlErr); ok {
+		switch fc.Type {
+		case scripting.FlowReturn:
+			returned, err = fc, nil
+		case scripting.FlowExit:
+			if self.evaldepth == 0 {
+				err = &ExitError{
+					Code: fc.Value.(int),
+				}
+			}
 		}
 	}
 
-	// if the script is an absolute path, then we won't be searching for anything
-	if filepath.IsAbs(scriptName) {
-		searchPaths = []string{scriptName}
-	} else {
-		// prepend the dirname of the calling script to the searchPaths
-		searchPaths = append([]string{options.BasePath}, searchPaths...)
+	// only the outermost script being evaluated emits the runtime events, as opposed to
+	// any scripts it calls via "run"
+	if self.evaldepth == 0 {
+		self.emitScriptEvents(script, err)
+	}
+
+	return rootScope, returned, err
+}
 
-		// join all the search paths with the candidate script name
-		for i, sp := range searchPaths {
-			searchPaths[i] = filepath.Join(sp, scriptName+`.fs`)
+func (self *Environment) Run(scriptName string, options *utils.RunOptions) (any, error) {
+	if options == nil {
+		options = &utils.RunOptions{
+			Isolated: true,
+			BasePath: `.`,
 		}
 	}
 
 	// find the file
-	for _, candidate := range searchPaths {
+	for _, candidate := range scriptSearchPaths(scriptName, options.BasePath) {
 		if !fileutil.IsNonemptyFile(candidate) {
 			continue
 		}
@@ -328,26 +409,53 @@ func (self *Environment) Run(scriptName string, options *utils.RunOptions) (any,
 			scope = scripting.NewScope(self.Scope())
 		}
 
-		if len(options.Data) > 0 {
-			for k, v := range options.Data {
-				scope.Set(k, v)
+		for k, v := range options.Data {
+			if err := scope.Assign(k, v); err != nil {
+				return nil, err
 			}
 		}
 
-		if res, err := self.EvaluateFile(candidate, scope); err == nil {
-			if options.ResultKey == `` {
-				return res.MostRecentValue(), err
-			} else {
-				return res.Get(options.ResultKey), nil
-			}
-		} else {
+		if script, err := scripting.LoadFromFile(candidate); err != nil {
+			return nil, err
+		} else if res, returned, err := self.evaluateScript(script, scope); err != nil {
 			return nil, err
+		} else if options.ResultKey != `` {
+			return res.Get(options.ResultKey), nil
+		} else if returned != nil {
+			return returned.Value, nil
+		} else {
+			return res.MostRecentValue(), nil
 		}
 	}
 
 	return nil, fmt.Errorf("could not locate script %q", scriptName)
 }
 
+// Return the candidate paths (in order of preference) that the named script may be found at.  The
+// directory of the calling script (basePath) is checked first, followed by each of the directories
+// in the FRIENDSCRIPT_PATH environment variable.
+func scriptSearchPaths(scriptName string, basePath string) []string {
+	var fsp = os.Getenv(`FRIENDSCRIPT_PATH`)
+	var searchPaths = sliceutil.CompactString(strings.Split(fsp, `:`))
+
+	scriptName = strings.TrimSuffix(scriptName, `.fs`) + `.fs`
+
+	// if the script is an absolute path, then we won't be searching for anything
+	if filepath.IsAbs(scriptName) {
+		return []string{scriptName}
+	}
+
+	// prepend the dirname of the calling script to the searchPaths
+	searchPaths = append([]string{basePath}, searchPaths...)
+
+	// join all the search paths with the candidate script name
+	for i, sp := range searchPaths {
+		searchPaths[i] = filepath.Join(sp, scriptName)
+	}
+
+	return searchPaths
+}
+
 func (self *Environment) replCompleter(d prompt.Document) []prompt.Suggest {
 	suggestions := []prompt.Suggest{}
 	// {Text: "users", Description: "Store the username and age"},
@@ -422,10 +530,14 @@ func (self *Environment) pushScope(scope *scripting.Scope) {
 	// 	log.Debugf("PUSH scope(%d) is ROOT", scope.Level())
 	// }
 
-	if len(self.stack) == 0 || scope != self.Scope() {
+	self.stacklock.Lock()
+
+	if len(self.stack) == 0 || scope != self.stack[len(self.stack)-1] {
 		self.stack = append(self.stack, scope)
 	}
 
+	self.stacklock.Unlock()
+
 	if self.script != nil {
 		self.script.SetScope(self.Scope())
 	}
@@ -434,6 +546,9 @@ func (self *Environment) pushScope(scope *scripting.Scope) {
 }
 
 func (self *Environment) Scope() *scripting.Scope {
+	self.stacklock.RLock()
+	defer self.stacklock.RUnlock()
+
 	if len(self.stack) > 0 {
 		return self.stack[len(self.stack)-1]
 	} else {
@@ -441,14 +556,40 @@ func (self *Environment) Scope() *scripting.Scope {
 	}
 }
 
+// Set a variable in the current scope.  Values that cannot be set (e.g.: constants) are logged and
+// otherwise ignored; use Assign to find out why.
 func (self *Environment) Set(key string, value any) {
 	self.Scope().Set(key, value)
 }
 
+// Set a variable in the current scope, returning an error if it cannot be set (e.g.: it is a constant.)
+func (self *Environment) Assign(key string, value any) error {
+	return self.Scope().Assign(key, value)
+}
+
 func (self *Environment) Get(key string, fallback ...any) any {
 	return self.Scope().Get(key, fallback...)
 }
 
+// Enable or disable strict mode, in which interpolating an undefined variable in a string is an error.
+func (self *Environment) SetStrict(strict bool) {
+	self.stacklock.RLock()
+	defer self.stacklock.RUnlock()
+
+	self.stack[0].SetStrict(strict)
+}
+
+// Set a read-only variable in the root scope of the environment.  Scripts cannot assign to (or unset)
+// it, but can read it like any other variable.
+func (self *Environment) SetConstant(key string, value any) error {
+	self.stacklock.RLock()
+	var root = self.stack[0]
+	self.stacklock.RUnlock()
+
+	return root.SetConstant(key, value)
+}
+
+// Set several variables in the current scope (see Set.)
 func (self *Environment) SetData(data map[string]any) {
 	for k, v := range data {
 		self.Set(k, v)
@@ -456,9 +597,12 @@ func (self *Environment) SetData(data map[string]any) {
 }
 
 func (self *Environment) popScope() *scripting.Scope {
+	self.stacklock.Lock()
+
 	if len(self.stack) > 1 {
 		top := self.stack[len(self.stack)-1]
 		self.stack = self.stack[0 : len(self.stack)-1]
+		self.stacklock.Unlock()
 
 		if self.script != nil {
 			self.script.SetScope(self.Scope())
@@ -468,8 +612,10 @@ func (self *Environment) popScope() *scripting.Scope {
 
 		return top
 	} else if len(self.stack) == 1 {
+		defer self.stacklock.Unlock()
 		return self.stack[0]
 	} else {
+		self.stacklock.Unlock()
 		log.Fatal("attempted pop on an empty scope stack")
 		return nil
 	}
@@ -478,13 +624,29 @@ func (self *Environment) popScope() *scripting.Scope {
 func (self *Environment) evaluateBlock(block *scripting.Block) error {
 	switch block.Type() {
 	case scripting.EventHandlerBlock:
-		return fmt.Errorf("Not Implemented")
+		return self.evaluateEventHandler(block)
 
 	case scripting.FlowControlWord:
 		if levels := block.FlowBreak(); levels > 0 {
 			return scripting.NewFlowControl(scripting.FlowBreak, levels)
 		} else if levels := block.FlowContinue(); levels > 0 {
 			return scripting.NewFlowControl(scripting.FlowContinue, levels)
+		} else if block.IsReturn() {
+			if value, err := block.ReturnValue(); err == nil {
+				var fc = scripting.NewFlowControl(scripting.FlowReturn, 0)
+				fc.Value = value
+				return fc
+			} else {
+				return err
+			}
+		} else if block.IsExit() {
+			if code, err := block.ExitCode(); err == nil {
+				var fc = scripting.NewFlowControl(scripting.FlowExit, 0)
+				fc.Value = code
+				return fc
+			} else {
+				return err
+			}
 		} else {
 			return fmt.Errorf("invalid flow control statement")
 		}
@@ -501,12 +663,20 @@ func (self *Environment) evaluateBlock(block *scripting.Block) error {
 }
 
 func (self *Environment) evaluateStatement(statement *scripting.Statement) error {
+	// stop evaluating once the script has been interrupted (e.g.: by a "timeout" statement)
+	if self.context().Err() != nil {
+		return context.Cause(self.context())
+	}
+
 	switch statement.Type() {
 	case scripting.AssignmentStatement:
-		return self.evaluateAssignment(statement.Assignment(), false)
+		return constantError(statement.SourceContext(), self.evaluateAssignment(statement.Assignment(), false))
+
+	case scripting.ConstantStatement:
+		return constantError(statement.SourceContext(), self.evaluateConstant(statement.Assignment()))
 
 	case scripting.DirectiveStatement:
-		return self.evaluateDirective(statement.Directive())
+		return constantError(statement.SourceContext(), self.evaluateDirective(statement.Directive()))
 
 	case scripting.ConditionalStatement:
 		_, err := self.evaluateConditional(statement.Conditional())
@@ -519,6 +689,30 @@ func (self *Environment) evaluateStatement(statement *scripting.Statement) error
 		_, _, err := self.evaluateCommand(statement.Command(), false)
 		return err
 
+	case scripting.TryCatchStatement:
+		return self.evaluateTryCatch(statement.TryCatch())
+
+	case scripting.MatchStatement:
+		return self.evaluateMatch(statement.Match())
+
+	case scripting.ParallelStatement:
+		return self.evaluateParallel(statement.Parallel())
+
+	case scripting.TimeoutStatement:
+		return self.evaluateTimeout(statement.Timeout())
+
+	case scripting.RetryStatement:
+		return self.evaluateRetry(statement.Retry())
+
+	case scripting.DeferStatement:
+		return self.evaluateDefer(statement.Defer())
+
+	case scripting.TransactionStatement:
+		return self.evaluateTransaction(statement.Transaction())
+
+	case scripting.FunctionStatement:
+		return self.evaluateFunctionDefinition(statement.Function())
+
 	case scripting.NoOpStatement:
 		return nil
 
@@ -530,6 +724,10 @@ func (self *Environment) evaluateStatement(statement *scripting.Statement) error
 func (self *Environment) evaluateAssignment(assignment *scripting.Assignment, forceDeclare bool) error {
 	log.Debugf("ASSN %v", assignment)
 
+	if err := assignment.Err(); err != nil {
+		return err
+	}
+
 	// clear out all the left-hand side variables (if there isn't already one in this scope)
 	if assignment.Operator.ShouldPreclear() {
 		for _, lhs := range assignment.LeftHandSide {
@@ -537,9 +735,38 @@ func (self *Environment) evaluateAssignment(assignment *scripting.Assignment, fo
 				if forceDeclare {
 					scope.Declare(lhs)
 				} else if !scope.SkipPreclear { // this line is erasing variables in a loop before performing the RHS eval
-					scope.Set(lhs, nil)
+					if err := scope.Assign(lhs, nil); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	// destructure objects and rest elements
+	if target := assignment.Target; target != nil && target.IsDestructuring() {
+		if len(assignment.RightHandSide) != 1 {
+			return fmt.Errorf("destructuring assignments require exactly one value, got %d", len(assignment.RightHandSide))
+		}
+
+		if rhs, err := assignment.RightHandSide[0].Value(); err == nil {
+			if names, values, err := target.Unpack(rhs); err == nil {
+				for i, name := range names {
+					if result, err := assignment.Operator.Evaluate(self.Scope().Get(name), values[i]); err == nil {
+						if err := self.Scope().Assign(name, result); err != nil {
+							return err
+						}
+					} else {
+						return err
+					}
 				}
+
+				return nil
+			} else {
+				return err
 			}
+		} else {
+			return err
 		}
 	}
 
@@ -555,7 +782,9 @@ func (self *Environment) evaluateAssignment(assignment *scripting.Assignment, fo
 							self.Scope().Get(assignment.LeftHandSide[i]),
 							rhs,
 						); err == nil {
-							self.Scope().Set(assignment.LeftHandSide[i], result)
+							if err := self.Scope().Assign(assignment.LeftHandSide[i], result); err != nil {
+								return err
+							}
 						} else {
 							return err
 						}
@@ -573,7 +802,9 @@ func (self *Environment) evaluateAssignment(assignment *scripting.Assignment, fo
 				self.Scope().Get(lhs),
 				assignment.RightHandSide[i],
 			); err == nil {
-				self.Scope().Set(lhs, result)
+				if err := self.Scope().Assign(lhs, result); err != nil {
+					return err
+				}
 			} else {
 				return err
 			}
@@ -586,9 +817,17 @@ func (self *Environment) evaluateAssignment(assignment *scripting.Assignment, fo
 func (self *Environment) evaluateDirective(directive *scripting.Directive) error {
 	switch directive.Type() {
 	case scripting.UnsetDirective:
-		return fmt.Errorf("'unset' not implemented yet")
+		if keys, err := directive.VariableKeys(); err == nil {
+			for _, key := range keys {
+				if err := self.Scope().Unset(key); err != nil {
+					return err
+				}
+			}
+		} else {
+			return err
+		}
 	case scripting.IncludeDirective:
-		return fmt.Errorf("'include' not implemented yet")
+		return self.evaluateInclude(directive)
 	case scripting.DeclareDirective:
 		for _, varname := range directive.VariableNames() {
 			self.Scope().Declare(varname)
@@ -606,6 +845,11 @@ func (self *Environment) ExecuteCommand(command *scripting.Command) (string, any
 func (self *Environment) evaluateCommand(command *scripting.Command, forceDeclare bool) (string, any, error) {
 	var modname, name = command.Name()
 
+	// functions defined in scripts can be called without qualifying them with a module name
+	if modname == scripting.UnqualifiedModuleName && self.functions.Has(name) {
+		modname = ScriptModuleName
+	}
+
 	// prevent the execution of disabled commands
 	if reject, _ := self.filterCommands[modname+scripting.CommandSeparator+name]; reject {
 		return ``, nil, fmt.Errorf("Execution of the %s::%s command has been disabled", modname, name)
@@ -617,7 +861,13 @@ func (self *Environment) evaluateCommand(command *scripting.Command, forceDeclar
 		log.Debugf("CMD %v::%v", modname, name)
 	}
 
-	var ctx = command.SourceContext()
+	// each evaluation labels a copy of the command's context, since the same command can be running in
+	// several branches of a parallel statement at once
+	var source = *command.SourceContext()
+	var ctx = &source
+
+	ctx.Label = modname + scripting.CommandSeparator + name
+	ctx.Branch = self.branch
 	self.sendContextUpdate(ctx, false)
 
 	if first, rest, err := command.Args(); err == nil {
@@ -628,12 +878,23 @@ func (self *Environment) evaluateCommand(command *scripting.Command, forceDeclar
 			// tell that module to execute the command, giving it the name and arguments
 			var evalscope = self.Scope()
 
+			var result any
+			var err error
+
 			evalscope.LockContext(ctx)
-			result, err := module.ExecuteCommand(name, first, rest)
+
+			// functions are evaluated in this environment, which may be a branch of a parallel statement
+			if fnmodule, ok := module.(*functionModule); ok {
+				result, err = fnmodule.call(self, name, first, rest)
+			} else {
+				result, err = utils.ExecuteCommandContext(self.context(), module, name, first, rest)
+			}
+
 			evalscope.Unlock()
 
 			if err == nil {
 				self.sendContextUpdate(ctx, true)
+				self.recordUndo(module, command, first, rest, result)
 				// log.Debugf("CMND returned %T(%v)", result, result)
 
 				// if there is an output variable destination, set that in the current scope
@@ -642,12 +903,27 @@ func (self *Environment) evaluateCommand(command *scripting.Command, forceDeclar
 						evalscope.Declare(resultVar)
 					}
 
-					evalscope.Set(resultVar, result)
+					if err := evalscope.Assign(resultVar, result); err != nil {
+						return ``, nil, constantError(ctx, err)
+					}
 
 					return resultVar, result, nil
+				} else if target, err := command.OutputTarget(); err != nil {
+					return ``, nil, scripting.NewContextError(ctx, err)
+				} else if target != nil {
+					// results assigned to several variables are unpacked into them
+					if err := unpackInto(evalscope, target, result, forceDeclare); errors.Is(err, scripting.ErrConstant) {
+						return ``, nil, constantError(ctx, err)
+					} else if err != nil {
+						return ``, nil, scripting.NewContextError(ctx, err)
+					}
 				}
 
 				return ``, result, nil
+			} else if fc, ok := err.(*scripting.FlowControlErr); ok && fc.Type == scripting.FlowExit {
+				// scripts that exit (e.g.: ones being run by "run") stop everything that is running them
+				self.sendContextUpdate(ctx, true)
+				return ``, nil, fc
 			} else {
 				ctx.Error = err
 			}
@@ -659,12 +935,21 @@ func (self *Environment) evaluateCommand(command *scripting.Command, forceDeclar
 	}
 
 	self.sendContextUpdate(ctx, true)
-	return ``, nil, ctx.Error
+
+	// errors are annotated with the context of the command that caused them, unless they already
+	// were (e.g.: a command failing inside of a function)
+	var cerr *scripting.ContextError
+
+	if errors.As(ctx.Error, &cerr) {
+		return ``, nil, ctx.Error
+	} else if errors.Is(ctx.Error, scripting.ErrConstant) {
+		return ``, nil, constantError(ctx, ctx.Error)
+	} else {
+		return ``, nil, scripting.NewContextError(ctx, ctx.Error)
+	}
 }
 
 func (self *Environment) evaluateConditional(conditional *scripting.Conditional) (bool, error) {
-	var blocks = make([]*scripting.Block, 0)
-	var takeTrueBranch bool
 	var conditionScope = scripting.NewScope(self.Scope())
 
 	conditionScope.SkipPreclear = true
@@ -672,90 +957,86 @@ func (self *Environment) evaluateConditional(conditional *scripting.Conditional)
 	self.pushScope(conditionScope)
 	defer self.popScope()
 
-	switch conditional.Type() {
-	case scripting.ConditionWithAssignment:
-		assignment, condition := conditional.WithAssignment()
-
-		if err := self.evaluateAssignment(assignment, true); err == nil {
-			result := condition.IsTrue()
-			blocks, takeTrueBranch = self.evaluateConditionalGetBranch(conditional, result)
-		} else {
-			return takeTrueBranch, err
-		}
-
-	case scripting.ConditionWithCommand:
-		command, condition := conditional.WithCommand()
-
-		if _, _, err := self.evaluateCommand(command, true); err == nil {
-			result := condition.IsTrue()
-			blocks, takeTrueBranch = self.evaluateConditionalGetBranch(conditional, result)
-		} else {
-			return takeTrueBranch, err
-		}
-
-	case scripting.ConditionWithRegex:
-		expression, matchOp, rx := conditional.WithRegex()
-		result := matchOp.Evaluate(rx, expression)
-		blocks, takeTrueBranch = self.evaluateConditionalGetBranch(conditional, result)
-
-	case scripting.ConditionWithComparator:
-		lhs, cmp, rhs := conditional.WithComparator()
-
-		result := cmp.Evaluate(lhs, rhs)
-		blocks, takeTrueBranch = self.evaluateConditionalGetBranch(conditional, result)
+	if blocks, takeTrueBranch, err := self.evaluateConditionalGetBranch(conditional); err == nil {
+		return takeTrueBranch, self.evaluateBlocks(blocks)
+	} else {
+		return false, err
+	}
+}
 
-	default:
-		return takeTrueBranch, fmt.Errorf("Unrecognized Conditional type")
+// Determines which branch of the given conditional should be taken (evaluating the conditions of any
+// else-if branches as necessary), returning the blocks in that branch.
+func (self *Environment) evaluateConditionalGetBranch(conditional *scripting.Conditional) ([]*scripting.Block, bool, error) {
+	if result, err := self.evaluateCondition(conditional.Condition()); err != nil {
+		return nil, false, err
+	} else if result {
+		return conditional.IfBlocks(), true, nil
 	}
 
-	for _, block := range blocks {
-		if err := self.evaluateBlock(block); err != nil {
-			return takeTrueBranch, err
+	for _, elif := range conditional.ElseIfConditions() {
+		if result, err := self.evaluateCondition(elif.Condition()); err != nil {
+			return nil, false, err
+		} else if result {
+			return elif.IfBlocks(), false, nil
 		}
 	}
 
-	return takeTrueBranch, nil
+	return conditional.ElseBlocks(), false, nil
 }
 
-func (self *Environment) evaluateConditionalGetBranch(conditional *scripting.Conditional, result bool) ([]*scripting.Block, bool) {
-	var blocks = make([]*scripting.Block, 0)
-	var takeTrueBranch bool
+// Evaluates a conditional expression, performing any assignments or executing any commands that are
+// part of it before testing the result.
+func (self *Environment) evaluateCondition(condition *scripting.ConditionalExpression) (bool, error) {
+	var result bool
 
-	if conditional.IsNegated() {
-		result = !result
+	if condition == nil {
+		return false, fmt.Errorf("malformed conditional expression")
 	}
 
-	if result {
-		blocks = conditional.IfBlocks()
-		takeTrueBranch = true
-	} else {
-		var tookElifBranch bool
+	switch condition.Type() {
+	case scripting.ConditionWithAssignment:
+		assignment, next := condition.WithAssignment()
 
-		for _, elif := range conditional.ElseIfConditions() {
-			if t, err := self.evaluateConditional(elif); err == nil {
-				if t {
-					tookElifBranch = true
-					blocks = elif.IfBlocks()
-					break
-				}
-			} else {
-				log.Fatal(err)
-				return nil, false
-			}
+		if err := self.evaluateAssignment(assignment, true); err != nil {
+			return false, err
+		} else if r, err := self.evaluateCondition(next); err == nil {
+			result = r
+		} else {
+			return false, err
 		}
 
-		if !tookElifBranch {
-			blocks = conditional.ElseBlocks()
+	case scripting.ConditionWithCommand:
+		command, next := condition.WithCommand()
+
+		if _, value, err := self.evaluateCommand(command, true); err != nil {
+			return false, err
+		} else if next == nil {
+			result = scripting.IsTruthy(value)
+		} else if r, err := self.evaluateCondition(next); err == nil {
+			result = r
+		} else {
+			return false, err
 		}
+
+	default:
+		// regex, comparator, and logical expressions account for their own negation
+		return condition.Evaluate()
 	}
 
-	return blocks, takeTrueBranch
+	if condition.IsNegated() {
+		result = !result
+	}
+
+	return result, nil
 }
 
 func (self *Environment) evaluateLoop(loop *scripting.Loop) error {
 	var sourceVar string
 	var destVars []string
-	var loopScope = scripting.NewScope(self.Scope())
+	var destTarget *scripting.Target
+	var iterRange *scripting.Range
+	var outerScope = self.Scope()
+	var loopScope = scripting.NewScope(outerScope)
 
 	loopScope.Declare(`index`)
 	loopScope.Declare(`index0`)
@@ -766,52 +1047,70 @@ func (self *Environment) evaluateLoop(loop *scripting.Loop) error {
 
 	// if we have an iterator we have to retrieve the values
 	if loop.Type() == scripting.IteratorLoop {
+		if target, err := loop.IterationTarget(); err == nil {
+			destTarget = target
+		} else {
+			return err
+		}
+
+		// ranges are evaluated once, and their values are generated as the loop runs
+		if rng, err := loop.IterationRange(); err == nil {
+			iterRange = rng
+		} else {
+			return err
+		}
+
 		if s, d, err := self.evaluateLoopIterationStart(loop, loopScope); err == nil {
 			sourceVar = s
 			destVars = d
 		} else {
 			return err
 		}
+
+		// the values iterated over by parallel loops are retrieved once, before any iterations start
+		if loop.IsParallel() {
+			return self.evaluateParallelLoop(loop, outerScope, loopScope, iterationVector(loopScope, sourceVar, iterRange), destVars, destTarget)
+		}
+	}
+
+	// condition-bounded loops run their first command once before the loop begins, and their
+	// second command after every iteration
+	var initCommand, stepCommand = loop.BoundingCommands()
+
+	if initCommand != nil {
+		if _, _, err := self.evaluateCommand(initCommand, true); err != nil {
+			return err
+		}
 	}
 
 LoopEval:
 	for {
+		if self.context().Err() != nil {
+			return context.Cause(self.context())
+		}
+
 		if i, proceed := loop.Iterate(); proceed {
-			if loop.Type() == scripting.IteratorLoop {
-				var iterVector = loopScope.Get(sourceVar)
-
-				if typeutil.IsMap(iterVector) {
-					var remap = make([][]any, 0)
-					var keys = maputil.StringKeys(iterVector)
-					sort.Strings(keys)
-
-					for _, key := range keys {
-						remap = append(remap, []any{
-							key,
-							maputil.Get(iterVector, key),
-						})
-					}
+			if stepCommand != nil && i > 0 {
+				if _, _, err := self.evaluateCommand(stepCommand, true); err != nil {
+					return err
+				}
+			}
 
-					iterVector = remap
+			if condition := loop.Condition(); condition != nil {
+				if result, err := self.evaluateCondition(condition); err != nil {
+					return err
+				} else if !result {
+					break
 				}
+			}
 
-				if iterLen := sliceutil.Len(iterVector); i < iterLen {
-					if iterItem, ok := sliceutil.At(iterVector, i); ok {
-						var didSet bool
-
-						if totalLhsCount := len(destVars); totalLhsCount > 1 {
-							if typeutil.IsArray(iterItem) {
-								for j, rhs := range sliceutil.Sliceify(iterItem) {
-									if j < totalLhsCount {
-										loopScope.Set(destVars[j], rhs)
-										didSet = true
-									}
-								}
-							}
-						}
+			if loop.Type() == scripting.IteratorLoop {
+				var iterVector = iterationVector(loopScope, sourceVar, iterRange)
 
-						if !didSet {
-							loopScope.Set(destVars[0], iterItem)
+				if iterLen := iterationLen(iterVector); i < iterLen {
+					if iterItem, ok := iterationAt(iterVector, i); ok {
+						if err := assignIteration(loopScope, destVars, destTarget, iterItem, false); err != nil {
+							return err
 						}
 					} else {
 						return fmt.Errorf("Failed to retrieve iterator item %d", i)
@@ -821,30 +1120,30 @@ LoopEval:
 				}
 			}
 
-			loopScope.Set(`index`, i)
-			loopScope.Set(`index0`, i-1)
-
-			for _, block := range loop.Blocks() {
-				if err := self.evaluateBlock(block); err != nil {
-					if fc, ok := err.(*scripting.FlowControlErr); ok {
-						if fc.Level <= 0 {
-							return fc
-						} else if fc.Level == 1 {
-							if fc.Type == scripting.FlowContinue {
-								continue LoopEval
-							} else {
-								break LoopEval
-							}
+			if err := loopScope.Assign(`index`, i); err != nil {
+				return err
+			} else if err := loopScope.Assign(`index0`, i-1); err != nil {
+				return err
+			}
+
+			if err := self.evaluateBlocks(loop.Blocks()); err != nil {
+				if fc, ok := err.(*scripting.FlowControlErr); ok {
+					if fc.Level <= 0 {
+						return fc
+					} else if fc.Level == 1 {
+						if fc.Type == scripting.FlowContinue {
+							continue LoopEval
 						} else {
-							fc.Level = fc.Level - 1
-							return fc
+							break LoopEval
 						}
 					} else {
-						return err
+						fc.Level = fc.Level - 1
+						return fc
 					}
+				} else {
+					return err
 				}
 			}
-
 		} else {
 			break
 		}
@@ -853,6 +1152,201 @@ LoopEval:
 	return nil
 }
 
+// Evaluates a try/catch/finally statement.  Errors raised in the "try" stanza are passed to the "catch"
+// stanza (if any), and the "finally" stanza is always evaluated last, regardless of whether any errors
+// occurred.  Flow control statements (e.g.: break, continue, return) are not considered errors, and
+// pass through the statement unaffected (after the "finally" stanza is evaluated.)
+func (self *Environment) evaluateTryCatch(trycatch *scripting.TryCatch) (err error) {
+	if finally := trycatch.FinallyBlocks(); len(finally) > 0 {
+		defer func() {
+			if ferr := self.evaluateScopedBlocks(finally, nil); ferr != nil {
+				err = ferr
+			}
+		}()
+	}
+
+	err = self.evaluateScopedBlocks(trycatch.TryBlocks(), nil)
+
+	if _, ok := err.(*scripting.FlowControlErr); err == nil || ok {
+		return err
+	} else if !trycatch.HasCatch() {
+		return err
+	}
+
+	if varname, verr := trycatch.CatchVariable(); verr == nil {
+		return self.evaluateScopedBlocks(trycatch.CatchBlocks(), func(scope *scripting.Scope) error {
+			if varname != `` {
+				scope.Declare(varname)
+				return scope.Assign(varname, errorToMap(err))
+			}
+
+			return nil
+		})
+	} else {
+		return verr
+	}
+}
+
+// Evaluates the blocks of the first arm of a match statement whose pattern matches the value being
+// matched.  If none of the arms match, nothing happens.
+func (self *Environment) evaluateMatch(match *scripting.Match) error {
+	if blocks, err := match.Branch(); err == nil {
+		return self.evaluateScopedBlocks(blocks, nil)
+	} else {
+		return err
+	}
+}
+
+// Evaluates the given blocks in a new scope, calling the setup function (if given) on the scope first.
+func (self *Environment) evaluateScopedBlocks(blocks []*scripting.Block, setup func(scope *scripting.Scope) error) error {
+	var scope = scripting.NewScope(self.Scope())
+
+	scope.SkipPreclear = true
+
+	if setup != nil {
+		if err := setup(scope); err != nil {
+			return err
+		}
+	}
+
+	self.pushScope(scope)
+	defer self.popScope()
+
+	return self.evaluateBlocks(blocks)
+}
+
+// Describe the given error as an object, including details about the command that raised it (if known).
+func errorToMap(err error) map[string]any {
+	var out = map[string]any{
+		`message`:  err.Error(),
+		`module`:   nil,
+		`command`:  nil,
+		`snippet`:  nil,
+		`line`:     nil,
+		`filename`: nil,
+	}
+
+	var cerr *scripting.ContextError
+
+	if errors.As(err, &cerr) && cerr.Context != nil {
+		var ctx = cerr.Context
+
+		if ctx.Type == scripting.CommandContext {
+			module, command := stringutil.SplitPair(ctx.Label, scripting.CommandSeparator)
+			out[`module`] = module
+			out[`command`] = command
+		}
+
+		if snippet := ctx.Snippet(); snippet != `` {
+			out[`snippet`] = strings.TrimSpace(snippet)
+		}
+
+		if line := ctx.Line(); line > 0 {
+			out[`line`] = line
+		}
+
+		if filename := ctx.Filename; filename != `` {
+			out[`filename`] = filename
+		}
+	}
+
+	return out
+}
+
+// Return the value being iterated over by a loop: either a range, or the value of the given variable.
+// Objects are iterated over as an array of [key, value] pairs, sorted by key.
+func iterationVector(scope *scripting.Scope, sourceVar string, iterRange *scripting.Range) any {
+	if iterRange != nil {
+		return iterRange
+	}
+
+	var iterVector = scope.Get(sourceVar)
+
+	if typeutil.IsMap(iterVector) {
+		var remap = make([][]any, 0)
+		var keys = maputil.StringKeys(iterVector)
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			remap = append(remap, []any{
+				key,
+				maputil.Get(iterVector, key),
+			})
+		}
+
+		iterVector = remap
+	}
+
+	return iterVector
+}
+
+// Assign an item being iterated over to the loop variable(s) in the given scope.  Items are destructured
+// into destructuring targets, and arrays are unpacked into loops with several variables.
+func assignIteration(scope *scripting.Scope, destVars []string, destTarget *scripting.Target, item any, declare bool) error {
+	if destTarget != nil && destTarget.IsDestructuring() {
+		return unpackInto(scope, destTarget, item, declare)
+	}
+
+	if declare {
+		for _, v := range destVars {
+			scope.Declare(v)
+		}
+	}
+
+	if totalLhsCount := len(destVars); totalLhsCount > 1 && typeutil.IsArray(item) {
+		if items := sliceutil.Sliceify(item); len(items) > 0 {
+			for j, rhs := range items {
+				if j < totalLhsCount {
+					if err := scope.Assign(destVars[j], rhs); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		}
+	}
+
+	return scope.Assign(destVars[0], item)
+}
+
+// Return the number of items in a value being iterated over by a loop.
+func iterationLen(vector any) int {
+	if rng, ok := vector.(*scripting.Range); ok {
+		return rng.Len()
+	}
+
+	return sliceutil.Len(vector)
+}
+
+// Return the item at the given position in a value being iterated over by a loop.
+func iterationAt(vector any, i int) (any, bool) {
+	if rng, ok := vector.(*scripting.Range); ok {
+		return rng.At(i), (i < rng.Len())
+	}
+
+	return sliceutil.At(vector, i)
+}
+
+// Unpack the given value into the variables of a (possibly destructuring) target in the given scope.
+func unpackInto(scope *scripting.Scope, target *scripting.Target, value any, declare bool) error {
+	if names, values, err := target.Unpack(value); err == nil {
+		for i, name := range names {
+			if declare {
+				scope.Declare(name)
+			}
+
+			if err := scope.Assign(name, values[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	} else {
+		return err
+	}
+}
+
 func (self *Environment) evaluateLoopIterationStart(loop *scripting.Loop, scope *scripting.Scope) (string, []string, error) {
 	var destVars, source = loop.IteratableParts()
 	var sourceVar string
@@ -881,10 +1375,12 @@ func (self *Environment) evaluateLoopIterationStart(loop *scripting.Loop, scope
 }
 
 func (self *Environment) sendContextUpdate(ctx *scripting.Context, isDone bool) {
-	self.chlock.Lock()
-	defer self.chlock.Unlock()
+	var root = self.root()
+
+	root.chlock.Lock()
+	defer root.chlock.Unlock()
 
-	for _, handler := range self.contextHandlers {
+	for _, handler := range root.contextHandlers {
 		handler(ctx, isDone)
 	}
 }
@@ -0,0 +1,55 @@
+package friendscript
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ghetzel/friendscript/scripting"
+)
+
+// Evaluates a constant declaration (e.g.: "const $api_base = 'https://example.com'"), which works like
+// an assignment to variables in the current scope that cannot be assigned to (or unset) afterwards.
+func (self *Environment) evaluateConstant(assignment *scripting.Assignment) error {
+	if err := assignment.Err(); err != nil {
+		return err
+	}
+
+	for _, name := range assignment.LeftHandSide {
+		if self.Scope().IsConstant(name) {
+			return fmt.Errorf("%w $%v", scripting.ErrConstant, name)
+		}
+	}
+
+	// the values are assigned in a scope of their own first so that destructuring (and errors) behave
+	// exactly the same as they do for any other assignment
+	var values = scripting.NewLocalScope(self.Scope())
+
+	self.pushScope(values)
+	var err = self.evaluateAssignment(assignment, true)
+	self.popScope()
+
+	if err != nil {
+		return err
+	}
+
+	for _, name := range assignment.LeftHandSide {
+		if err := self.Scope().SetConstant(name, values.Get(name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Annotates an error caused by assigning to a constant with the line and source of the statement that
+// tried to do so.  Other errors are returned as-is.
+func constantError(ctx *scripting.Context, err error) error {
+	var cerr *scripting.ContextError
+
+	if !errors.Is(err, scripting.ErrConstant) || errors.As(err, &cerr) {
+		return err
+	}
+
+	return scripting.NewContextError(ctx, fmt.Errorf("line %d: %w: %s", ctx.Line(), err, strings.TrimSpace(ctx.Snippet())))
+}
@@ -0,0 +1,139 @@
+package friendscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ghetzel/friendscript/scripting"
+	"github.com/ghetzel/go-stockutil/log"
+)
+
+// The error returned by Evaluate when the script is interrupted (see Interrupt).
+var ErrInterrupted = errors.New(`interrupted`)
+
+// Interrupts the script being evaluated (e.g.: when the user presses Ctrl+C.)  Running commands are
+// cancelled (if they support it) and no more statements are evaluated, but deferred statements are, after
+// which Evaluate returns ErrInterrupted.  Does nothing if no script is being evaluated.
+func (self *Environment) Interrupt() {
+	self.stacklock.RLock()
+	defer self.stacklock.RUnlock()
+
+	if self.interrupt != nil {
+		self.interrupt(ErrInterrupted)
+	}
+}
+
+// A statement whose evaluation has been deferred until the blocks it appears in finish, along with the
+// scope it is evaluated in.
+type deferred struct {
+	statement *scripting.Defer
+	scope     *scripting.Scope
+}
+
+// Evaluates the given blocks in order, stopping at the first one that fails.  Statements deferred by
+// the blocks are evaluated once they finish (whether or not they failed), in the reverse of the order
+// they were deferred in.  If the blocks failed, that error is returned (and errors from deferred
+// statements are logged); otherwise the first error from a deferred statement is.
+func (self *Environment) evaluateBlocks(blocks []*scripting.Block) (err error) {
+	self.stacklock.Lock()
+	self.defers = append(self.defers, nil)
+	self.stacklock.Unlock()
+
+	defer func() {
+		// flow control (e.g.: "return") is not a failure, so deferred statements that fail take precedence
+		if _, ok := err.(*scripting.FlowControlErr); err == nil || ok {
+			if derr := self.evaluateDeferred(); derr != nil {
+				err = derr
+			}
+		} else if derr := self.evaluateDeferred(); derr != nil {
+			log.Warningf("friendscript: deferred statement failed: %v", derr)
+		}
+	}()
+
+	for _, block := range blocks {
+		if err = self.evaluateBlock(block); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Saves the given statement to be evaluated when the blocks it appears in finish.
+func (self *Environment) evaluateDefer(statement *scripting.Defer) error {
+	self.stacklock.Lock()
+	defer self.stacklock.Unlock()
+
+	// statements evaluated outside of any blocks (e.g.: ExecuteCommand) have nothing to wait for
+	if len(self.defers) == 0 {
+		return fmt.Errorf("defer can only be used inside of a script")
+	}
+
+	var frame = len(self.defers) - 1
+
+	self.defers[frame] = append(self.defers[frame], &deferred{
+		statement: statement,
+		scope:     self.stack[len(self.stack)-1],
+	})
+
+	return nil
+}
+
+// Evaluates the statements deferred by the innermost blocks being evaluated, most recently deferred
+// first.  They are evaluated even if the script has been interrupted, and all of them are evaluated
+// even if some fail, in which case the first error is returned and the others are logged.
+func (self *Environment) evaluateDeferred() error {
+	self.stacklock.Lock()
+	var frame = self.defers[len(self.defers)-1]
+	self.defers = self.defers[:len(self.defers)-1]
+	self.stacklock.Unlock()
+
+	if len(frame) == 0 {
+		return nil
+	}
+
+	var outer = self.context()
+	var first error
+
+	self.setContext(context.WithoutCancel(outer))
+	defer self.setContext(outer)
+
+	for i := len(frame) - 1; i >= 0; i-- {
+		if err := self.evaluateDeferredStatement(frame[i]); err != nil {
+			if first == nil {
+				first = err
+			} else {
+				log.Warningf("friendscript: deferred statement failed: %v", err)
+			}
+		}
+	}
+
+	return first
+}
+
+func (self *Environment) evaluateDeferredStatement(d *deferred) error {
+	var err = self.evaluateDetached(d.scope, d.statement.Command(), d.statement.Blocks())
+
+	if fc, ok := err.(*scripting.FlowControlErr); ok {
+		return fmt.Errorf("cannot use %v in a deferred statement", fc)
+	}
+
+	return err
+}
+
+// Evaluates the given command (or if it is nil, the given blocks) in the given scope.  This is for
+// statements that are evaluated somewhere other than where they appear (e.g.: deferred statements.)
+func (self *Environment) evaluateDetached(scope *scripting.Scope, command *scripting.Command, blocks []*scripting.Block) error {
+	if scope != self.Scope() {
+		self.pushScope(scope)
+		defer self.popScope()
+	}
+
+	if command != nil {
+		var _, _, err = self.evaluateCommand(command, false)
+		return err
+	} else {
+		return self.evaluateBlocks(blocks)
+	}
+}
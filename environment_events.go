@@ -0,0 +1,218 @@
+package friendscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/ghetzel/friendscript/scripting"
+	"github.com/ghetzel/go-stockutil/log"
+)
+
+// The name of the event emitted when a script fails with an error.
+const ScriptErrorEvent = `script.error`
+
+// The name of the event emitted when a script finishes executing (successfully or otherwise).
+const ScriptExitEvent = `script.exit`
+
+type EventHandlerFunc func(event *Event) error
+
+// Represents a named occurrence, along with any data relevant to it.  Events are delivered to
+// "on" blocks in scripts (as the $event variable) and to any registered EventHandlerFuncs.
+type Event struct {
+	Name      string    `json:"name"`
+	Data      any       `json:"data"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func (self *Event) ToMap() map[string]any {
+	return map[string]any{
+		`name`:      self.Name,
+		`data`:      self.Data,
+		`timestamp`: self.Timestamp,
+	}
+}
+
+type eventHandler struct {
+	pattern string
+	script  *scripting.Friendscript
+	offset  int
+	blocks  []*scripting.Block
+	scope   *scripting.Scope
+	fn      EventHandlerFunc
+}
+
+type handlerChainKey struct{}
+
+// Return the event handlers that are running on the call chain of the given context.
+func handlerChain(ctx context.Context) []*eventHandler {
+	if chain, ok := ctx.Value(handlerChainKey{}).([]*eventHandler); ok {
+		return chain
+	}
+
+	return nil
+}
+
+// Registers a function that will be called whenever an event matching the given pattern is emitted.
+// Patterns are either exact event names or shell-style globs (e.g.: "http.*").  Will return an integer
+// that can be used to remove the handler at a later point.  Events emitted by several branches of a
+// parallel statement at once are handled at the same time, so handlers must be safe to call concurrently.
+// Handlers that call Emit themselves start a new call chain, and so must avoid recursing on their own.
+func (self *Environment) RegisterEventHandler(pattern string, handler EventHandlerFunc) int {
+	self.ehlock.Lock()
+	defer self.ehlock.Unlock()
+
+	self.eventHandlers = append(self.eventHandlers, &eventHandler{
+		pattern: pattern,
+		fn:      handler,
+	})
+
+	return len(self.eventHandlers) - 1
+}
+
+// Remove the event handler with the given ID.
+func (self *Environment) UnregisterEventHandler(id int) {
+	self.ehlock.Lock()
+	defer self.ehlock.Unlock()
+
+	if id >= 0 && id < len(self.eventHandlers) {
+		self.eventHandlers[id] = nil
+	}
+}
+
+// Emits the named event, calling all matching event handlers (both those registered from Go and those
+// declared in scripts) in the order they were registered.
+func (self *Environment) Emit(name string, payload any) error {
+	return self.EmitContext(self.context(), name, payload)
+}
+
+// Emits the named event on behalf of the call chain of the given context (e.g.: the context given to a
+// command.)  Handlers that are already running on that call chain will not be called again, so handlers
+// that (directly or indirectly) emit the event they handle will not recurse.  Handlers running on other
+// call chains (e.g.: other branches of a parallel statement) do not prevent them from being called.
+func (self *Environment) EmitContext(ctx context.Context, name string, payload any) error {
+	var event = &Event{
+		Name:      name,
+		Data:      payload,
+		Timestamp: time.Now(),
+	}
+
+	var root = self.root()
+	var chain = handlerChain(ctx)
+
+	root.ehlock.Lock()
+	var handlers = make([]*eventHandler, 0, len(root.eventHandlers))
+
+	for _, handler := range root.eventHandlers {
+		if handler != nil && !slices.Contains(chain, handler) {
+			if ok, _ := path.Match(handler.pattern, name); ok || handler.pattern == name {
+				handlers = append(handlers, handler)
+			}
+		}
+	}
+	root.ehlock.Unlock()
+
+	log.Debugf("EMIT %v (%d handlers)", name, len(handlers))
+
+	for _, handler := range handlers {
+		if err := self.dispatchEvent(ctx, handler, event); err != nil {
+			return fmt.Errorf("%v handler: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Registers the body of an "on" block as the handler for its event.  Handlers capture the scope they
+// were declared in, so re-evaluating the same block (e.g.: in a loop) replaces the existing handler.
+func (self *Environment) evaluateEventHandler(block *scripting.Block) error {
+	var pattern = block.EventName()
+	var offset = block.SourceContext().AbsoluteStartOffset
+
+	if pattern == `` {
+		return fmt.Errorf("event handlers must specify an event name")
+	}
+
+	var root = self.root()
+
+	root.ehlock.Lock()
+	defer root.ehlock.Unlock()
+
+	for _, handler := range root.eventHandlers {
+		if handler != nil && handler.script != nil && handler.script.Origin() == block.Script().Origin() && handler.offset == offset {
+			handler.pattern = pattern
+			handler.scope = self.Scope()
+			return nil
+		}
+	}
+
+	root.eventHandlers = append(root.eventHandlers, &eventHandler{
+		pattern: pattern,
+		script:  block.Script(),
+		offset:  offset,
+		blocks:  block.EventBlocks(),
+		scope:   self.Scope(),
+	})
+
+	return nil
+}
+
+// Calls the given handler on the call chain of the given context, which it is added to for as long as
+// it runs.
+func (self *Environment) dispatchEvent(ctx context.Context, handler *eventHandler, event *Event) error {
+	var chain = handlerChain(ctx)
+
+	ctx = context.WithValue(ctx, handlerChainKey{}, append(slices.Clip(chain), handler))
+
+	if handler.fn != nil {
+		return handler.fn(event)
+	}
+
+	var scope = scripting.NewScope(handler.scope)
+
+	scope.Declare(`event`)
+
+	if err := scope.Assign(`event`, event.ToMap()); err != nil {
+		return err
+	}
+
+	// events can be emitted from several branches of a parallel statement at once, so handlers are
+	// always evaluated in a branch of their own
+	var branch = self.fork(handler.script, scope, ``)
+
+	branch.runctx = ctx
+
+	return branch.evaluateBlocks(blocksInScript(handler.blocks, branch.script))
+}
+
+// Emits the script.error (if the script failed) and script.exit events for the given script.
+// Errors returned from these handlers are logged rather than returned, since the script has
+// already finished by the time they are called.
+func (self *Environment) emitScriptEvents(script *scripting.Friendscript, scriptErr error) {
+	var exit *ExitError
+
+	// scripts that exit with a status of zero succeeded
+	if errors.As(scriptErr, &exit) && exit.Code == 0 {
+		scriptErr = nil
+	}
+
+	var data = map[string]any{
+		`filename`: script.Filename(),
+		`success`:  (scriptErr == nil),
+	}
+
+	if scriptErr != nil {
+		data[`error`] = scriptErr.Error()
+
+		if err := self.Emit(ScriptErrorEvent, data); err != nil {
+			log.Warningf("friendscript: %v", err)
+		}
+	}
+
+	if err := self.Emit(ScriptExitEvent, data); err != nil {
+		log.Warningf("friendscript: %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+package friendscript
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ghetzel/friendscript/scripting"
+	"github.com/ghetzel/go-stockutil/typeutil"
+)
+
+// The name of the module that functions defined in scripts (via "def") are registered to.  These
+// functions can also be called without qualifying them with this module name.
+var ScriptModuleName = `script`
+
+// The maximum depth that function calls can be nested to before failing; this prevents runaway
+// recursion from exhausting the stack.
+var MaxFunctionCallDepth = 256
+
+type scriptFunction struct {
+	definition *scripting.Function
+	script     *scripting.Friendscript
+	scope      *scripting.Scope
+}
+
+// A module whose commands are the functions defined by the scripts running in an Environment.
+type functionModule struct {
+	env       *Environment
+	functions map[string]*scriptFunction
+	fnlock    sync.RWMutex
+}
+
+func newFunctionModule(env *Environment) *functionModule {
+	return &functionModule{
+		env:       env,
+		functions: make(map[string]*scriptFunction),
+	}
+}
+
+func (self *functionModule) SetInstance(any) {}
+
+func (self *functionModule) FormatCommandName(name string) string {
+	return name
+}
+
+func (self *functionModule) CommandNames() []string {
+	self.fnlock.RLock()
+	defer self.fnlock.RUnlock()
+
+	var names = make([]string, 0, len(self.functions))
+
+	for name := range self.functions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func (self *functionModule) Has(name string) bool {
+	self.fnlock.RLock()
+	defer self.fnlock.RUnlock()
+
+	_, ok := self.functions[name]
+	return ok
+}
+
+func (self *functionModule) ExecuteCommand(name string, arg any, objargs map[string]any) (any, error) {
+	return self.call(self.env, name, arg, objargs)
+}
+
+// Calls the named function in the given environment (which may be a branch of a parallel statement
+// being evaluated by this module's environment.)
+func (self *functionModule) call(env *Environment, name string, arg any, objargs map[string]any) (any, error) {
+	self.fnlock.RLock()
+	fn, ok := self.functions[name]
+	self.fnlock.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("function %q is not defined", name)
+	} else if env.calldepth >= MaxFunctionCallDepth {
+		return nil, fmt.Errorf("%s: maximum call depth (%d) exceeded", name, MaxFunctionCallDepth)
+	}
+
+	env.calldepth += 1
+	defer func() {
+		env.calldepth -= 1
+	}()
+
+	return env.callFunction(fn, arg, objargs)
+}
+
+func (self *functionModule) define(fn *scriptFunction) {
+	self.fnlock.Lock()
+	defer self.fnlock.Unlock()
+
+	self.functions[fn.definition.Name()] = fn
+}
+
+// Defines the function described by the given statement, making it callable as a command.  Functions
+// capture the scope they were defined in, and defining a function with an existing name replaces it.
+func (self *Environment) evaluateFunctionDefinition(definition *scripting.Function) error {
+	if definition.Name() == `` {
+		return fmt.Errorf("functions must have a name")
+	}
+
+	self.functions.define(&scriptFunction{
+		definition: definition,
+		script:     definition.Statement().Script(),
+		scope:      self.Scope(),
+	})
+
+	return nil
+}
+
+// Calls the given function.  The function body is evaluated in a new scope inheriting from the scope
+// the function was defined in, and whose writes remain local to the function call.  The first argument
+// (if any) is assigned to the function's argument variable, and each of the function's options is
+// assigned to a variable of the same name (using the default value declared in the function if the
+// caller did not provide one).
+func (self *Environment) callFunction(fn *scriptFunction, arg any, objargs map[string]any) (any, error) {
+	var name = fn.definition.Name()
+	var parentScript = self.script
+	var scope = scripting.NewLocalScope(fn.scope)
+	var script = fn.script
+
+	// branches of parallel statements evaluate functions in their own copy of the script
+	if self.parent != nil {
+		script = script.Fork(scope)
+	}
+
+	self.script = script
+	self.pushScope(scope)
+
+	defer func() {
+		self.popScope()
+		self.script = parentScript
+
+		if parentScript != nil {
+			parentScript.SetScope(self.Scope())
+		}
+	}()
+
+	argname, err := fn.definition.ArgumentName()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	// commands given only an object collapse it into the first argument, so if this function
+	// only accepts options, treat it as such.
+	if argname == `` && objargs == nil && typeutil.IsMap(arg) && fn.definition.HasOptions() {
+		objargs, arg = typeutil.MapNative(arg), nil
+	}
+
+	if argname != `` {
+		scope.Declare(argname)
+
+		if err := scope.Assign(argname, arg); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	} else if arg != nil {
+		return nil, fmt.Errorf("%s: function does not accept an argument", name)
+	}
+
+	if options, err := fn.definition.Options(); err == nil {
+		for key, value := range objargs {
+			if _, ok := options[key]; ok {
+				options[key] = value
+			} else {
+				return nil, fmt.Errorf("%s: unknown option %q", name, key)
+			}
+		}
+
+		for key, value := range options {
+			scope.Declare(key)
+
+			if err := scope.Assign(key, value); err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	if err := self.evaluateBlocks(blocksInScript(fn.definition.Blocks(), script)); err != nil {
+		if fc, ok := err.(*scripting.FlowControlErr); ok {
+			if fc.Type == scripting.FlowReturn {
+				return fc.Value, nil
+			} else if fc.Type == scripting.FlowExit {
+				return nil, fc
+			} else {
+				return nil, fmt.Errorf("%s: %v outside of a loop", name, fc)
+			}
+		}
+
+		return nil, err
+	}
+
+	return nil, nil
+}
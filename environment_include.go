@@ -0,0 +1,145 @@
+package friendscript
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ghetzel/friendscript/scripting"
+	"github.com/ghetzel/go-stockutil/fileutil"
+)
+
+// Evaluates the script named by an include directive.  Included scripts are evaluated in the
+// current scope, as if their statements had appeared in place of the directive itself.
+func (self *Environment) evaluateInclude(directive *scripting.Directive) error {
+	var name = directive.IncludePath()
+	var caller = directive.Statement().Script().Filename()
+	var basePath = `.`
+
+	if name == `` {
+		return fmt.Errorf("include: no script specified")
+	}
+
+	if caller != `` {
+		basePath = filepath.Dir(caller)
+	}
+
+	if path, rc, err := self.resolveInclude(name, basePath); err == nil {
+		if rc != nil {
+			defer rc.Close()
+		}
+
+		return self.include(path, rc, caller)
+	} else {
+		return err
+	}
+}
+
+// Locate the script being included.  The name is first given as-is to the registered PathReaders; if
+// one of them claims it, the stream it returns is the script.  Otherwise, the name is searched for on the
+// local filesystem in the same manner as Run().
+func (self *Environment) resolveInclude(name string, basePath string) (string, io.ReadCloser, error) {
+	if rc, err := self.readerFromPathReaders(name); err != nil {
+		return ``, nil, fmt.Errorf("include %s: %v", name, err)
+	} else if rc != nil {
+		return name, rc, nil
+	}
+
+	for _, candidate := range scriptSearchPaths(name, basePath) {
+		if fileutil.IsNonemptyFile(candidate) {
+			if abs, err := filepath.Abs(candidate); err == nil {
+				candidate = abs
+			}
+
+			return candidate, nil, nil
+		}
+	}
+
+	return ``, nil, fmt.Errorf("include: could not locate script %q", name)
+}
+
+// Evaluate the script at the given (resolved) path, failing if doing so would create an include cycle.
+func (self *Environment) include(path string, rc io.ReadCloser, caller string) error {
+	var chain = self.includeChain
+
+	if len(chain) == 0 && caller != `` {
+		if abs, err := filepath.Abs(caller); err == nil {
+			caller = abs
+		}
+
+		chain = []string{caller}
+	}
+
+	if slices.Contains(chain, path) {
+		return fmt.Errorf("include cycle detected: %s", strings.Join(append(slices.Clone(chain), path), ` -> `))
+	}
+
+	script, err := self.loadInclude(path, rc)
+
+	if err != nil {
+		return err
+	}
+
+	var parentScript = self.script
+	var parentChain = self.includeChain
+
+	// branches of parallel statements evaluate included scripts in their own copy of them
+	if self.parent != nil {
+		script = script.Fork(self.Scope())
+	}
+
+	self.includeChain = append(slices.Clone(chain), path)
+	self.script = script
+	script.SetScope(self.Scope())
+
+	defer func() {
+		self.includeChain = parentChain
+		self.script = parentScript
+
+		if parentScript != nil {
+			parentScript.SetScope(self.Scope())
+		}
+	}()
+
+	// "return" ends the included script, rather than the one including it
+	if err := self.evaluateBlocks(script.Blocks()); err != nil {
+		if fc, ok := err.(*scripting.FlowControlErr); ok && fc.Type == scripting.FlowReturn {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Retrieve and parse the script at the given path, reading it from rc if one was already opened for
+// it.  Scripts are only parsed the first time they are included; subsequent includes reuse the parsed script.
+func (self *Environment) loadInclude(path string, rc io.ReadCloser) (*scripting.Friendscript, error) {
+	var root = self.root()
+
+	root.inclock.Lock()
+	defer root.inclock.Unlock()
+
+	if script, ok := root.includes[path]; ok {
+		return script, nil
+	}
+
+	if rc == nil {
+		if r, err := self.GetReaderForPath(path); err == nil {
+			defer r.Close()
+			rc = r
+		} else {
+			return nil, fmt.Errorf("include %s: %v", path, err)
+		}
+	}
+
+	if script, err := scripting.LoadFromReader(path, rc); err == nil {
+		root.includes[path] = script
+		return script, nil
+	} else {
+		return nil, fmt.Errorf("include %s: %v", path, err)
+	}
+}
@@ -46,6 +46,18 @@ func (self *Environment) GetWriterForPath(path string) (string, io.Writer, error
 // the path will be responsible for returning an io.ReadCloser that represents the stream of data being
 // sought.
 func (self *Environment) GetReaderForPath(path string) (io.ReadCloser, error) {
+	if r, err := self.readerFromPathReaders(path); err != nil {
+		return nil, err
+	} else if r != nil {
+		return r, nil
+	}
+
+	return os.Open(path)
+}
+
+// Consults only the registered PathReaderFuncs, returning a nil io.ReadCloser (and no error) if none
+// of them claimed the path.
+func (self *Environment) readerFromPathReaders(path string) (io.ReadCloser, error) {
 	for _, handler := range self.pathReaders {
 		if r, err := handler(path); err == nil {
 			// non-nil RC + nil error = a handled request
@@ -57,7 +69,7 @@ func (self *Environment) GetReaderForPath(path string) (io.ReadCloser, error) {
 		}
 	}
 
-	return os.Open(path)
+	return nil, nil
 }
 
 // Open a readable destination file for reading.  If fileOrReader is a string, it will be treated
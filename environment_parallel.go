@@ -0,0 +1,327 @@
+package friendscript
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ghetzel/friendscript/scripting"
+)
+
+// The number of iterations of a parallel loop that run at the same time, for loops that do not
+// specify a limit (e.g.: "loop parallel $url in $urls").
+var DefaultParallelism = 8
+
+// Return the environment that this one was forked from (see fork), or this environment if it is not
+// a branch of a parallel statement.
+func (self *Environment) root() *Environment {
+	for self.parent != nil {
+		self = self.parent
+	}
+
+	return self
+}
+
+// The error returned by a parallel block when any of its branches fail.  The errors of the branches that
+// failed are in the order the branches appear in the block.
+type ParallelError struct {
+	Errors   []error
+	Branches int
+}
+
+func (self *ParallelError) Error() string {
+	var messages = make([]string, len(self.Errors))
+
+	for i, err := range self.Errors {
+		messages[i] = err.Error()
+	}
+
+	return fmt.Sprintf("%d of %d parallel branches failed: %s", len(self.Errors), self.Branches, strings.Join(messages, `; `))
+}
+
+func (self *ParallelError) Unwrap() []error {
+	return self.Errors
+}
+
+// Return an environment for evaluating a branch of a parallel statement in the given scope.  Branches
+// share their modules, functions, and handlers with the environment they came from, but have their own
+// scope stack and copy of the given script, so that several of them can be evaluated at the same time.
+// The id identifies the branch in the contexts sent to context handlers.
+func (self *Environment) fork(script *scripting.Friendscript, scope *scripting.Scope, id string) *Environment {
+	var branch = &Environment{
+		Name:           self.Name,
+		modules:        self.modules,
+		stack:          []*scripting.Scope{scope},
+		replHandlers:   self.replHandlers,
+		filterCommands: self.filterCommands,
+		pathWriters:    self.pathWriters,
+		pathReaders:    self.pathReaders,
+		includeChain:   self.includeChain,
+		functions:      self.functions,
+		evaldepth:      self.evaldepth,
+		calldepth:      self.calldepth,
+		parent:         self,
+		branch:         self.branch,
+		runctx:         self.runctx,
+		transactions:   slices.Clone(self.transactions),
+	}
+
+	if id != `` && branch.branch != `` {
+		branch.branch += `.` + id
+	} else if id != `` {
+		branch.branch = id
+	}
+
+	if script != nil {
+		branch.script = script.Fork(scope)
+	}
+
+	return branch
+}
+
+// Return copies of the given blocks that are evaluated in the given script (see Block.InScript).
+func blocksInScript(blocks []*scripting.Block, script *scripting.Friendscript) []*scripting.Block {
+	var out = make([]*scripting.Block, len(blocks))
+
+	for i, block := range blocks {
+		out[i] = block.InScript(script)
+	}
+
+	return out
+}
+
+// Evaluates the iterations of a parallel loop over the given values, running up to the loop's limit of
+// them at the same time.  Each iteration runs in a scope of its own, and the result of each one that
+// started (the last value it set) is collected in order into the loop's result variable.  The first iteration to fail
+// stops any more from starting and cancels the ones that are running, and the loop fails with its error
+// once they finish.  If the loop collects errors, all iterations run, and the errors are collected instead.
+func (self *Environment) evaluateParallelLoop(
+	loop *scripting.Loop,
+	outerScope *scripting.Scope,
+	loopScope *scripting.Scope,
+	vector any,
+	destVars []string,
+	destTarget *scripting.Target,
+) error {
+	var limit int
+	var resultsVar, errorsVar string
+
+	if l, err := loop.Parallelism(DefaultParallelism); err == nil {
+		limit = l
+	} else {
+		return err
+	}
+
+	if r, e, err := loop.ParallelResults(); err == nil {
+		resultsVar, errorsVar = r, e
+	} else {
+		return err
+	}
+
+	var blocks = loop.Blocks()
+	var count = max(iterationLen(vector), 0)
+	var started int
+	var semaphore = make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	var resultlock sync.Mutex
+	var halted bool
+	var loopErr error
+
+	// results and errors are only kept if they are being collected, and only for the iterations that
+	// ran, so loops over (very) large ranges that stop early do not need room for every iteration
+	var results = make(map[int]any)
+	var failures = make(map[int]map[string]any)
+
+	// the first iteration to fail cancels the others that are still running
+	var ctx, cancel = context.WithCancel(self.context())
+
+	defer cancel()
+
+	var stopped = func() bool {
+		resultlock.Lock()
+		defer resultlock.Unlock()
+
+		return halted
+	}
+
+	for i := 0; i < count && !stopped(); i++ {
+		semaphore <- struct{}{}
+
+		// an iteration may have failed while we were waiting for one to finish
+		if stopped() {
+			<-semaphore
+			break
+		}
+
+		started = i + 1
+
+		var item, _ = iterationAt(vector, i)
+		var scope = scripting.NewScope(loopScope)
+
+		scope.SkipPreclear = true
+		scope.Declare(`index`)
+		scope.Declare(`index0`)
+		if err := scope.Assign(`index`, i); err != nil {
+			<-semaphore
+			wg.Wait()
+			return err
+		} else if err := scope.Assign(`index0`, i-1); err != nil {
+			<-semaphore
+			wg.Wait()
+			return err
+		} else if err := assignIteration(scope, destVars, destTarget, item, true); err != nil {
+			<-semaphore
+			wg.Wait()
+			return err
+		}
+
+		wg.Add(1)
+
+		go func(i int, scope *scripting.Scope) {
+			defer wg.Done()
+			defer func() {
+				<-semaphore
+			}()
+
+			var err error
+			var halt bool
+
+			// the body is evaluated in a scope of its own, so that only the values it sets are results
+			var body = scripting.NewScope(scope)
+
+			body.SkipPreclear = true
+
+			if len(blocks) > 0 {
+				var branch = self.fork(blocks[0].Script(), body, strconv.Itoa(i))
+
+				branch.runctx = ctx
+				err = branch.evaluateBlocks(blocksInScript(blocks, branch.script))
+			}
+
+			// "continue" ends this iteration, and "break" stops any more from starting; flow control
+			// aimed at anything outside of this loop stops it and is passed along once it finishes
+			if fc, ok := err.(*scripting.FlowControlErr); ok {
+				if fc.Level == 1 {
+					halt = (fc.Type == scripting.FlowBreak)
+					err = nil
+				} else if fc.Level > 1 {
+					fc.Level = fc.Level - 1
+				}
+			}
+
+			resultlock.Lock()
+			defer resultlock.Unlock()
+
+			if err == nil {
+				if resultsVar != `` {
+					results[i] = body.MostRecentValue()
+				}
+
+				halted = halted || halt
+			} else if _, ok := err.(*scripting.FlowControlErr); ok || errorsVar == `` {
+				if loopErr == nil {
+					loopErr = err
+				}
+
+				halted = true
+				cancel()
+			} else {
+				var failure = errorToMap(err)
+
+				failure[`index`] = i
+				failures[i] = failure
+			}
+		}(i, scope)
+	}
+
+	wg.Wait()
+
+	if resultsVar != `` {
+		var values = make([]any, started)
+
+		for i, result := range results {
+			values[i] = result
+		}
+
+		if err := outerScope.Assign(resultsVar, values); err != nil {
+			return err
+		}
+	}
+
+	if errorsVar != `` {
+		var errs = make([]any, 0, len(failures))
+
+		for i := 0; i < started; i++ {
+			if failure, ok := failures[i]; ok {
+				errs = append(errs, failure)
+			}
+		}
+
+		if err := outerScope.Assign(errorsVar, errs); err != nil {
+			return err
+		}
+	}
+
+	return loopErr
+}
+
+// Evaluates the statements in a parallel block at the same time, waiting for all of them to finish.
+// Each statement runs in a scope of its own that keeps all of its writes (including to variables that
+// already existed), and once they have all finished, the variables each of them set are copied into the
+// enclosing scope in the order the statements appear (so if several set the same variable, the last one
+// wins.)  Statements that fail do not stop the others, and their errors are returned together as a
+// ParallelError.
+func (self *Environment) evaluateParallel(parallel *scripting.Parallel) error {
+	var blocks = parallel.Blocks()
+	var outerScope = self.Scope()
+	var scopes = make([]*scripting.Scope, len(blocks))
+	var errs = make([]error, len(blocks))
+	var wg sync.WaitGroup
+
+	for i, block := range blocks {
+		var scope = scripting.NewLocalScope(outerScope)
+
+		scope.SkipPreclear = true
+		scopes[i] = scope
+		wg.Add(1)
+
+		go func(i int, block *scripting.Block) {
+			defer wg.Done()
+
+			var branch = self.fork(block.Script(), scope, strconv.Itoa(i))
+
+			errs[i] = branch.evaluateBlocks([]*scripting.Block{block.InScript(branch.script)})
+		}(i, block)
+	}
+
+	wg.Wait()
+
+	for _, scope := range scopes {
+		for key, value := range scope.Data() {
+			if err := outerScope.Assign(key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	var perr = &ParallelError{
+		Branches: len(blocks),
+	}
+
+	for _, err := range errs {
+		if _, ok := err.(*scripting.FlowControlErr); ok {
+			return err
+		} else if err != nil {
+			perr.Errors = append(perr.Errors, err)
+		}
+	}
+
+	if len(perr.Errors) > 0 {
+		return perr
+	}
+
+	return nil
+}
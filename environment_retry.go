@@ -0,0 +1,154 @@
+package friendscript
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ghetzel/friendscript/scripting"
+)
+
+// The error returned by a timeout statement whose body did not finish in time.
+var ErrTimeout = errors.New(`timed out`)
+
+// The error an attempt of a retry statement fails with when its condition is not met.
+var ErrRetryConditionNotMet = errors.New(`retry condition was not met`)
+
+// Return the context that commands evaluated in this environment are given, which is cancelled when
+// the script is interrupted (e.g.: when a timeout expires.)
+func (self *Environment) context() context.Context {
+	self.stacklock.RLock()
+	defer self.stacklock.RUnlock()
+
+	if self.runctx != nil {
+		return self.runctx
+	} else {
+		return context.Background()
+	}
+}
+
+func (self *Environment) setContext(ctx context.Context) {
+	self.stacklock.Lock()
+	defer self.stacklock.Unlock()
+
+	self.runctx = ctx
+}
+
+// Evaluates the body of a timeout statement, interrupting it if it does not finish in time.  Commands
+// that are running when the timeout expires are cancelled (if they support it), and no more statements
+// are evaluated.
+func (self *Environment) evaluateTimeout(timeout *scripting.Timeout) error {
+	var duration, err = timeout.Duration()
+
+	if err != nil {
+		return err
+	}
+
+	var outer = self.context()
+	var ctx, cancel = context.WithTimeout(outer, duration)
+
+	defer cancel()
+
+	self.setContext(ctx)
+	defer self.setContext(outer)
+
+	err = self.evaluateScopedBlocks(timeout.Blocks(), nil)
+
+	if _, ok := err.(*scripting.FlowControlErr); ok {
+		return err
+	}
+
+	// only the timeout that expired reports it; enclosing ones pass along the error as-is
+	if err != nil && ctx.Err() == context.DeadlineExceeded && outer.Err() == nil {
+		return scripting.NewContextError(timeout.SourceContext(), fmt.Errorf("%w after %v", ErrTimeout, duration))
+	}
+
+	return err
+}
+
+// Evaluates the body of a retry statement until it succeeds, or until it has been attempted as many
+// times as the statement allows.  An attempt succeeds if it does not fail, and its condition (if any)
+// is true afterwards.  Each attempt runs in a scope of its own, in which $attempt is the number of the
+// attempt (starting from 1), and $last_error is the error the previous attempt failed with.
+func (self *Environment) evaluateRetry(retry *scripting.Retry) error {
+	var attempts, err = retry.Attempts()
+
+	if err != nil {
+		return err
+	}
+
+	backoff, err := retry.Backoff()
+
+	if err != nil {
+		return err
+	}
+
+	var condition = retry.Condition()
+	var lastErr error
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var scope = scripting.NewScope(self.Scope())
+
+		scope.SkipPreclear = true
+		scope.Declare(`attempt`)
+		scope.Declare(`last_error`)
+		if err := scope.Assign(`attempt`, attempt); err != nil {
+			return err
+		}
+
+		if lastErr != nil {
+			if err := scope.Assign(`last_error`, errorToMap(lastErr)); err != nil {
+				return err
+			}
+		}
+
+		var satisfied, err = self.evaluateRetryAttempt(retry, scope, condition)
+
+		// flow control leaves the statement, as does the script being interrupted
+		if _, ok := err.(*scripting.FlowControlErr); ok {
+			return err
+		} else if cerr := self.context().Err(); cerr != nil {
+			if err != nil {
+				return err
+			} else {
+				return cerr
+			}
+		} else if err == nil && satisfied {
+			return nil
+		} else if err == nil {
+			lastErr = scripting.NewContextError(retry.SourceContext(), ErrRetryConditionNotMet)
+		} else {
+			lastErr = err
+		}
+
+		if attempt < attempts {
+			if delay := backoff.Delay(attempt); delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-self.context().Done():
+					return self.context().Err()
+				}
+			}
+		}
+	}
+
+	return fmt.Errorf("failed after %d attempts: %w", attempts, lastErr)
+}
+
+// Evaluates one attempt of a retry statement in the given scope, returning whether its condition is
+// true afterwards.  Errors evaluating the condition fail the attempt.
+func (self *Environment) evaluateRetryAttempt(retry *scripting.Retry, scope *scripting.Scope, condition *scripting.ConditionalExpression) (bool, error) {
+	self.pushScope(scope)
+	defer self.popScope()
+
+	if err := self.evaluateBlocks(retry.Blocks()); err != nil {
+		return false, err
+	}
+
+	if condition != nil {
+		return self.evaluateCondition(condition)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,229 @@
+package friendscript
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ghetzel/friendscript/scripting"
+	"github.com/ghetzel/friendscript/utils"
+)
+
+// A step of a transaction that is undone if the transaction fails.
+type compensation struct {
+	label string
+	undo  func(ctx context.Context) error
+}
+
+type transaction struct {
+	steps []*compensation
+	lock  sync.Mutex
+}
+
+func (self *transaction) add(steps ...*compensation) {
+	self.lock.Lock()
+	defer self.lock.Unlock()
+
+	self.steps = append(self.steps, steps...)
+}
+
+// The outcome of undoing one step of a transaction that failed.
+type Compensation struct {
+	Command string
+	Undone  bool
+	Error   error
+}
+
+// The error returned by a transaction that failed (and has no "rollback" stanza to handle it.)  The
+// steps of the transaction that were undone are in the order they were undone in.
+type TransactionError struct {
+	Err           error
+	Compensations []Compensation
+}
+
+func (self *TransactionError) Error() string {
+	var undone int
+
+	for _, c := range self.Compensations {
+		if c.Undone {
+			undone += 1
+		}
+	}
+
+	return fmt.Sprintf("transaction failed: %v (undid %d of %d steps)", self.Err, undone, len(self.Compensations))
+}
+
+func (self *TransactionError) Unwrap() error {
+	return self.Err
+}
+
+// Return whether every step of the transaction was undone.
+func (self *TransactionError) RolledBack() bool {
+	for _, c := range self.Compensations {
+		if !c.Undone {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Return the transaction that commands evaluated in this environment are part of, if any (which there
+// is not while a transaction is being rolled back.)
+func (self *Environment) transaction() *transaction {
+	self.stacklock.RLock()
+	defer self.stacklock.RUnlock()
+
+	if len(self.transactions) > 0 {
+		return self.transactions[len(self.transactions)-1]
+	} else {
+		return nil
+	}
+}
+
+// Evaluates a transaction statement.  If the body of the "transaction" stanza fails, the steps it
+// completed that can be undone are undone, most recent first, and the "rollback" stanza (if any) is
+// evaluated with a report of what was undone.  Like a "catch" stanza, a "rollback" stanza handles the
+// error; without one, the statement fails with a TransactionError.  Flow control statements (e.g.:
+// break, continue, return) are not failures, and leave the transaction without undoing anything.
+func (self *Environment) evaluateTransaction(statement *scripting.Transaction) error {
+	var txn = new(transaction)
+
+	self.stacklock.Lock()
+	self.transactions = append(self.transactions, txn)
+	self.stacklock.Unlock()
+
+	var err = self.evaluateScopedBlocks(statement.Blocks(), nil)
+
+	self.stacklock.Lock()
+	self.transactions = self.transactions[:len(self.transactions)-1]
+	self.stacklock.Unlock()
+
+	if _, ok := err.(*scripting.FlowControlErr); err == nil || ok {
+		// the steps of a transaction inside of another are undone if the outer one fails
+		if outer := self.transaction(); outer != nil {
+			outer.add(txn.steps...)
+		}
+
+		return err
+	}
+
+	// undoing steps is not a step of any enclosing transaction, so nothing is recorded while it happens
+	self.stacklock.Lock()
+	self.transactions = append(self.transactions, nil)
+	self.stacklock.Unlock()
+
+	var terr = self.rollback(txn, err)
+
+	self.stacklock.Lock()
+	self.transactions = self.transactions[:len(self.transactions)-1]
+	self.stacklock.Unlock()
+
+	if !statement.HasRollback() {
+		return terr
+	}
+
+	if varname, verr := statement.RollbackVariable(); verr == nil {
+		return self.evaluateScopedBlocks(statement.RollbackBlocks(), func(scope *scripting.Scope) error {
+			if varname != `` {
+				scope.Declare(varname)
+				return scope.Assign(varname, transactionReport(terr))
+			}
+
+			return nil
+		})
+	} else {
+		return verr
+	}
+}
+
+// Undoes the steps of the given transaction, which failed with the given error, most recent first.
+// Every step is undone (even if the script was interrupted, or undoing another step failed.)
+func (self *Environment) rollback(txn *transaction, err error) *TransactionError {
+	var terr = &TransactionError{
+		Err: err,
+	}
+
+	var ctx = context.WithoutCancel(self.context())
+
+	for _, step := range slices.Backward(txn.steps) {
+		var uerr = step.undo(ctx)
+
+		terr.Compensations = append(terr.Compensations, Compensation{
+			Command: step.label,
+			Undone:  (uerr == nil),
+			Error:   uerr,
+		})
+	}
+
+	return terr
+}
+
+// Records how to undo the given command (which just succeeded) in the transaction it is part of, if
+// any.  Commands are undone by their "undo" clause if they have one, or by their module if it can undo
+// them (see utils.UndoModule).
+func (self *Environment) recordUndo(module Module, command *scripting.Command, first any, rest map[string]any, result any) {
+	var txn = self.transaction()
+
+	if txn == nil {
+		return
+	}
+
+	var _, name = command.Name()
+	var label = strings.TrimSpace(command.SourceContext().Snippet())
+	var scope = self.Scope()
+
+	if undo := command.Undo(); undo != nil {
+		txn.add(&compensation{
+			label: label,
+			undo: func(ctx context.Context) error {
+				var outer = self.context()
+
+				self.setContext(ctx)
+				defer self.setContext(outer)
+
+				var err = self.evaluateDetached(scope, undo.Command(), undo.Blocks())
+
+				if fc, ok := err.(*scripting.FlowControlErr); ok {
+					return fmt.Errorf("cannot use %v in an undo clause", fc)
+				}
+
+				return err
+			},
+		})
+	} else if utils.CanUndoCommand(module, name) {
+		txn.add(&compensation{
+			label: label,
+			undo: func(ctx context.Context) error {
+				return utils.UndoCommand(ctx, module, name, first, rest, result)
+			},
+		})
+	}
+}
+
+// Return the report given to the "rollback" stanza of a failed transaction.
+func transactionReport(terr *TransactionError) map[string]any {
+	var steps = make([]any, len(terr.Compensations))
+
+	for i, c := range terr.Compensations {
+		var step = map[string]any{
+			`command`: c.Command,
+			`undone`:  c.Undone,
+			`error`:   nil,
+		}
+
+		if c.Error != nil {
+			step[`error`] = errorToMap(c.Error)
+		}
+
+		steps[i] = step
+	}
+
+	return map[string]any{
+		`error`:       errorToMap(terr.Err),
+		`steps`:       steps,
+		`rolled_back`: terr.RolledBack(),
+	}
+}
@@ -55,6 +55,15 @@ func (self *Block) Script() *Friendscript {
 	return self.friendscript
 }
 
+// Return a copy of this block that belongs to the given copy of its script (see Friendscript.Fork).
+func (self *Block) InScript(script *Friendscript) *Block {
+	return &Block{
+		friendscript: script,
+		node:         self.node,
+		parent:       self.parent,
+	}
+}
+
 func (self *Block) String() string {
 	if n := self.node; n == nil {
 		return fmt.Sprintf("%v (%d children)", self.Type(), len(self.node.children()))
@@ -67,7 +76,8 @@ func (self *Block) Type() BlockType {
 	switch self.node.rule() {
 	case ruleStatementBlock:
 		return StatementBlock
-	// case ruleEventHandlerBlock:
+	case ruleEventHandler:
+		return EventHandlerBlock
 	case ruleFlowControlWord:
 		return FlowControlWord
 	default:
@@ -83,10 +93,81 @@ func (self *Block) FlowContinue() int {
 	return self.flowControl(ruleFlowControlContinue)
 }
 
+// Return whether this block is a "return" statement.
+func (self *Block) IsReturn() bool {
+	return self.Type() == FlowControlWord && self.node.firstChild(ruleFlowControlReturn) != nil
+}
+
+// Evaluate the value(s) given to a "return" statement.  Multiple values are returned as an array.
+func (self *Block) ReturnValue() (any, error) {
+	var values = make([]any, 0)
+
+	if node := self.node.firstChild(ruleFlowControlReturn); node != nil {
+		statement := &Statement{
+			node:  node,
+			block: self,
+		}
+
+		if seq := node.firstChild(ruleExpressionSequence); seq != nil {
+			for _, exprNode := range seq.children(ruleExpression) {
+				if value, err := NewExpression(statement, exprNode).Value(); err == nil {
+					values = append(values, value)
+				} else {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	switch len(values) {
+	case 0:
+		return nil, nil
+	case 1:
+		return values[0], nil
+	default:
+		return values, nil
+	}
+}
+
+// Return whether this block is an "exit" statement.
+func (self *Block) IsExit() bool {
+	return self.Type() == FlowControlWord && self.node.firstChild(ruleFlowControlExit) != nil
+}
+
+// Return the exit code given to an "exit" statement, which is 0 if none was given.
+func (self *Block) ExitCode() (int, error) {
+	var code any = 0
+
+	if node := self.node.firstChild(ruleFlowControlExit); node != nil {
+		statement := &Statement{
+			node:  node,
+			block: self,
+		}
+
+		if varNode := node.firstChild(ruleVariable); varNode != nil {
+			if value, err := statement.resolveVariable(varNode); err == nil {
+				code = value
+			} else {
+				return 0, err
+			}
+		} else if intNode := node.firstChild(ruleInteger); intNode != nil {
+			code = self.Script().s(intNode)
+		}
+	}
+
+	if n, err := stringutil.ConvertToInteger(code); err == nil && n >= 0 && n <= 255 {
+		return int(n), nil
+	} else {
+		return 0, fmt.Errorf("invalid exit code %v: must be an integer from 0 to 255", code)
+	}
+}
+
 func (self *Block) flowControl(rule pegRule) int {
 	if self.Type() == FlowControlWord {
 		if n := self.node.firstChild(rule); n != nil {
-			if levels := n.firstChild(rulePositiveInteger); levels != nil {
+			if label := n.subnode(ruleFlowControlLabel); label != nil {
+				return self.Script().labelLevels(label)
+			} else if levels := n.firstChild(rulePositiveInteger); levels != nil {
 				l := int(stringutil.MustInteger(
 					strings.TrimSpace(self.Script().s(levels)),
 				))
@@ -105,6 +186,36 @@ func (self *Block) flowControl(rule pegRule) int {
 	return 0
 }
 
+// Return the (interpolated) name of the event an event handler block responds to.
+func (self *Block) EventName() string {
+	if self.Type() == EventHandlerBlock {
+		statement := &Statement{
+			node:  self.node,
+			block: self,
+		}
+
+		return statement.s(self.node.firstChild(ruleString))
+	}
+
+	return ``
+}
+
+// Return the blocks that make up the body of an event handler block.
+func (self *Block) EventBlocks() []*Block {
+	var blocks = make([]*Block, 0)
+
+	if self.Type() == EventHandlerBlock {
+		for _, node := range self.node.children(ruleBlock) {
+			blocks = append(blocks, &Block{
+				friendscript: self.friendscript,
+				node:         node.first(),
+			})
+		}
+	}
+
+	return blocks
+}
+
 func (self *Block) Statements() []*Statement {
 	statements := make([]*Statement, 0)
 
@@ -2,6 +2,7 @@ package scripting
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -24,6 +25,11 @@ type Context struct {
 	Error               error
 	StartedAt           time.Time
 	Took                time.Duration
+
+	// identifies the branch of a parallel statement being evaluated (e.g.: "1" for the second statement
+	// in a "parallel" block, or "1.3" for the fourth iteration of a parallel loop inside of it); empty
+	// outside of parallel statements
+	Branch string
 }
 
 func (self *Context) String() string {
@@ -32,14 +38,15 @@ func (self *Context) String() string {
 
 func (self *Context) Snippet() string {
 	if self.Script != nil {
-		var src = self.Script.Buffer
+		// offsets are in runes, not bytes
+		var src = []rune(self.Script.Buffer)
 
 		if self.AbsoluteStartOffset >= 0 && self.Length > 0 {
 			if self.AbsoluteStartOffset < len(src) {
 				var endIndex = self.AbsoluteStartOffset + self.Length
 
 				if endIndex <= len(src) {
-					return src[self.AbsoluteStartOffset:endIndex]
+					return string(src[self.AbsoluteStartOffset:endIndex])
 				}
 			}
 		}
@@ -47,3 +54,35 @@ func (self *Context) Snippet() string {
 
 	return ``
 }
+
+// Return the (1-indexed) line number in the script that this context starts on, or 0 if unknown.
+func (self *Context) Line() int {
+	if self.Script != nil {
+		if src := []rune(self.Script.Buffer); self.AbsoluteStartOffset >= 0 && self.AbsoluteStartOffset <= len(src) {
+			return strings.Count(string(src[:self.AbsoluteStartOffset]), "\n") + 1
+		}
+	}
+
+	return 0
+}
+
+// An error that occurred during evaluation, along with the context it occurred in.
+type ContextError struct {
+	Context *Context
+	Err     error
+}
+
+func NewContextError(ctx *Context, err error) *ContextError {
+	return &ContextError{
+		Context: ctx,
+		Err:     err,
+	}
+}
+
+func (self *ContextError) Error() string {
+	return self.Err.Error()
+}
+
+func (self *ContextError) Unwrap() error {
+	return self.Err
+}
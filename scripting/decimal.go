@@ -0,0 +1,301 @@
+package scripting
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// The number of decimal places that the results of dividing two decimals are rounded to when the
+// result cannot be represented exactly (e.g.: 1d / 3d).
+var DecimalDivisionPrecision = 32
+
+var bigTen = big.NewInt(10)
+
+// Represents an exact base-10 number.  Unlike floating point values, decimals carry their exact value
+// through addition, subtraction, multiplication, and comparison (so 0.1d + 0.2d == 0.3d).  Decimals
+// are written in scripts as numbers with a "d" suffix (e.g.: 19.99d).
+type Decimal struct {
+	value *big.Rat
+}
+
+// Create a new decimal from the given value, which may be a string, any integer or float type, a
+// *big.Int, a *big.Rat, or another decimal.  Floats are converted using the shortest decimal
+// representation that round-trips back to the same float (so 0.1 becomes exactly 0.1d).
+func NewDecimal(value any) (*Decimal, error) {
+	var rat = new(big.Rat)
+
+	switch v := value.(type) {
+	case *Decimal:
+		rat.Set(v.value)
+	case Decimal:
+		rat.Set(v.value)
+	case *big.Rat:
+		rat.Set(v)
+	case string:
+		if _, ok := rat.SetString(strings.TrimSuffix(strings.TrimSpace(v), `d`)); !ok {
+			return nil, fmt.Errorf("invalid decimal %q", v)
+		} else if !isTerminating(rat) {
+			return nil, fmt.Errorf("invalid decimal %q: not a base-10 number", v)
+		}
+	case float32:
+		return NewDecimal(float64(v))
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return nil, fmt.Errorf("cannot convert %v to a decimal", v)
+		}
+
+		rat.SetString(strconv.FormatFloat(v, 'g', -1, 64))
+	default:
+		if i, ok := toBigInt(v); ok {
+			rat.SetInt(i)
+		} else {
+			return nil, fmt.Errorf("cannot convert %T to a decimal", value)
+		}
+	}
+
+	return &Decimal{
+		value: rat,
+	}, nil
+}
+
+// Same as NewDecimal, but panics if the value cannot be converted.
+func MustDecimal(value any) *Decimal {
+	if d, err := NewDecimal(value); err == nil {
+		return d
+	} else {
+		panic(err.Error())
+	}
+}
+
+// Return the exact value of the decimal as a fraction.
+func (self *Decimal) Rat() *big.Rat {
+	return new(big.Rat).Set(self.value)
+}
+
+// Return the nearest float64 to the decimal's value.
+func (self *Decimal) Float64() float64 {
+	f, _ := self.value.Float64()
+	return f
+}
+
+// Return whether the decimal is a whole number.
+func (self *Decimal) IsInteger() bool {
+	return self.value.IsInt()
+}
+
+func (self *Decimal) IsZero() bool {
+	return (self.value.Sign() == 0)
+}
+
+// Compare this decimal to another, returning -1, 0, or +1 if this decimal is less than, equal to,
+// or greater than the other.
+func (self *Decimal) Cmp(other *Decimal) int {
+	return self.value.Cmp(other.value)
+}
+
+// Return the decimal formatted with as many decimal places as are needed to represent it exactly.
+func (self *Decimal) String() string {
+	var places int
+	var twos, fives = factorTwosAndFives(self.value.Denom())
+
+	if twos > fives {
+		places = twos
+	} else {
+		places = fives
+	}
+
+	return self.value.FloatString(places)
+}
+
+// Implements fmt.Formatter so that the %f verb formats decimals exactly (e.g.: "%.2f" rounds to two
+// places, halves away from zero.)  The %v and %s verbs format the decimal as String does, and all
+// other verbs format its nearest float64.
+func (self *Decimal) Format(f fmt.State, verb rune) {
+	var out string
+
+	switch verb {
+	case 'f', 'F':
+		if precision, ok := f.Precision(); ok {
+			out = self.value.FloatString(precision)
+		} else {
+			out = self.String()
+		}
+
+		if f.Flag('+') && self.value.Sign() >= 0 {
+			out = `+` + out
+		}
+	case 'v', 's':
+		out = self.String()
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), self.Float64())
+		return
+	}
+
+	if width, ok := f.Width(); ok && len(out) < width {
+		if f.Flag('-') {
+			out = out + strings.Repeat(` `, width-len(out))
+		} else {
+			out = strings.Repeat(` `, width-len(out)) + out
+		}
+	}
+
+	fmt.Fprint(f, out)
+}
+
+func (self *Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(self.String()), nil
+}
+
+func (self *Decimal) add(other *Decimal) *Decimal {
+	return &Decimal{new(big.Rat).Add(self.value, other.value)}
+}
+
+func (self *Decimal) sub(other *Decimal) *Decimal {
+	return &Decimal{new(big.Rat).Sub(self.value, other.value)}
+}
+
+func (self *Decimal) mul(other *Decimal) *Decimal {
+	return &Decimal{new(big.Rat).Mul(self.value, other.value)}
+}
+
+func (self *Decimal) quo(other *Decimal) (*Decimal, error) {
+	if other.IsZero() {
+		return nil, fmt.Errorf("cannot divide by zero")
+	}
+
+	var quotient = new(big.Rat).Quo(self.value, other.value)
+
+	if !isTerminating(quotient) {
+		quotient = roundRat(quotient, DecimalDivisionPrecision)
+	}
+
+	return &Decimal{quotient}, nil
+}
+
+// the remainder has the same sign as the dividend, consistent with math.Mod
+func (self *Decimal) rem(other *Decimal) (*Decimal, error) {
+	if other.IsZero() {
+		return nil, fmt.Errorf("cannot divide by zero")
+	}
+
+	var quotient = new(big.Rat).Quo(self.value, other.value)
+	var truncated = new(big.Int).Quo(quotient.Num(), quotient.Denom())
+
+	return &Decimal{
+		new(big.Rat).Sub(self.value, new(big.Rat).Mul(other.value, new(big.Rat).SetInt(truncated))),
+	}, nil
+}
+
+func (self *Decimal) pow(other *Decimal) (*Decimal, error) {
+	if !other.IsInteger() || !other.value.Num().IsInt64() {
+		return nil, fmt.Errorf("decimals can only be raised to integer powers")
+	}
+
+	var exponent = other.value.Num().Int64()
+	var negative = (exponent < 0)
+
+	if negative {
+		exponent = -exponent
+	}
+
+	// like integers (see MaxIntegerBits), decimals are only raised to powers that can be calculated
+	// in a reasonable amount of time and memory
+	if bits := int64(max(self.value.Num().BitLen(), self.value.Denom().BitLen())); bits > 1 && exponent > MaxIntegerBits/bits {
+		return nil, fmt.Errorf("%v ** %v is too large to calculate", self, other)
+	}
+
+	var num = new(big.Int).Exp(self.value.Num(), big.NewInt(exponent), nil)
+	var den = new(big.Int).Exp(self.value.Denom(), big.NewInt(exponent), nil)
+	var result = &Decimal{new(big.Rat).SetFrac(num, den)}
+
+	if negative {
+		return MustDecimal(1).quo(result)
+	}
+
+	return result, nil
+}
+
+// Return whether the given value is one of the numeric types that must be stored as-is (rather than
+// being converted to a map like other structs.)
+func isNumericType(in any) bool {
+	_, ok := asNumericType(in)
+	return ok
+}
+
+// Return the given numeric type as a pointer; this recovers values that were dereferenced while
+// walking through a map.
+func asNumericType(in any) (any, bool) {
+	switch v := in.(type) {
+	case *Decimal, *big.Int:
+		return v, true
+	case Decimal:
+		return &v, true
+	case big.Int:
+		return &v, true
+	default:
+		return nil, false
+	}
+}
+
+// Return whether the given fraction has a finite base-10 representation (i.e.: its denominator has no
+// prime factors other than 2 and 5.)
+func isTerminating(rat *big.Rat) bool {
+	var denom = new(big.Int).Set(rat.Denom())
+
+	for _, factor := range []int64{2, 5} {
+		var f = big.NewInt(factor)
+		var m = new(big.Int)
+
+		for {
+			if q, r := new(big.Int).QuoRem(denom, f, m); r.Sign() == 0 {
+				denom = q
+			} else {
+				break
+			}
+		}
+	}
+
+	return denom.IsInt64() && denom.Int64() == 1
+}
+
+func factorTwosAndFives(denom *big.Int) (int, int) {
+	var counts = make([]int, 2)
+	var n = new(big.Int).Set(denom)
+
+	for i, factor := range []int64{2, 5} {
+		var f = big.NewInt(factor)
+		var m = new(big.Int)
+
+		for n.Sign() != 0 {
+			if q, r := new(big.Int).QuoRem(n, f, m); r.Sign() == 0 {
+				n = q
+				counts[i] += 1
+			} else {
+				break
+			}
+		}
+	}
+
+	return counts[0], counts[1]
+}
+
+// rounds the given fraction to the given number of decimal places, rounding halves away from zero
+func roundRat(rat *big.Rat, places int) *big.Rat {
+	var scale = new(big.Int).Exp(bigTen, big.NewInt(int64(places)), nil)
+	var scaled = new(big.Rat).Mul(rat, new(big.Rat).SetInt(scale))
+	var q, r = new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	// if twice the remainder is at least the denominator, round away from zero
+	if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(scaled.Denom()) >= 0 {
+		if scaled.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	return new(big.Rat).SetFrac(q, scale)
+}
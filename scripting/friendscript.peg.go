@@ -21,35 +21,68 @@ const (
 	ruleFriendscript
 	rule_
 	rule__
+	ruleAND
+	ruleBACKOFF
 	ruleASSIGN
 	ruleBREAK
+	ruleCATCH
 	ruleCLOSE
+	ruleCOALESCE
 	ruleCOLON
 	ruleCOMMA
 	ruleCOMMENT
+	ruleCONST
 	ruleCONT
 	ruleCOUNT
 	ruleDECLARE
+	ruleDEF
+	ruleDEFER
 	ruleDOT
 	ruleELSE
+	ruleEXIT
+	ruleFINALLY
 	ruleGROUPCLOSE
 	ruleGROUPOPEN
 	ruleIF
 	ruleIN
 	ruleINCLUDE
+	ruleINTO
+	ruleIS
+	ruleJITTER
 	ruleLOOP
+	ruleMATCH
 	ruleNOOP
 	ruleNOT
+	ruleON
+	ruleOPTDOT
 	ruleOPEN
+	ruleOR
+	rulePARALLEL
+	ruleQUESTION
+	ruleRANGE
+	ruleRANGEEXCL
+	ruleRETRY
+	ruleROLLBACK
+	ruleRETURN
 	ruleSCOPE
 	ruleSEMI
 	ruleSHEBANG
+	ruleSTEP
+	ruleTIMEOUT
+	ruleTRANSACTION
 	ruleSKIPVAR
 	ruleTRIQUOT
+	ruleTRY
+	ruleUNDO
 	ruleUNSET
+	ruleUNTIL
 	ruleScalarType
 	ruleIdentifier
 	ruleFloat
+	ruleDecimal
+	ruleDuration
+	ruleDurationUnit
+	ruleTimestamp
 	ruleBoolean
 	ruleInteger
 	rulePositiveInteger
@@ -76,10 +109,17 @@ const (
 	ruleBitwiseOr
 	ruleBitwiseNot
 	ruleBitwiseXor
+	ruleNegate
+	ruleLogicalNot
 	ruleMatchOperator
 	ruleUnmatch
 	ruleMatch
 	ruleOperator
+	ruleExponentOperator
+	ruleMultiplicativeOperator
+	ruleAdditiveOperator
+	ruleBitwiseOperator
+	ruleUnaryOperator
 	ruleAssignmentOperator
 	ruleAssignEq
 	ruleStarEq
@@ -102,25 +142,50 @@ const (
 	ruleVariableNameSequence
 	ruleVariableName
 	ruleVariableIndex
+	ruleVariableSlice
+	ruleVariableSliceStart
+	ruleVariableSliceEnd
 	ruleBlock
 	ruleFlowControlWord
 	ruleFlowControlBreak
 	ruleFlowControlContinue
+	ruleFlowControlLabel
+	ruleFlowControlReturn
+	ruleFlowControlExit
 	ruleStatementBlock
+	ruleEventHandler
 	ruleAssignment
 	ruleAssignmentLHS
+	ruleConstantDeclaration
 	ruleAssignmentRHS
 	ruleVariableSequence
+	ruleAssignmentTarget
+	ruleArrayTarget
+	ruleObjectTarget
+	ruleObjectTargetField
+	ruleRestVariable
 	ruleExpressionSequence
 	ruleExpression
-	ruleExpressionLHS
-	ruleExpressionRHS
+	ruleExpressionTernary
+	ruleExpressionTernaryComparison
+	ruleExpressionCoalesce
+	ruleExpressionBitwise
+	ruleExpressionAdditive
+	ruleExpressionMultiplicative
+	ruleExpressionUnary
+	ruleExpressionExponent
+	ruleExpressionOperand
+	ruleExpressionGroup
 	ruleInlineCommand
 	ruleValueYielding
 	ruleDirective
 	ruleDirectiveUnset
 	ruleDirectiveInclude
 	ruleDirectiveDeclare
+	ruleFunctionDefinition
+	ruleFunctionParameters
+	ruleFunctionArgument
+	ruleFunctionOptions
 	ruleCommand
 	ruleCommandName
 	ruleCommandFirstArg
@@ -130,7 +195,37 @@ const (
 	ruleIfStanza
 	ruleElseIfStanza
 	ruleElseStanza
+	ruleParallelStatement
+	ruleMatchStatement
+	ruleMatchArm
+	ruleMatchPattern
+	ruleMatchWildcard
+	ruleMatchType
+	ruleRange
+	ruleRangeStart
+	ruleRangeEnd
+	ruleRangeStep
+	ruleTimeoutStatement
+	ruleRetryStatement
+	ruleRetryAttempts
+	ruleRetryBackoff
+	ruleRetryBackoffType
+	ruleRetryJitter
+	ruleRetryUntil
+	ruleDeferStatement
+	ruleTransactionStatement
+	ruleTransactionStanza
+	ruleRollbackStanza
+	ruleUndoableCommand
+	ruleTryCatch
+	ruleTryStanza
+	ruleCatchStanza
+	ruleFinallyStanza
 	ruleLoop
+	ruleLoopLabel
+	ruleLoopParallel
+	ruleLoopParallelLimit
+	ruleLoopParallelResults
 	ruleLoopConditionFixedLength
 	ruleLoopConditionIterable
 	ruleLoopIterableLHS
@@ -138,6 +233,10 @@ const (
 	ruleLoopConditionBounded
 	ruleLoopConditionTruthy
 	ruleConditionalExpression
+	ruleConditionDisjunction
+	ruleConditionConjunction
+	ruleConditionTerm
+	ruleConditionGroup
 	ruleConditionWithAssignment
 	ruleConditionWithCommand
 	ruleConditionWithRegex
@@ -151,35 +250,68 @@ var rul3s = [...]string{
 	"Friendscript",
 	"_",
 	"__",
+	"AND",
+	"BACKOFF",
 	"ASSIGN",
 	"BREAK",
+	"CATCH",
 	"CLOSE",
+	"COALESCE",
 	"COLON",
 	"COMMA",
 	"COMMENT",
+	"CONST",
 	"CONT",
 	"COUNT",
 	"DECLARE",
+	"DEF",
+	"DEFER",
 	"DOT",
 	"ELSE",
+	"EXIT",
+	"FINALLY",
 	"GROUPCLOSE",
 	"GROUPOPEN",
 	"IF",
 	"IN",
 	"INCLUDE",
+	"INTO",
+	"IS",
+	"JITTER",
 	"LOOP",
+	"MATCH",
 	"NOOP",
 	"NOT",
+	"ON",
+	"OPTDOT",
 	"OPEN",
+	"OR",
+	"PARALLEL",
+	"QUESTION",
+	"RANGE",
+	"RANGEEXCL",
+	"RETRY",
+	"ROLLBACK",
+	"RETURN",
 	"SCOPE",
 	"SEMI",
 	"SHEBANG",
+	"STEP",
+	"TIMEOUT",
+	"TRANSACTION",
 	"SKIPVAR",
 	"TRIQUOT",
+	"TRY",
+	"UNDO",
 	"UNSET",
+	"UNTIL",
 	"ScalarType",
 	"Identifier",
 	"Float",
+	"Decimal",
+	"Duration",
+	"DurationUnit",
+	"Timestamp",
 	"Boolean",
 	"Integer",
 	"PositiveInteger",
@@ -206,10 +338,17 @@ var rul3s = [...]string{
 	"BitwiseOr",
 	"BitwiseNot",
 	"BitwiseXor",
+	"Negate",
+	"LogicalNot",
 	"MatchOperator",
 	"Unmatch",
 	"Match",
 	"Operator",
+	"ExponentOperator",
+	"MultiplicativeOperator",
+	"AdditiveOperator",
+	"BitwiseOperator",
+	"UnaryOperator",
 	"AssignmentOperator",
 	"AssignEq",
 	"StarEq",
@@ -232,25 +371,50 @@ var rul3s = [...]string{
 	"VariableNameSequence",
 	"VariableName",
 	"VariableIndex",
+	"VariableSlice",
+	"VariableSliceStart",
+	"VariableSliceEnd",
 	"Block",
 	"FlowControlWord",
 	"FlowControlBreak",
 	"FlowControlContinue",
+	"FlowControlLabel",
+	"FlowControlReturn",
+	"FlowControlExit",
 	"StatementBlock",
+	"EventHandler",
 	"Assignment",
 	"AssignmentLHS",
+	"ConstantDeclaration",
 	"AssignmentRHS",
 	"VariableSequence",
+	"AssignmentTarget",
+	"ArrayTarget",
+	"ObjectTarget",
+	"ObjectTargetField",
+	"RestVariable",
 	"ExpressionSequence",
 	"Expression",
-	"ExpressionLHS",
-	"ExpressionRHS",
+	"ExpressionTernary",
+	"ExpressionTernaryComparison",
+	"ExpressionCoalesce",
+	"ExpressionBitwise",
+	"ExpressionAdditive",
+	"ExpressionMultiplicative",
+	"ExpressionUnary",
+	"ExpressionExponent",
+	"ExpressionOperand",
+	"ExpressionGroup",
 	"InlineCommand",
 	"ValueYielding",
 	"Directive",
 	"DirectiveUnset",
 	"DirectiveInclude",
 	"DirectiveDeclare",
+	"FunctionDefinition",
+	"FunctionParameters",
+	"FunctionArgument",
+	"FunctionOptions",
 	"Command",
 	"CommandName",
 	"CommandFirstArg",
@@ -260,7 +424,37 @@ var rul3s = [...]string{
 	"IfStanza",
 	"ElseIfStanza",
 	"ElseStanza",
+	"ParallelStatement",
+	"MatchStatement",
+	"MatchArm",
+	"MatchPattern",
+	"MatchWildcard",
+	"MatchType",
+	"Range",
+	"RangeStart",
+	"RangeEnd",
+	"RangeStep",
+	"TimeoutStatement",
+	"RetryStatement",
+	"RetryAttempts",
+	"RetryBackoff",
+	"RetryBackoffType",
+	"RetryJitter",
+	"RetryUntil",
+	"DeferStatement",
+	"TransactionStatement",
+	"TransactionStanza",
+	"RollbackStanza",
+	"UndoableCommand",
+	"TryCatch",
+	"TryStanza",
+	"CatchStanza",
+	"FinallyStanza",
 	"Loop",
+	"LoopLabel",
+	"LoopParallel",
+	"LoopParallelLimit",
+	"LoopParallelResults",
 	"LoopConditionFixedLength",
 	"LoopConditionIterable",
 	"LoopIterableLHS",
@@ -268,6 +462,10 @@ var rul3s = [...]string{
 	"LoopConditionBounded",
 	"LoopConditionTruthy",
 	"ConditionalExpression",
+	"ConditionDisjunction",
+	"ConditionConjunction",
+	"ConditionTerm",
+	"ConditionGroup",
 	"ConditionWithAssignment",
 	"ConditionWithCommand",
 	"ConditionWithRegex",
@@ -390,7 +588,7 @@ type Friendscript struct {
 
 	Buffer string
 	buffer []rune
-	rules  [127]func() bool
+	rules  [226]func() bool
 	parse  func(rule ...int) error
 	reset  func()
 	Pretty bool
@@ -784,11 +982,11 @@ func (p *Friendscript) Init(options ...func(*Friendscript) error) error {
 			position, tokenIndex = position20, tokenIndex20
 			return false
 		},
-		/* 3 ASSIGN <- <(_ ('-' '>') _)> */
+		/* 3 AND <- <(_ ('a' 'n' 'd') __)> */
 		nil,
-		/* 4 BREAK <- <(_ ('b' 'r' 'e' 'a' 'k') _)> */
+		/* 4 BACKOFF <- <(_ ('b' 'a' 'c' 'k' 'o' 'f' 'f') __)> */
 		nil,
-		/* 5 CLOSE <- <(_ '}' _)> */
+		/* 5 ASSIGN <- <(_ ('-' '>') _)> */
 		func() bool {
 			position34, tokenIndex34 := position, tokenIndex
 			{
@@ -796,2859 +994,6446 @@ func (p *Friendscript) Init(options ...func(*Friendscript) error) error {
 				if !_rules[rule_]() {
 					goto l34
 				}
-				if buffer[position] != rune('}') {
+				if buffer[position] != rune('-') {
+					goto l34
+				}
+				position++
+				if buffer[position] != rune('>') {
 					goto l34
 				}
 				position++
 				if !_rules[rule_]() {
 					goto l34
 				}
-				add(ruleCLOSE, position35)
+				add(ruleASSIGN, position35)
 			}
 			return true
 		l34:
 			position, tokenIndex = position34, tokenIndex34
 			return false
 		},
-		/* 6 COLON <- <(_ ':' _)> */
+		/* 6 BREAK <- <(_ ('b' 'r' 'e' 'a' 'k') (' ' / '\t')*)> */
+		nil,
+		/* 7 CATCH <- <(_ ('c' 'a' 't' 'c' 'h') _)> */
+		nil,
+		/* 8 CLOSE <- <(_ '}' _)> */
+		func() bool {
+			position38, tokenIndex38 := position, tokenIndex
+			{
+				position39 := position
+				if !_rules[rule_]() {
+					goto l38
+				}
+				if buffer[position] != rune('}') {
+					goto l38
+				}
+				position++
+				if !_rules[rule_]() {
+					goto l38
+				}
+				add(ruleCLOSE, position39)
+			}
+			return true
+		l38:
+			position, tokenIndex = position38, tokenIndex38
+			return false
+		},
+		/* 9 COALESCE <- <(_ ('?' '?') _)> */
 		nil,
-		/* 7 COMMA <- <(_ ',' _)> */
+		/* 10 COLON <- <(_ ':' _)> */
+		func() bool {
+			position41, tokenIndex41 := position, tokenIndex
+			{
+				position42 := position
+				if !_rules[rule_]() {
+					goto l41
+				}
+				if buffer[position] != rune(':') {
+					goto l41
+				}
+				position++
+				if !_rules[rule_]() {
+					goto l41
+				}
+				add(ruleCOLON, position42)
+			}
+			return true
+		l41:
+			position, tokenIndex = position41, tokenIndex41
+			return false
+		},
+		/* 11 COMMA <- <(_ ',' _)> */
 		func() bool {
-			position37, tokenIndex37 := position, tokenIndex
+			position43, tokenIndex43 := position, tokenIndex
 			{
-				position38 := position
+				position44 := position
 				if !_rules[rule_]() {
-					goto l37
+					goto l43
 				}
 				if buffer[position] != rune(',') {
-					goto l37
+					goto l43
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l37
+					goto l43
 				}
-				add(ruleCOMMA, position38)
+				add(ruleCOMMA, position44)
 			}
 			return true
-		l37:
-			position, tokenIndex = position37, tokenIndex37
+		l43:
+			position, tokenIndex = position43, tokenIndex43
 			return false
 		},
-		/* 8 COMMENT <- <(_ '#' (!'\n' .)*)> */
+		/* 12 COMMENT <- <(_ '#' (!'\n' .)*)> */
+		nil,
+		/* 13 CONST <- <(_ ('c' 'o' 'n' 's' 't') __)> */
 		nil,
-		/* 9 CONT <- <(_ ('c' 'o' 'n' 't' 'i' 'n' 'u' 'e') _)> */
+		/* 14 CONT <- <(_ ('c' 'o' 'n' 't' 'i' 'n' 'u' 'e') (' ' / '\t')*)> */
 		nil,
-		/* 10 COUNT <- <(_ ('c' 'o' 'u' 'n' 't') _)> */
+		/* 15 COUNT <- <(_ ('c' 'o' 'u' 'n' 't') _)> */
 		nil,
-		/* 11 DECLARE <- <(_ ('d' 'e' 'c' 'l' 'a' 'r' 'e') __)> */
+		/* 16 DECLARE <- <(_ ('d' 'e' 'c' 'l' 'a' 'r' 'e') __)> */
 		nil,
-		/* 12 DOT <- <'.'> */
+		/* 17 DEF <- <(_ ('d' 'e' 'f') __)> */
 		nil,
-		/* 13 ELSE <- <(_ ('e' 'l' 's' 'e') _)> */
+		/* 18 DEFER <- <(_ ('d' 'e' 'f' 'e' 'r') __)> */
+		nil,
+		/* 19 DOT <- <'.'> */
+		nil,
+		/* 20 ELSE <- <(_ ('e' 'l' 's' 'e') _)> */
 		func() bool {
-			position44, tokenIndex44 := position, tokenIndex
+			position53, tokenIndex53 := position, tokenIndex
 			{
-				position45 := position
+				position54 := position
 				if !_rules[rule_]() {
-					goto l44
+					goto l53
 				}
 				if buffer[position] != rune('e') {
-					goto l44
+					goto l53
 				}
 				position++
 				if buffer[position] != rune('l') {
-					goto l44
+					goto l53
 				}
 				position++
 				if buffer[position] != rune('s') {
-					goto l44
+					goto l53
 				}
 				position++
 				if buffer[position] != rune('e') {
-					goto l44
+					goto l53
+				}
+				position++
+				if !_rules[rule_]() {
+					goto l53
+				}
+				add(ruleELSE, position54)
+			}
+			return true
+		l53:
+			position, tokenIndex = position53, tokenIndex53
+			return false
+		},
+		/* 21 EXIT <- <(_ ('e' 'x' 'i' 't') !([a-z] / [A-Z] / ([0-9] / [0-9]) / '_' / ':') (' ' / '\t')*)> */
+		nil,
+		/* 22 FINALLY <- <(_ ('f' 'i' 'n' 'a' 'l' 'l' 'y') _)> */
+		nil,
+		/* 23 GROUPCLOSE <- <(_ ')' _)> */
+		func() bool {
+			position57, tokenIndex57 := position, tokenIndex
+			{
+				position58 := position
+				if !_rules[rule_]() {
+					goto l57
+				}
+				if buffer[position] != rune(')') {
+					goto l57
+				}
+				position++
+				if !_rules[rule_]() {
+					goto l57
+				}
+				add(ruleGROUPCLOSE, position58)
+			}
+			return true
+		l57:
+			position, tokenIndex = position57, tokenIndex57
+			return false
+		},
+		/* 24 GROUPOPEN <- <(_ '(' _)> */
+		func() bool {
+			position59, tokenIndex59 := position, tokenIndex
+			{
+				position60 := position
+				if !_rules[rule_]() {
+					goto l59
+				}
+				if buffer[position] != rune('(') {
+					goto l59
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l44
+					goto l59
+				}
+				add(ruleGROUPOPEN, position60)
+			}
+			return true
+		l59:
+			position, tokenIndex = position59, tokenIndex59
+			return false
+		},
+		/* 25 IF <- <(_ ('i' 'f') _)> */
+		nil,
+		/* 26 IN <- <(__ ('i' 'n') __)> */
+		func() bool {
+			position62, tokenIndex62 := position, tokenIndex
+			{
+				position63 := position
+				if !_rules[rule__]() {
+					goto l62
+				}
+				if buffer[position] != rune('i') {
+					goto l62
+				}
+				position++
+				if buffer[position] != rune('n') {
+					goto l62
+				}
+				position++
+				if !_rules[rule__]() {
+					goto l62
 				}
-				add(ruleELSE, position45)
+				add(ruleIN, position63)
 			}
 			return true
-		l44:
-			position, tokenIndex = position44, tokenIndex44
+		l62:
+			position, tokenIndex = position62, tokenIndex62
 			return false
 		},
-		/* 14 GROUPCLOSE <- <(_ ')' _)> */
+		/* 27 INCLUDE <- <(_ ('i' 'n' 'c' 'l' 'u' 'd' 'e') __)> */
 		nil,
-		/* 15 GROUPOPEN <- <(_ '(' _)> */
+		/* 28 INTO <- <(_ ('i' 'n' 't' 'o') __)> */
 		nil,
-		/* 16 IF <- <(_ ('i' 'f') _)> */
+		/* 29 IS <- <(_ ('i' 's') __)> */
 		nil,
-		/* 17 IN <- <(__ ('i' 'n') __)> */
+		/* 30 JITTER <- <(_ ('j' 'i' 't' 't' 'e' 'r') !([a-z] / [A-Z] / ([0-9] / [0-9]) / '_') _)> */
 		nil,
-		/* 18 INCLUDE <- <(_ ('i' 'n' 'c' 'l' 'u' 'd' 'e') __)> */
+		/* 31 LOOP <- <(_ ('l' 'o' 'o' 'p') _)> */
 		nil,
-		/* 19 LOOP <- <(_ ('l' 'o' 'o' 'p') _)> */
+		/* 32 MATCH <- <(_ ('m' 'a' 't' 'c' 'h') __)> */
 		nil,
-		/* 20 NOOP <- <SEMI> */
+		/* 33 NOOP <- <SEMI> */
+		nil,
+		/* 34 NOT <- <(_ ('n' 'o' 't') __)> */
+		func() bool {
+			position71, tokenIndex71 := position, tokenIndex
+			{
+				position72 := position
+				if !_rules[rule_]() {
+					goto l71
+				}
+				if buffer[position] != rune('n') {
+					goto l71
+				}
+				position++
+				if buffer[position] != rune('o') {
+					goto l71
+				}
+				position++
+				if buffer[position] != rune('t') {
+					goto l71
+				}
+				position++
+				if !_rules[rule__]() {
+					goto l71
+				}
+				add(ruleNOT, position72)
+			}
+			return true
+		l71:
+			position, tokenIndex = position71, tokenIndex71
+			return false
+		},
+		/* 35 ON <- <(_ ('o' 'n') __)> */
 		nil,
-		/* 21 NOT <- <(_ ('n' 'o' 't') __)> */
+		/* 36 OPTDOT <- <('?' '.')> */
 		nil,
-		/* 22 OPEN <- <(_ '{' _)> */
+		/* 37 OPEN <- <(_ '{' _)> */
 		func() bool {
-			position54, tokenIndex54 := position, tokenIndex
+			position75, tokenIndex75 := position, tokenIndex
 			{
-				position55 := position
+				position76 := position
 				if !_rules[rule_]() {
-					goto l54
+					goto l75
 				}
 				if buffer[position] != rune('{') {
-					goto l54
+					goto l75
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l54
+					goto l75
 				}
-				add(ruleOPEN, position55)
+				add(ruleOPEN, position76)
 			}
 			return true
-		l54:
-			position, tokenIndex = position54, tokenIndex54
+		l75:
+			position, tokenIndex = position75, tokenIndex75
 			return false
 		},
-		/* 23 SCOPE <- <(':' ':')> */
+		/* 38 OR <- <(_ ('o' 'r') __)> */
 		nil,
-		/* 24 SEMI <- <(_ ';' _)> */
+		/* 39 PARALLEL <- <(_ ('p' 'a' 'r' 'a' 'l' 'l' 'e' 'l') __)> */
 		func() bool {
-			position57, tokenIndex57 := position, tokenIndex
+			position78, tokenIndex78 := position, tokenIndex
 			{
-				position58 := position
+				position79 := position
 				if !_rules[rule_]() {
-					goto l57
+					goto l78
 				}
-				if buffer[position] != rune(';') {
-					goto l57
+				if buffer[position] != rune('p') {
+					goto l78
 				}
 				position++
-				if !_rules[rule_]() {
-					goto l57
+				if buffer[position] != rune('a') {
+					goto l78
+				}
+				position++
+				if buffer[position] != rune('r') {
+					goto l78
+				}
+				position++
+				if buffer[position] != rune('a') {
+					goto l78
+				}
+				position++
+				if buffer[position] != rune('l') {
+					goto l78
+				}
+				position++
+				if buffer[position] != rune('l') {
+					goto l78
+				}
+				position++
+				if buffer[position] != rune('e') {
+					goto l78
+				}
+				position++
+				if buffer[position] != rune('l') {
+					goto l78
+				}
+				position++
+				if !_rules[rule__]() {
+					goto l78
 				}
-				add(ruleSEMI, position58)
+				add(rulePARALLEL, position79)
 			}
 			return true
-		l57:
-			position, tokenIndex = position57, tokenIndex57
+		l78:
+			position, tokenIndex = position78, tokenIndex78
 			return false
 		},
-		/* 25 SHEBANG <- <('#' '!' (!'\n' .)+ '\n')> */
+		/* 40 QUESTION <- <(_ '?' _)> */
+		nil,
+		/* 41 RANGE <- <(_ ('.' '.') _)> */
+		nil,
+		/* 42 RANGEEXCL <- <(_ ('.' '.' '<') _)> */
+		nil,
+		/* 43 RETRY <- <(_ ('r' 'e' 't' 'r' 'y') __)> */
+		nil,
+		/* 44 ROLLBACK <- <(_ ('r' 'o' 'l' 'l' 'b' 'a' 'c' 'k') _)> */
+		nil,
+		/* 45 RETURN <- <(_ ('r' 'e' 't' 'u' 'r' 'n') !([a-z] / [A-Z] / ([0-9] / [0-9]) / '_') (' ' / '\t')*)> */
 		nil,
-		/* 26 SKIPVAR <- <(_ '_' _)> */
+		/* 46 SCOPE <- <(':' ':')> */
 		nil,
-		/* 27 TRIQUOT <- <(_ ('"' '"' '"') _)> */
+		/* 47 SEMI <- <(_ ';' _)> */
 		func() bool {
-			position61, tokenIndex61 := position, tokenIndex
+			position87, tokenIndex87 := position, tokenIndex
 			{
-				position62 := position
+				position88 := position
 				if !_rules[rule_]() {
-					goto l61
+					goto l87
 				}
-				if buffer[position] != rune('"') {
-					goto l61
+				if buffer[position] != rune(';') {
+					goto l87
 				}
 				position++
+				if !_rules[rule_]() {
+					goto l87
+				}
+				add(ruleSEMI, position88)
+			}
+			return true
+		l87:
+			position, tokenIndex = position87, tokenIndex87
+			return false
+		},
+		/* 48 SHEBANG <- <('#' '!' (!'\n' .)+ '\n')> */
+		nil,
+		/* 49 STEP <- <(_ ('s' 't' 'e' 'p') __)> */
+		nil,
+		/* 50 TIMEOUT <- <(_ ('t' 'i' 'm' 'e' 'o' 'u' 't') __)> */
+		nil,
+		/* 51 TRANSACTION <- <(_ ('t' 'r' 'a' 'n' 's' 'a' 'c' 't' 'i' 'o' 'n') _)> */
+		nil,
+		/* 52 SKIPVAR <- <(_ '_' _)> */
+		nil,
+		/* 53 TRIQUOT <- <('"' '"' '"')> */
+		func() bool {
+			position94, tokenIndex94 := position, tokenIndex
+			{
+				position95 := position
 				if buffer[position] != rune('"') {
-					goto l61
+					goto l94
 				}
 				position++
 				if buffer[position] != rune('"') {
-					goto l61
+					goto l94
 				}
 				position++
-				if !_rules[rule_]() {
-					goto l61
+				if buffer[position] != rune('"') {
+					goto l94
 				}
-				add(ruleTRIQUOT, position62)
+				position++
+				add(ruleTRIQUOT, position95)
 			}
 			return true
-		l61:
-			position, tokenIndex = position61, tokenIndex61
+		l94:
+			position, tokenIndex = position94, tokenIndex94
 			return false
 		},
-		/* 28 UNSET <- <(_ ('u' 'n' 's' 'e' 't') __)> */
+		/* 54 TRY <- <(_ ('t' 'r' 'y') _)> */
+		nil,
+		/* 55 UNDO <- <(_ ('u' 'n' 'd' 'o') __)> */
+		nil,
+		/* 56 UNSET <- <(_ ('u' 'n' 's' 'e' 't') __)> */
 		nil,
-		/* 29 ScalarType <- <(Boolean / Float / Integer / String / NullValue)> */
+		/* 57 UNTIL <- <(_ ('u' 'n' 't' 'i' 'l') __)> */
 		nil,
-		/* 30 Identifier <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / ([0-9] / [0-9]) / '_')*)> */
+		/* 58 ScalarType <- <(Boolean / Timestamp / Duration / Decimal / Float / Integer / String / NullValue)> */
+		nil,
+		/* 59 Identifier <- <(([a-z] / [A-Z] / '_') ([a-z] / [A-Z] / ([0-9] / [0-9]) / '_')*)> */
 		func() bool {
-			position65, tokenIndex65 := position, tokenIndex
+			position101, tokenIndex101 := position, tokenIndex
 			{
-				position66 := position
+				position102 := position
 				{
-					position67, tokenIndex67 := position, tokenIndex
+					position103, tokenIndex103 := position, tokenIndex
 					if c := buffer[position]; c < rune('a') || c > rune('z') {
-						goto l68
+						goto l104
 					}
 					position++
-					goto l67
-				l68:
-					position, tokenIndex = position67, tokenIndex67
+					goto l103
+				l104:
+					position, tokenIndex = position103, tokenIndex103
 					if c := buffer[position]; c < rune('A') || c > rune('Z') {
-						goto l69
+						goto l105
 					}
 					position++
-					goto l67
-				l69:
-					position, tokenIndex = position67, tokenIndex67
+					goto l103
+				l105:
+					position, tokenIndex = position103, tokenIndex103
 					if buffer[position] != rune('_') {
-						goto l65
+						goto l101
 					}
 					position++
 				}
-			l67:
-			l70:
+			l103:
+			l106:
 				{
-					position71, tokenIndex71 := position, tokenIndex
+					position107, tokenIndex107 := position, tokenIndex
 					{
-						position72, tokenIndex72 := position, tokenIndex
+						position108, tokenIndex108 := position, tokenIndex
 						if c := buffer[position]; c < rune('a') || c > rune('z') {
-							goto l73
+							goto l109
 						}
 						position++
-						goto l72
-					l73:
-						position, tokenIndex = position72, tokenIndex72
+						goto l108
+					l109:
+						position, tokenIndex = position108, tokenIndex108
 						if c := buffer[position]; c < rune('A') || c > rune('Z') {
-							goto l74
+							goto l110
 						}
 						position++
-						goto l72
-					l74:
-						position, tokenIndex = position72, tokenIndex72
+						goto l108
+					l110:
+						position, tokenIndex = position108, tokenIndex108
 						{
-							position76, tokenIndex76 := position, tokenIndex
+							position112, tokenIndex112 := position, tokenIndex
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l77
+								goto l113
 							}
 							position++
-							goto l76
-						l77:
-							position, tokenIndex = position76, tokenIndex76
+							goto l112
+						l113:
+							position, tokenIndex = position112, tokenIndex112
 							if c := buffer[position]; c < rune('0') || c > rune('9') {
-								goto l75
+								goto l111
 							}
 							position++
 						}
-					l76:
-						goto l72
-					l75:
-						position, tokenIndex = position72, tokenIndex72
+					l112:
+						goto l108
+					l111:
+						position, tokenIndex = position108, tokenIndex108
 						if buffer[position] != rune('_') {
-							goto l71
+							goto l107
+						}
+						position++
+					}
+				l108:
+					goto l106
+				l107:
+					position, tokenIndex = position107, tokenIndex107
+				}
+				add(ruleIdentifier, position102)
+			}
+			return true
+		l101:
+			position, tokenIndex = position101, tokenIndex101
+			return false
+		},
+		/* 60 Float <- <(Integer ('.' [0-9]+)?)> */
+		func() bool {
+			position114, tokenIndex114 := position, tokenIndex
+			{
+				position115 := position
+				if !_rules[ruleInteger]() {
+					goto l114
+				}
+				{
+					position116, tokenIndex116 := position, tokenIndex
+					if buffer[position] != rune('.') {
+						goto l116
+					}
+					position++
+					if c := buffer[position]; c < rune('0') || c > rune('9') {
+						goto l116
+					}
+					position++
+				l118:
+					{
+						position119, tokenIndex119 := position, tokenIndex
+						if c := buffer[position]; c < rune('0') || c > rune('9') {
+							goto l119
 						}
 						position++
+						goto l118
+					l119:
+						position, tokenIndex = position119, tokenIndex119
 					}
-				l72:
-					goto l70
-				l71:
-					position, tokenIndex = position71, tokenIndex71
+					goto l117
+				l116:
+					position, tokenIndex = position116, tokenIndex116
 				}
-				add(ruleIdentifier, position66)
+			l117:
+				add(ruleFloat, position115)
 			}
 			return true
-		l65:
-			position, tokenIndex = position65, tokenIndex65
+		l114:
+			position, tokenIndex = position114, tokenIndex114
 			return false
 		},
-		/* 31 Float <- <(Integer ('.' [0-9]+)?)> */
+		/* 61 Decimal <- <(Integer ('.' [0-9]+)? 'd' !([a-z] / [A-Z] / ([0-9] / [0-9]) / '_'))> */
+		nil,
+		/* 62 Duration <- <((PositiveInteger ('.' [0-9]+)? DurationUnit)+ !([a-z] / [A-Z] / ([0-9] / [0-9]) / '_'))> */
 		nil,
-		/* 32 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
+		/* 63 DurationUnit <- <(('n' 's') / ('u' 's') / ('m' 's') / 's' / 'm' / 'h')> */
 		nil,
-		/* 33 Integer <- <('-'? PositiveInteger)> */
+		/* 64 Timestamp <- <('@' [0-9] ([0-9] / (':' / '.' / '+' / 'T' / 'Z' / 't' / 'z') / '-')*)> */
+		nil,
+		/* 65 Boolean <- <(('t' 'r' 'u' 'e') / ('f' 'a' 'l' 's' 'e'))> */
+		nil,
+		/* 66 Integer <- <('-'? PositiveInteger)> */
 		func() bool {
-			position80, tokenIndex80 := position, tokenIndex
+			position125, tokenIndex125 := position, tokenIndex
 			{
-				position81 := position
+				position126 := position
 				{
-					position82, tokenIndex82 := position, tokenIndex
+					position127, tokenIndex127 := position, tokenIndex
 					if buffer[position] != rune('-') {
-						goto l82
+						goto l127
 					}
 					position++
-					goto l83
-				l82:
-					position, tokenIndex = position82, tokenIndex82
+					goto l128
+				l127:
+					position, tokenIndex = position127, tokenIndex127
 				}
-			l83:
+			l128:
 				if !_rules[rulePositiveInteger]() {
-					goto l80
+					goto l125
 				}
-				add(ruleInteger, position81)
+				add(ruleInteger, position126)
 			}
 			return true
-		l80:
-			position, tokenIndex = position80, tokenIndex80
+		l125:
+			position, tokenIndex = position125, tokenIndex125
 			return false
 		},
-		/* 34 PositiveInteger <- <[0-9]+> */
+		/* 67 PositiveInteger <- <[0-9]+> */
 		func() bool {
-			position84, tokenIndex84 := position, tokenIndex
+			position129, tokenIndex129 := position, tokenIndex
 			{
-				position85 := position
+				position130 := position
 				if c := buffer[position]; c < rune('0') || c > rune('9') {
-					goto l84
+					goto l129
 				}
 				position++
-			l86:
+			l131:
 				{
-					position87, tokenIndex87 := position, tokenIndex
+					position132, tokenIndex132 := position, tokenIndex
 					if c := buffer[position]; c < rune('0') || c > rune('9') {
-						goto l87
+						goto l132
 					}
 					position++
-					goto l86
-				l87:
-					position, tokenIndex = position87, tokenIndex87
+					goto l131
+				l132:
+					position, tokenIndex = position132, tokenIndex132
 				}
-				add(rulePositiveInteger, position85)
+				add(rulePositiveInteger, position130)
 			}
 			return true
-		l84:
-			position, tokenIndex = position84, tokenIndex84
+		l129:
+			position, tokenIndex = position129, tokenIndex129
 			return false
 		},
-		/* 35 String <- <(Triquote / StringLiteral / StringInterpolated)> */
+		/* 68 String <- <(Triquote / StringLiteral / StringInterpolated)> */
 		func() bool {
-			position88, tokenIndex88 := position, tokenIndex
+			position133, tokenIndex133 := position, tokenIndex
 			{
-				position89 := position
+				position134 := position
 				{
-					position90, tokenIndex90 := position, tokenIndex
+					position135, tokenIndex135 := position, tokenIndex
 					{
-						position92 := position
+						position137 := position
+						if !_rules[rule_]() {
+							goto l136
+						}
 						if !_rules[ruleTRIQUOT]() {
-							goto l91
+							goto l136
 						}
 						{
-							position93 := position
-						l94:
+							position138 := position
+						l139:
 							{
-								position95, tokenIndex95 := position, tokenIndex
+								position140, tokenIndex140 := position, tokenIndex
 								{
-									position96, tokenIndex96 := position, tokenIndex
+									position141, tokenIndex141 := position, tokenIndex
 									if !_rules[ruleTRIQUOT]() {
-										goto l96
+										goto l141
 									}
-									goto l95
-								l96:
-									position, tokenIndex = position96, tokenIndex96
+									goto l140
+								l141:
+									position, tokenIndex = position141, tokenIndex141
 								}
 								if !matchDot() {
-									goto l95
+									goto l140
 								}
-								goto l94
-							l95:
-								position, tokenIndex = position95, tokenIndex95
+								goto l139
+							l140:
+								position, tokenIndex = position140, tokenIndex140
 							}
-							add(ruleTriquoteBody, position93)
+							add(ruleTriquoteBody, position138)
 						}
 						if !_rules[ruleTRIQUOT]() {
-							goto l91
+							goto l136
+						}
+						if !_rules[rule_]() {
+							goto l136
 						}
-						add(ruleTriquote, position92)
+						add(ruleTriquote, position137)
 					}
-					goto l90
-				l91:
-					position, tokenIndex = position90, tokenIndex90
+					goto l135
+				l136:
+					position, tokenIndex = position135, tokenIndex135
 					if !_rules[ruleStringLiteral]() {
-						goto l97
+						goto l142
 					}
-					goto l90
-				l97:
-					position, tokenIndex = position90, tokenIndex90
+					goto l135
+				l142:
+					position, tokenIndex = position135, tokenIndex135
 					if !_rules[ruleStringInterpolated]() {
-						goto l88
+						goto l133
 					}
 				}
-			l90:
-				add(ruleString, position89)
+			l135:
+				add(ruleString, position134)
 			}
 			return true
-		l88:
-			position, tokenIndex = position88, tokenIndex88
+		l133:
+			position, tokenIndex = position133, tokenIndex133
 			return false
 		},
-		/* 36 StringLiteral <- <('\'' (!'\'' .)* '\'')> */
+		/* 69 StringLiteral <- <('\'' (('\\' .) / (!('\'' / '\\') .))* '\'')> */
 		func() bool {
-			position98, tokenIndex98 := position, tokenIndex
+			position143, tokenIndex143 := position, tokenIndex
 			{
-				position99 := position
+				position144 := position
 				if buffer[position] != rune('\'') {
-					goto l98
+					goto l143
 				}
 				position++
-			l100:
+			l145:
 				{
-					position101, tokenIndex101 := position, tokenIndex
+					position146, tokenIndex146 := position, tokenIndex
 					{
-						position102, tokenIndex102 := position, tokenIndex
-						if buffer[position] != rune('\'') {
-							goto l102
+						position147, tokenIndex147 := position, tokenIndex
+						if buffer[position] != rune('\\') {
+							goto l148
 						}
 						position++
-						goto l101
-					l102:
-						position, tokenIndex = position102, tokenIndex102
-					}
-					if !matchDot() {
-						goto l101
+						if !matchDot() {
+							goto l148
+						}
+						goto l147
+					l148:
+						position, tokenIndex = position147, tokenIndex147
+						{
+							position149, tokenIndex149 := position, tokenIndex
+							{
+								position150, tokenIndex150 := position, tokenIndex
+								if buffer[position] != rune('\'') {
+									goto l151
+								}
+								position++
+								goto l150
+							l151:
+								position, tokenIndex = position150, tokenIndex150
+								if buffer[position] != rune('\\') {
+									goto l149
+								}
+								position++
+							}
+						l150:
+							goto l146
+						l149:
+							position, tokenIndex = position149, tokenIndex149
+						}
+						if !matchDot() {
+							goto l146
+						}
 					}
-					goto l100
-				l101:
-					position, tokenIndex = position101, tokenIndex101
+				l147:
+					goto l145
+				l146:
+					position, tokenIndex = position146, tokenIndex146
 				}
 				if buffer[position] != rune('\'') {
-					goto l98
+					goto l143
 				}
 				position++
-				add(ruleStringLiteral, position99)
+				add(ruleStringLiteral, position144)
 			}
 			return true
-		l98:
-			position, tokenIndex = position98, tokenIndex98
+		l143:
+			position, tokenIndex = position143, tokenIndex143
 			return false
 		},
-		/* 37 StringInterpolated <- <('"' (!'"' .)* '"')> */
+		/* 70 StringInterpolated <- <('"' (('\\' .) / (!('"' / '\\') .))* '"')> */
 		func() bool {
-			position103, tokenIndex103 := position, tokenIndex
+			position152, tokenIndex152 := position, tokenIndex
 			{
-				position104 := position
+				position153 := position
 				if buffer[position] != rune('"') {
-					goto l103
+					goto l152
 				}
 				position++
-			l105:
+			l154:
 				{
-					position106, tokenIndex106 := position, tokenIndex
+					position155, tokenIndex155 := position, tokenIndex
 					{
-						position107, tokenIndex107 := position, tokenIndex
-						if buffer[position] != rune('"') {
-							goto l107
+						position156, tokenIndex156 := position, tokenIndex
+						if buffer[position] != rune('\\') {
+							goto l157
 						}
 						position++
-						goto l106
-					l107:
-						position, tokenIndex = position107, tokenIndex107
-					}
-					if !matchDot() {
-						goto l106
+						if !matchDot() {
+							goto l157
+						}
+						goto l156
+					l157:
+						position, tokenIndex = position156, tokenIndex156
+						{
+							position158, tokenIndex158 := position, tokenIndex
+							{
+								position159, tokenIndex159 := position, tokenIndex
+								if buffer[position] != rune('"') {
+									goto l160
+								}
+								position++
+								goto l159
+							l160:
+								position, tokenIndex = position159, tokenIndex159
+								if buffer[position] != rune('\\') {
+									goto l158
+								}
+								position++
+							}
+						l159:
+							goto l155
+						l158:
+							position, tokenIndex = position158, tokenIndex158
+						}
+						if !matchDot() {
+							goto l155
+						}
 					}
-					goto l105
-				l106:
-					position, tokenIndex = position106, tokenIndex106
+				l156:
+					goto l154
+				l155:
+					position, tokenIndex = position155, tokenIndex155
 				}
 				if buffer[position] != rune('"') {
-					goto l103
+					goto l152
 				}
 				position++
-				add(ruleStringInterpolated, position104)
+				add(ruleStringInterpolated, position153)
 			}
 			return true
-		l103:
-			position, tokenIndex = position103, tokenIndex103
+		l152:
+			position, tokenIndex = position152, tokenIndex152
 			return false
 		},
-		/* 38 Triquote <- <(TRIQUOT TriquoteBody TRIQUOT)> */
+		/* 71 Triquote <- <(_ TRIQUOT TriquoteBody TRIQUOT _)> */
 		nil,
-		/* 39 TriquoteBody <- <(!TRIQUOT .)*> */
+		/* 72 TriquoteBody <- <(!TRIQUOT .)*> */
 		nil,
-		/* 40 NullValue <- <('n' 'u' 'l' 'l')> */
+		/* 73 NullValue <- <('n' 'u' 'l' 'l')> */
 		nil,
-		/* 41 Object <- <(OPEN (_ KeyValuePair _)* CLOSE)> */
+		/* 74 Object <- <(OPEN (_ KeyValuePair _)* CLOSE)> */
 		func() bool {
-			position111, tokenIndex111 := position, tokenIndex
+			position164, tokenIndex164 := position, tokenIndex
 			{
-				position112 := position
+				position165 := position
 				if !_rules[ruleOPEN]() {
-					goto l111
+					goto l164
 				}
-			l113:
+			l166:
 				{
-					position114, tokenIndex114 := position, tokenIndex
+					position167, tokenIndex167 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l114
+						goto l167
 					}
 					{
-						position115 := position
-						{
-							position116 := position
-							{
-								position117, tokenIndex117 := position, tokenIndex
-								if !_rules[ruleIdentifier]() {
-									goto l118
-								}
-								goto l117
-							l118:
-								position, tokenIndex = position117, tokenIndex117
-								if !_rules[ruleStringLiteral]() {
-									goto l119
-								}
-								goto l117
-							l119:
-								position, tokenIndex = position117, tokenIndex117
-								if !_rules[ruleStringInterpolated]() {
-									goto l114
-								}
-							}
-						l117:
-							add(ruleKey, position116)
+						position168 := position
+						if !_rules[ruleKey]() {
+							goto l167
 						}
-						{
-							position120 := position
-							if !_rules[rule_]() {
-								goto l114
-							}
-							if buffer[position] != rune(':') {
-								goto l114
-							}
-							position++
-							if !_rules[rule_]() {
-								goto l114
-							}
-							add(ruleCOLON, position120)
+						if !_rules[ruleCOLON]() {
+							goto l167
 						}
 						{
-							position121 := position
+							position169 := position
 							{
-								position122, tokenIndex122 := position, tokenIndex
+								position170, tokenIndex170 := position, tokenIndex
 								if !_rules[ruleArray]() {
-									goto l123
+									goto l171
 								}
-								goto l122
-							l123:
-								position, tokenIndex = position122, tokenIndex122
+								goto l170
+							l171:
+								position, tokenIndex = position170, tokenIndex170
 								if !_rules[ruleObject]() {
-									goto l124
+									goto l172
 								}
-								goto l122
-							l124:
-								position, tokenIndex = position122, tokenIndex122
+								goto l170
+							l172:
+								position, tokenIndex = position170, tokenIndex170
 								if !_rules[ruleExpression]() {
-									goto l114
+									goto l167
 								}
 							}
-						l122:
-							add(ruleKValue, position121)
+						l170:
+							add(ruleKValue, position169)
 						}
 						{
-							position125, tokenIndex125 := position, tokenIndex
+							position173, tokenIndex173 := position, tokenIndex
 							if !_rules[ruleCOMMA]() {
-								goto l125
+								goto l173
 							}
-							goto l126
-						l125:
-							position, tokenIndex = position125, tokenIndex125
+							goto l174
+						l173:
+							position, tokenIndex = position173, tokenIndex173
 						}
-					l126:
-						add(ruleKeyValuePair, position115)
+					l174:
+						add(ruleKeyValuePair, position168)
 					}
 					if !_rules[rule_]() {
-						goto l114
+						goto l167
 					}
-					goto l113
-				l114:
-					position, tokenIndex = position114, tokenIndex114
+					goto l166
+				l167:
+					position, tokenIndex = position167, tokenIndex167
 				}
 				if !_rules[ruleCLOSE]() {
-					goto l111
+					goto l164
 				}
-				add(ruleObject, position112)
+				add(ruleObject, position165)
 			}
 			return true
-		l111:
-			position, tokenIndex = position111, tokenIndex111
+		l164:
+			position, tokenIndex = position164, tokenIndex164
 			return false
 		},
-		/* 42 Array <- <('[' _ ExpressionSequence COMMA? ']')> */
+		/* 75 Array <- <('[' _ ExpressionSequence COMMA? ']')> */
 		func() bool {
-			position127, tokenIndex127 := position, tokenIndex
+			position175, tokenIndex175 := position, tokenIndex
 			{
-				position128 := position
+				position176 := position
 				if buffer[position] != rune('[') {
-					goto l127
+					goto l175
 				}
 				position++
 				if !_rules[rule_]() {
-					goto l127
+					goto l175
 				}
 				if !_rules[ruleExpressionSequence]() {
-					goto l127
+					goto l175
 				}
 				{
-					position129, tokenIndex129 := position, tokenIndex
+					position177, tokenIndex177 := position, tokenIndex
 					if !_rules[ruleCOMMA]() {
-						goto l129
+						goto l177
 					}
-					goto l130
-				l129:
-					position, tokenIndex = position129, tokenIndex129
+					goto l178
+				l177:
+					position, tokenIndex = position177, tokenIndex177
 				}
-			l130:
+			l178:
 				if buffer[position] != rune(']') {
-					goto l127
+					goto l175
 				}
 				position++
-				add(ruleArray, position128)
+				add(ruleArray, position176)
 			}
 			return true
-		l127:
-			position, tokenIndex = position127, tokenIndex127
+		l175:
+			position, tokenIndex = position175, tokenIndex175
 			return false
 		},
-		/* 43 RegularExpression <- <('/' (!'/' .)+ '/' ('i' / 'l' / 'm' / 's' / 'u')*)> */
+		/* 76 RegularExpression <- <('/' (!'/' .)+ '/' ('i' / 'l' / 'm' / 's' / 'u')*)> */
 		func() bool {
-			position131, tokenIndex131 := position, tokenIndex
+			position179, tokenIndex179 := position, tokenIndex
 			{
-				position132 := position
+				position180 := position
 				if buffer[position] != rune('/') {
-					goto l131
+					goto l179
 				}
 				position++
 				{
-					position135, tokenIndex135 := position, tokenIndex
+					position183, tokenIndex183 := position, tokenIndex
 					if buffer[position] != rune('/') {
-						goto l135
+						goto l183
 					}
 					position++
-					goto l131
-				l135:
-					position, tokenIndex = position135, tokenIndex135
+					goto l179
+				l183:
+					position, tokenIndex = position183, tokenIndex183
 				}
 				if !matchDot() {
-					goto l131
+					goto l179
 				}
-			l133:
+			l181:
 				{
-					position134, tokenIndex134 := position, tokenIndex
+					position182, tokenIndex182 := position, tokenIndex
 					{
-						position136, tokenIndex136 := position, tokenIndex
+						position184, tokenIndex184 := position, tokenIndex
 						if buffer[position] != rune('/') {
-							goto l136
+							goto l184
 						}
 						position++
-						goto l134
-					l136:
-						position, tokenIndex = position136, tokenIndex136
+						goto l182
+					l184:
+						position, tokenIndex = position184, tokenIndex184
 					}
 					if !matchDot() {
-						goto l134
+						goto l182
 					}
-					goto l133
-				l134:
-					position, tokenIndex = position134, tokenIndex134
+					goto l181
+				l182:
+					position, tokenIndex = position182, tokenIndex182
 				}
 				if buffer[position] != rune('/') {
-					goto l131
+					goto l179
 				}
 				position++
-			l137:
+			l185:
 				{
-					position138, tokenIndex138 := position, tokenIndex
+					position186, tokenIndex186 := position, tokenIndex
 					{
-						position139, tokenIndex139 := position, tokenIndex
+						position187, tokenIndex187 := position, tokenIndex
 						if buffer[position] != rune('i') {
-							goto l140
+							goto l188
 						}
 						position++
-						goto l139
-					l140:
-						position, tokenIndex = position139, tokenIndex139
+						goto l187
+					l188:
+						position, tokenIndex = position187, tokenIndex187
 						if buffer[position] != rune('l') {
-							goto l141
+							goto l189
 						}
 						position++
-						goto l139
-					l141:
-						position, tokenIndex = position139, tokenIndex139
+						goto l187
+					l189:
+						position, tokenIndex = position187, tokenIndex187
 						if buffer[position] != rune('m') {
-							goto l142
+							goto l190
 						}
 						position++
-						goto l139
-					l142:
-						position, tokenIndex = position139, tokenIndex139
+						goto l187
+					l190:
+						position, tokenIndex = position187, tokenIndex187
 						if buffer[position] != rune('s') {
-							goto l143
+							goto l191
 						}
 						position++
-						goto l139
-					l143:
-						position, tokenIndex = position139, tokenIndex139
+						goto l187
+					l191:
+						position, tokenIndex = position187, tokenIndex187
 						if buffer[position] != rune('u') {
-							goto l138
+							goto l186
 						}
 						position++
 					}
-				l139:
-					goto l137
-				l138:
-					position, tokenIndex = position138, tokenIndex138
+				l187:
+					goto l185
+				l186:
+					position, tokenIndex = position186, tokenIndex186
 				}
-				add(ruleRegularExpression, position132)
+				add(ruleRegularExpression, position180)
 			}
 			return true
-		l131:
-			position, tokenIndex = position131, tokenIndex131
+		l179:
+			position, tokenIndex = position179, tokenIndex179
 			return false
 		},
-		/* 44 KeyValuePair <- <(Key COLON KValue COMMA?)> */
-		nil,
-		/* 45 Key <- <(Identifier / StringLiteral / StringInterpolated)> */
-		nil,
-		/* 46 KValue <- <(Array / Object / Expression)> */
+		/* 77 KeyValuePair <- <(Key COLON KValue COMMA?)> */
 		nil,
-		/* 47 Type <- <(Array / Object / RegularExpression / ScalarType)> */
+		/* 78 Key <- <(Identifier / StringLiteral / StringInterpolated)> */
 		func() bool {
-			position147, tokenIndex147 := position, tokenIndex
+			position193, tokenIndex193 := position, tokenIndex
 			{
-				position148 := position
+				position194 := position
 				{
-					position149, tokenIndex149 := position, tokenIndex
-					if !_rules[ruleArray]() {
-						goto l150
+					position195, tokenIndex195 := position, tokenIndex
+					if !_rules[ruleIdentifier]() {
+						goto l196
 					}
-					goto l149
-				l150:
-					position, tokenIndex = position149, tokenIndex149
-					if !_rules[ruleObject]() {
-						goto l151
+					goto l195
+				l196:
+					position, tokenIndex = position195, tokenIndex195
+					if !_rules[ruleStringLiteral]() {
+						goto l197
 					}
-					goto l149
-				l151:
-					position, tokenIndex = position149, tokenIndex149
-					if !_rules[ruleRegularExpression]() {
-						goto l152
+					goto l195
+				l197:
+					position, tokenIndex = position195, tokenIndex195
+					if !_rules[ruleStringInterpolated]() {
+						goto l193
 					}
-					goto l149
-				l152:
-					position, tokenIndex = position149, tokenIndex149
-					{
-						position153 := position
-						{
-							position154, tokenIndex154 := position, tokenIndex
-							{
-								position156 := position
-								{
-									position157, tokenIndex157 := position, tokenIndex
-									if buffer[position] != rune('t') {
-										goto l158
-									}
+				}
+			l195:
+				add(ruleKey, position194)
+			}
+			return true
+		l193:
+			position, tokenIndex = position193, tokenIndex193
+			return false
+		},
+		/* 79 KValue <- <(Array / Object / Expression)> */
+		nil,
+		/* 80 Type <- <(Array / Object / RegularExpression / ScalarType)> */
+		func() bool {
+			position199, tokenIndex199 := position, tokenIndex
+			{
+				position200 := position
+				{
+					position201, tokenIndex201 := position, tokenIndex
+					if !_rules[ruleArray]() {
+						goto l202
+					}
+					goto l201
+				l202:
+					position, tokenIndex = position201, tokenIndex201
+					if !_rules[ruleObject]() {
+						goto l203
+					}
+					goto l201
+				l203:
+					position, tokenIndex = position201, tokenIndex201
+					if !_rules[ruleRegularExpression]() {
+						goto l204
+					}
+					goto l201
+				l204:
+					position, tokenIndex = position201, tokenIndex201
+					{
+						position205 := position
+						{
+							position206, tokenIndex206 := position, tokenIndex
+							{
+								position208 := position
+								{
+									position209, tokenIndex209 := position, tokenIndex
+									if buffer[position] != rune('t') {
+										goto l210
+									}
 									position++
 									if buffer[position] != rune('r') {
-										goto l158
+										goto l210
 									}
 									position++
 									if buffer[position] != rune('u') {
-										goto l158
+										goto l210
 									}
 									position++
 									if buffer[position] != rune('e') {
-										goto l158
+										goto l210
 									}
 									position++
-									goto l157
-								l158:
-									position, tokenIndex = position157, tokenIndex157
+									goto l209
+								l210:
+									position, tokenIndex = position209, tokenIndex209
 									if buffer[position] != rune('f') {
-										goto l155
+										goto l207
 									}
 									position++
 									if buffer[position] != rune('a') {
-										goto l155
+										goto l207
 									}
 									position++
 									if buffer[position] != rune('l') {
-										goto l155
+										goto l207
 									}
 									position++
 									if buffer[position] != rune('s') {
-										goto l155
+										goto l207
 									}
 									position++
 									if buffer[position] != rune('e') {
-										goto l155
+										goto l207
+									}
+									position++
+								}
+							l209:
+								add(ruleBoolean, position208)
+							}
+							goto l206
+						l207:
+							position, tokenIndex = position206, tokenIndex206
+							{
+								position212 := position
+								if buffer[position] != rune('@') {
+									goto l211
+								}
+								position++
+								if c := buffer[position]; c < rune('0') || c > rune('9') {
+									goto l211
+								}
+								position++
+							l213:
+								{
+									position214, tokenIndex214 := position, tokenIndex
+									{
+										position215, tokenIndex215 := position, tokenIndex
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l216
+										}
+										position++
+										goto l215
+									l216:
+										position, tokenIndex = position215, tokenIndex215
+										{
+											position218, tokenIndex218 := position, tokenIndex
+											if buffer[position] != rune(':') {
+												goto l219
+											}
+											position++
+											goto l218
+										l219:
+											position, tokenIndex = position218, tokenIndex218
+											if buffer[position] != rune('.') {
+												goto l220
+											}
+											position++
+											goto l218
+										l220:
+											position, tokenIndex = position218, tokenIndex218
+											if buffer[position] != rune('+') {
+												goto l221
+											}
+											position++
+											goto l218
+										l221:
+											position, tokenIndex = position218, tokenIndex218
+											if buffer[position] != rune('T') {
+												goto l222
+											}
+											position++
+											goto l218
+										l222:
+											position, tokenIndex = position218, tokenIndex218
+											if buffer[position] != rune('Z') {
+												goto l223
+											}
+											position++
+											goto l218
+										l223:
+											position, tokenIndex = position218, tokenIndex218
+											if buffer[position] != rune('t') {
+												goto l224
+											}
+											position++
+											goto l218
+										l224:
+											position, tokenIndex = position218, tokenIndex218
+											if buffer[position] != rune('z') {
+												goto l217
+											}
+											position++
+										}
+									l218:
+										goto l215
+									l217:
+										position, tokenIndex = position215, tokenIndex215
+										if buffer[position] != rune('-') {
+											goto l214
+										}
+										position++
+									}
+								l215:
+									goto l213
+								l214:
+									position, tokenIndex = position214, tokenIndex214
+								}
+								add(ruleTimestamp, position212)
+							}
+							goto l206
+						l211:
+							position, tokenIndex = position206, tokenIndex206
+							{
+								position226 := position
+								if !_rules[rulePositiveInteger]() {
+									goto l225
+								}
+								{
+									position229, tokenIndex229 := position, tokenIndex
+									if buffer[position] != rune('.') {
+										goto l229
+									}
+									position++
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l229
 									}
 									position++
+								l231:
+									{
+										position232, tokenIndex232 := position, tokenIndex
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l232
+										}
+										position++
+										goto l231
+									l232:
+										position, tokenIndex = position232, tokenIndex232
+									}
+									goto l230
+								l229:
+									position, tokenIndex = position229, tokenIndex229
+								}
+							l230:
+								{
+									position233 := position
+									{
+										position234, tokenIndex234 := position, tokenIndex
+										if buffer[position] != rune('n') {
+											goto l235
+										}
+										position++
+										if buffer[position] != rune('s') {
+											goto l235
+										}
+										position++
+										goto l234
+									l235:
+										position, tokenIndex = position234, tokenIndex234
+										if buffer[position] != rune('u') {
+											goto l236
+										}
+										position++
+										if buffer[position] != rune('s') {
+											goto l236
+										}
+										position++
+										goto l234
+									l236:
+										position, tokenIndex = position234, tokenIndex234
+										if buffer[position] != rune('m') {
+											goto l237
+										}
+										position++
+										if buffer[position] != rune('s') {
+											goto l237
+										}
+										position++
+										goto l234
+									l237:
+										position, tokenIndex = position234, tokenIndex234
+										if buffer[position] != rune('s') {
+											goto l238
+										}
+										position++
+										goto l234
+									l238:
+										position, tokenIndex = position234, tokenIndex234
+										if buffer[position] != rune('m') {
+											goto l239
+										}
+										position++
+										goto l234
+									l239:
+										position, tokenIndex = position234, tokenIndex234
+										if buffer[position] != rune('h') {
+											goto l225
+										}
+										position++
+									}
+								l234:
+									add(ruleDurationUnit, position233)
+								}
+							l227:
+								{
+									position228, tokenIndex228 := position, tokenIndex
+									if !_rules[rulePositiveInteger]() {
+										goto l228
+									}
+									{
+										position240, tokenIndex240 := position, tokenIndex
+										if buffer[position] != rune('.') {
+											goto l240
+										}
+										position++
+										if c := buffer[position]; c < rune('0') || c > rune('9') {
+											goto l240
+										}
+										position++
+									l242:
+										{
+											position243, tokenIndex243 := position, tokenIndex
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l243
+											}
+											position++
+											goto l242
+										l243:
+											position, tokenIndex = position243, tokenIndex243
+										}
+										goto l241
+									l240:
+										position, tokenIndex = position240, tokenIndex240
+									}
+								l241:
+									{
+										position244 := position
+										{
+											position245, tokenIndex245 := position, tokenIndex
+											if buffer[position] != rune('n') {
+												goto l246
+											}
+											position++
+											if buffer[position] != rune('s') {
+												goto l246
+											}
+											position++
+											goto l245
+										l246:
+											position, tokenIndex = position245, tokenIndex245
+											if buffer[position] != rune('u') {
+												goto l247
+											}
+											position++
+											if buffer[position] != rune('s') {
+												goto l247
+											}
+											position++
+											goto l245
+										l247:
+											position, tokenIndex = position245, tokenIndex245
+											if buffer[position] != rune('m') {
+												goto l248
+											}
+											position++
+											if buffer[position] != rune('s') {
+												goto l248
+											}
+											position++
+											goto l245
+										l248:
+											position, tokenIndex = position245, tokenIndex245
+											if buffer[position] != rune('s') {
+												goto l249
+											}
+											position++
+											goto l245
+										l249:
+											position, tokenIndex = position245, tokenIndex245
+											if buffer[position] != rune('m') {
+												goto l250
+											}
+											position++
+											goto l245
+										l250:
+											position, tokenIndex = position245, tokenIndex245
+											if buffer[position] != rune('h') {
+												goto l228
+											}
+											position++
+										}
+									l245:
+										add(ruleDurationUnit, position244)
+									}
+									goto l227
+								l228:
+									position, tokenIndex = position228, tokenIndex228
+								}
+								{
+									position251, tokenIndex251 := position, tokenIndex
+									{
+										position252, tokenIndex252 := position, tokenIndex
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l253
+										}
+										position++
+										goto l252
+									l253:
+										position, tokenIndex = position252, tokenIndex252
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l254
+										}
+										position++
+										goto l252
+									l254:
+										position, tokenIndex = position252, tokenIndex252
+										{
+											position256, tokenIndex256 := position, tokenIndex
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l257
+											}
+											position++
+											goto l256
+										l257:
+											position, tokenIndex = position256, tokenIndex256
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l255
+											}
+											position++
+										}
+									l256:
+										goto l252
+									l255:
+										position, tokenIndex = position252, tokenIndex252
+										if buffer[position] != rune('_') {
+											goto l251
+										}
+										position++
+									}
+								l252:
+									goto l225
+								l251:
+									position, tokenIndex = position251, tokenIndex251
 								}
-							l157:
-								add(ruleBoolean, position156)
+								add(ruleDuration, position226)
 							}
-							goto l154
-						l155:
-							position, tokenIndex = position154, tokenIndex154
+							goto l206
+						l225:
+							position, tokenIndex = position206, tokenIndex206
 							{
-								position160 := position
+								position259 := position
 								if !_rules[ruleInteger]() {
-									goto l159
+									goto l258
 								}
 								{
-									position161, tokenIndex161 := position, tokenIndex
+									position260, tokenIndex260 := position, tokenIndex
 									if buffer[position] != rune('.') {
-										goto l161
+										goto l260
 									}
 									position++
 									if c := buffer[position]; c < rune('0') || c > rune('9') {
-										goto l161
+										goto l260
 									}
 									position++
-								l163:
+								l262:
 									{
-										position164, tokenIndex164 := position, tokenIndex
+										position263, tokenIndex263 := position, tokenIndex
 										if c := buffer[position]; c < rune('0') || c > rune('9') {
-											goto l164
+											goto l263
+										}
+										position++
+										goto l262
+									l263:
+										position, tokenIndex = position263, tokenIndex263
+									}
+									goto l261
+								l260:
+									position, tokenIndex = position260, tokenIndex260
+								}
+							l261:
+								if buffer[position] != rune('d') {
+									goto l258
+								}
+								position++
+								{
+									position264, tokenIndex264 := position, tokenIndex
+									{
+										position265, tokenIndex265 := position, tokenIndex
+										if c := buffer[position]; c < rune('a') || c > rune('z') {
+											goto l266
+										}
+										position++
+										goto l265
+									l266:
+										position, tokenIndex = position265, tokenIndex265
+										if c := buffer[position]; c < rune('A') || c > rune('Z') {
+											goto l267
+										}
+										position++
+										goto l265
+									l267:
+										position, tokenIndex = position265, tokenIndex265
+										{
+											position269, tokenIndex269 := position, tokenIndex
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l270
+											}
+											position++
+											goto l269
+										l270:
+											position, tokenIndex = position269, tokenIndex269
+											if c := buffer[position]; c < rune('0') || c > rune('9') {
+												goto l268
+											}
+											position++
+										}
+									l269:
+										goto l265
+									l268:
+										position, tokenIndex = position265, tokenIndex265
+										if buffer[position] != rune('_') {
+											goto l264
 										}
 										position++
-										goto l163
-									l164:
-										position, tokenIndex = position164, tokenIndex164
 									}
-									goto l162
-								l161:
-									position, tokenIndex = position161, tokenIndex161
+								l265:
+									goto l258
+								l264:
+									position, tokenIndex = position264, tokenIndex264
 								}
-							l162:
-								add(ruleFloat, position160)
+								add(ruleDecimal, position259)
 							}
-							goto l154
-						l159:
-							position, tokenIndex = position154, tokenIndex154
+							goto l206
+						l258:
+							position, tokenIndex = position206, tokenIndex206
+							if !_rules[ruleFloat]() {
+								goto l271
+							}
+							goto l206
+						l271:
+							position, tokenIndex = position206, tokenIndex206
 							if !_rules[ruleInteger]() {
-								goto l165
+								goto l272
 							}
-							goto l154
-						l165:
-							position, tokenIndex = position154, tokenIndex154
+							goto l206
+						l272:
+							position, tokenIndex = position206, tokenIndex206
 							if !_rules[ruleString]() {
-								goto l166
+								goto l273
 							}
-							goto l154
-						l166:
-							position, tokenIndex = position154, tokenIndex154
+							goto l206
+						l273:
+							position, tokenIndex = position206, tokenIndex206
 							{
-								position167 := position
+								position274 := position
 								if buffer[position] != rune('n') {
-									goto l147
+									goto l199
 								}
 								position++
 								if buffer[position] != rune('u') {
-									goto l147
+									goto l199
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l147
+									goto l199
 								}
 								position++
 								if buffer[position] != rune('l') {
-									goto l147
+									goto l199
 								}
 								position++
-								add(ruleNullValue, position167)
+								add(ruleNullValue, position274)
 							}
 						}
-					l154:
-						add(ruleScalarType, position153)
+					l206:
+						add(ruleScalarType, position205)
 					}
 				}
-			l149:
-				add(ruleType, position148)
+			l201:
+				add(ruleType, position200)
 			}
 			return true
-		l147:
-			position, tokenIndex = position147, tokenIndex147
+		l199:
+			position, tokenIndex = position199, tokenIndex199
 			return false
 		},
-		/* 48 Exponentiate <- <(_ ('*' '*') _)> */
-		nil,
-		/* 49 Multiply <- <(_ '*' _)> */
-		nil,
-		/* 50 Divide <- <(_ '/' _)> */
-		nil,
-		/* 51 Modulus <- <(_ '%' _)> */
-		nil,
-		/* 52 Add <- <(_ '+' _)> */
-		nil,
-		/* 53 Subtract <- <(_ '-' _)> */
-		nil,
-		/* 54 BitwiseAnd <- <(_ '&' _)> */
-		nil,
-		/* 55 BitwiseOr <- <(_ '|' _)> */
-		nil,
-		/* 56 BitwiseNot <- <(_ '~' _)> */
-		nil,
-		/* 57 BitwiseXor <- <(_ '^' _)> */
-		nil,
-		/* 58 MatchOperator <- <(Match / Unmatch)> */
-		nil,
-		/* 59 Unmatch <- <(_ ('!' '~') _)> */
-		nil,
-		/* 60 Match <- <(_ ('=' '~') _)> */
-		nil,
-		/* 61 Operator <- <(_ (Exponentiate / Multiply / Divide / Modulus / Add / Subtract / BitwiseAnd / BitwiseOr / BitwiseNot / BitwiseXor) _)> */
-		nil,
-		/* 62 AssignmentOperator <- <(_ (AssignEq / StarEq / DivEq / PlusEq / MinusEq / AndEq / OrEq / Append) _)> */
+		/* 81 Exponentiate <- <(_ ('*' '*') _)> */
 		nil,
-		/* 63 AssignEq <- <(_ '=' _)> */
+		/* 82 Multiply <- <(_ '*' _)> */
 		nil,
-		/* 64 StarEq <- <(_ ('*' '=') _)> */
+		/* 83 Divide <- <(_ '/' _)> */
 		nil,
-		/* 65 DivEq <- <(_ ('/' '=') _)> */
+		/* 84 Modulus <- <(_ '%' _)> */
 		nil,
-		/* 66 PlusEq <- <(_ ('+' '=') _)> */
+		/* 85 Add <- <(_ '+' _)> */
 		nil,
-		/* 67 MinusEq <- <(_ ('-' '=') _)> */
+		/* 86 Subtract <- <(_ '-' _)> */
 		nil,
-		/* 68 AndEq <- <(_ ('&' '=') _)> */
+		/* 87 BitwiseAnd <- <(_ '&' _)> */
 		nil,
-		/* 69 OrEq <- <(_ ('|' '=') _)> */
+		/* 88 BitwiseOr <- <(_ '|' _)> */
 		nil,
-		/* 70 Append <- <(_ ('<' '<') _)> */
+		/* 89 BitwiseNot <- <(_ '~' _)> */
 		nil,
-		/* 71 ComparisonOperator <- <(_ (Equality / NonEquality / GreaterEqual / LessEqual / GreaterThan / LessThan / Membership / NonMembership) _)> */
+		/* 90 BitwiseXor <- <(_ '^' _)> */
 		nil,
-		/* 72 Equality <- <(_ ('=' '=') _)> */
+		/* 91 Negate <- <(_ '-' _)> */
 		nil,
-		/* 73 NonEquality <- <(_ ('!' '=') _)> */
+		/* 92 LogicalNot <- <(_ (('n' 'o' 't' __) / ('!' !('=' / '~'))) _)> */
 		nil,
-		/* 74 GreaterThan <- <(_ '>' _)> */
-		nil,
-		/* 75 GreaterEqual <- <(_ ('>' '=') _)> */
-		nil,
-		/* 76 LessEqual <- <(_ ('<' '=') _)> */
-		nil,
-		/* 77 LessThan <- <(_ '<' _)> */
-		nil,
-		/* 78 Membership <- <(_ ('i' 'n') _)> */
-		nil,
-		/* 79 NonMembership <- <(_ ('n' 'o' 't') __ ('i' 'n') _)> */
-		nil,
-		/* 80 Variable <- <(('$' VariableNameSequence) / SKIPVAR)> */
+		/* 93 MatchOperator <- <(Match / Unmatch)> */
 		func() bool {
-			position200, tokenIndex200 := position, tokenIndex
+			position287, tokenIndex287 := position, tokenIndex
 			{
-				position201 := position
+				position288 := position
 				{
-					position202, tokenIndex202 := position, tokenIndex
-					if buffer[position] != rune('$') {
-						goto l203
-					}
-					position++
+					position289, tokenIndex289 := position, tokenIndex
 					{
-						position204 := position
-					l205:
-						{
-							position206, tokenIndex206 := position, tokenIndex
-							if !_rules[ruleVariableName]() {
-								goto l206
-							}
-							{
-								position207 := position
-								if buffer[position] != rune('.') {
-									goto l206
-								}
-								position++
-								add(ruleDOT, position207)
-							}
-							goto l205
-						l206:
-							position, tokenIndex = position206, tokenIndex206
+						position291 := position
+						if !_rules[rule_]() {
+							goto l290
 						}
-						if !_rules[ruleVariableName]() {
-							goto l203
+						if buffer[position] != rune('=') {
+							goto l290
+						}
+						position++
+						if buffer[position] != rune('~') {
+							goto l290
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l290
 						}
-						add(ruleVariableNameSequence, position204)
+						add(ruleMatch, position291)
 					}
-					goto l202
-				l203:
-					position, tokenIndex = position202, tokenIndex202
+					goto l289
+				l290:
+					position, tokenIndex = position289, tokenIndex289
 					{
-						position208 := position
+						position292 := position
 						if !_rules[rule_]() {
-							goto l200
+							goto l287
 						}
-						if buffer[position] != rune('_') {
-							goto l200
+						if buffer[position] != rune('!') {
+							goto l287
+						}
+						position++
+						if buffer[position] != rune('~') {
+							goto l287
 						}
 						position++
 						if !_rules[rule_]() {
-							goto l200
+							goto l287
 						}
-						add(ruleSKIPVAR, position208)
+						add(ruleUnmatch, position292)
 					}
 				}
-			l202:
-				add(ruleVariable, position201)
+			l289:
+				add(ruleMatchOperator, position288)
 			}
 			return true
-		l200:
-			position, tokenIndex = position200, tokenIndex200
+		l287:
+			position, tokenIndex = position287, tokenIndex287
 			return false
 		},
-		/* 81 VariableNameSequence <- <((VariableName DOT)* VariableName)> */
+		/* 94 Unmatch <- <(_ ('!' '~') _)> */
+		nil,
+		/* 95 Match <- <(_ ('=' '~') _)> */
 		nil,
-		/* 82 VariableName <- <(Identifier ('[' _ VariableIndex _ ']')?)> */
+		/* 96 Operator <- <(_ (ExponentOperator / MultiplicativeOperator / AdditiveOperator / BitwiseOperator) _)> */
+		nil,
+		/* 97 ExponentOperator <- <(_ Exponentiate _)> */
 		func() bool {
-			position210, tokenIndex210 := position, tokenIndex
+			position296, tokenIndex296 := position, tokenIndex
 			{
-				position211 := position
-				if !_rules[ruleIdentifier]() {
-					goto l210
+				position297 := position
+				if !_rules[rule_]() {
+					goto l296
 				}
 				{
-					position212, tokenIndex212 := position, tokenIndex
-					if buffer[position] != rune('[') {
-						goto l212
-					}
-					position++
+					position298 := position
 					if !_rules[rule_]() {
-						goto l212
-					}
-					{
-						position214 := position
-						if !_rules[ruleExpression]() {
-							goto l212
-						}
-						add(ruleVariableIndex, position214)
+						goto l296
 					}
-					if !_rules[rule_]() {
-						goto l212
+					if buffer[position] != rune('*') {
+						goto l296
 					}
-					if buffer[position] != rune(']') {
-						goto l212
+					position++
+					if buffer[position] != rune('*') {
+						goto l296
 					}
 					position++
-					goto l213
-				l212:
-					position, tokenIndex = position212, tokenIndex212
+					if !_rules[rule_]() {
+						goto l296
+					}
+					add(ruleExponentiate, position298)
+				}
+				if !_rules[rule_]() {
+					goto l296
 				}
-			l213:
-				add(ruleVariableName, position211)
+				add(ruleExponentOperator, position297)
 			}
 			return true
-		l210:
-			position, tokenIndex = position210, tokenIndex210
+		l296:
+			position, tokenIndex = position296, tokenIndex296
 			return false
 		},
-		/* 83 VariableIndex <- <Expression> */
-		nil,
-		/* 84 Block <- <(_ (COMMENT / FlowControlWord / StatementBlock) SEMI? _)> */
+		/* 98 MultiplicativeOperator <- <(_ (Multiply / Divide / Modulus) _)> */
 		func() bool {
-			position216, tokenIndex216 := position, tokenIndex
+			position299, tokenIndex299 := position, tokenIndex
 			{
-				position217 := position
+				position300 := position
 				if !_rules[rule_]() {
-					goto l216
+					goto l299
 				}
 				{
-					position218, tokenIndex218 := position, tokenIndex
+					position301, tokenIndex301 := position, tokenIndex
 					{
-						position220 := position
+						position303 := position
 						if !_rules[rule_]() {
-							goto l219
+							goto l302
 						}
-						if buffer[position] != rune('#') {
-							goto l219
+						if buffer[position] != rune('*') {
+							goto l302
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l302
+						}
+						add(ruleMultiply, position303)
+					}
+					goto l301
+				l302:
+					position, tokenIndex = position301, tokenIndex301
+					{
+						position305 := position
+						if !_rules[rule_]() {
+							goto l304
+						}
+						if buffer[position] != rune('/') {
+							goto l304
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l304
+						}
+						add(ruleDivide, position305)
+					}
+					goto l301
+				l304:
+					position, tokenIndex = position301, tokenIndex301
+					{
+						position306 := position
+						if !_rules[rule_]() {
+							goto l299
+						}
+						if buffer[position] != rune('%') {
+							goto l299
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l299
+						}
+						add(ruleModulus, position306)
+					}
+				}
+			l301:
+				if !_rules[rule_]() {
+					goto l299
+				}
+				add(ruleMultiplicativeOperator, position300)
+			}
+			return true
+		l299:
+			position, tokenIndex = position299, tokenIndex299
+			return false
+		},
+		/* 99 AdditiveOperator <- <(_ (Add / Subtract) _)> */
+		func() bool {
+			position307, tokenIndex307 := position, tokenIndex
+			{
+				position308 := position
+				if !_rules[rule_]() {
+					goto l307
+				}
+				{
+					position309, tokenIndex309 := position, tokenIndex
+					{
+						position311 := position
+						if !_rules[rule_]() {
+							goto l310
+						}
+						if buffer[position] != rune('+') {
+							goto l310
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l310
+						}
+						add(ruleAdd, position311)
+					}
+					goto l309
+				l310:
+					position, tokenIndex = position309, tokenIndex309
+					{
+						position312 := position
+						if !_rules[rule_]() {
+							goto l307
+						}
+						if buffer[position] != rune('-') {
+							goto l307
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l307
+						}
+						add(ruleSubtract, position312)
+					}
+				}
+			l309:
+				if !_rules[rule_]() {
+					goto l307
+				}
+				add(ruleAdditiveOperator, position308)
+			}
+			return true
+		l307:
+			position, tokenIndex = position307, tokenIndex307
+			return false
+		},
+		/* 100 BitwiseOperator <- <(_ (BitwiseAnd / BitwiseOr / BitwiseXor) _)> */
+		func() bool {
+			position313, tokenIndex313 := position, tokenIndex
+			{
+				position314 := position
+				if !_rules[rule_]() {
+					goto l313
+				}
+				{
+					position315, tokenIndex315 := position, tokenIndex
+					{
+						position317 := position
+						if !_rules[rule_]() {
+							goto l316
+						}
+						if buffer[position] != rune('&') {
+							goto l316
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l316
+						}
+						add(ruleBitwiseAnd, position317)
+					}
+					goto l315
+				l316:
+					position, tokenIndex = position315, tokenIndex315
+					{
+						position319 := position
+						if !_rules[rule_]() {
+							goto l318
+						}
+						if buffer[position] != rune('|') {
+							goto l318
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l318
+						}
+						add(ruleBitwiseOr, position319)
+					}
+					goto l315
+				l318:
+					position, tokenIndex = position315, tokenIndex315
+					{
+						position320 := position
+						if !_rules[rule_]() {
+							goto l313
+						}
+						if buffer[position] != rune('^') {
+							goto l313
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l313
+						}
+						add(ruleBitwiseXor, position320)
+					}
+				}
+			l315:
+				if !_rules[rule_]() {
+					goto l313
+				}
+				add(ruleBitwiseOperator, position314)
+			}
+			return true
+		l313:
+			position, tokenIndex = position313, tokenIndex313
+			return false
+		},
+		/* 101 UnaryOperator <- <(_ (Negate / BitwiseNot / LogicalNot) _)> */
+		nil,
+		/* 102 AssignmentOperator <- <(_ (AssignEq / StarEq / DivEq / PlusEq / MinusEq / AndEq / OrEq / Append) _)> */
+		nil,
+		/* 103 AssignEq <- <(_ '=' _)> */
+		func() bool {
+			position323, tokenIndex323 := position, tokenIndex
+			{
+				position324 := position
+				if !_rules[rule_]() {
+					goto l323
+				}
+				if buffer[position] != rune('=') {
+					goto l323
+				}
+				position++
+				if !_rules[rule_]() {
+					goto l323
+				}
+				add(ruleAssignEq, position324)
+			}
+			return true
+		l323:
+			position, tokenIndex = position323, tokenIndex323
+			return false
+		},
+		/* 104 StarEq <- <(_ ('*' '=') _)> */
+		nil,
+		/* 105 DivEq <- <(_ ('/' '=') _)> */
+		nil,
+		/* 106 PlusEq <- <(_ ('+' '=') _)> */
+		nil,
+		/* 107 MinusEq <- <(_ ('-' '=') _)> */
+		nil,
+		/* 108 AndEq <- <(_ ('&' '=') _)> */
+		nil,
+		/* 109 OrEq <- <(_ ('|' '=') _)> */
+		nil,
+		/* 110 Append <- <(_ ('<' '<') _)> */
+		nil,
+		/* 111 ComparisonOperator <- <(_ (Equality / NonEquality / GreaterEqual / LessEqual / GreaterThan / LessThan / Membership / NonMembership) _)> */
+		func() bool {
+			position332, tokenIndex332 := position, tokenIndex
+			{
+				position333 := position
+				if !_rules[rule_]() {
+					goto l332
+				}
+				{
+					position334, tokenIndex334 := position, tokenIndex
+					{
+						position336 := position
+						if !_rules[rule_]() {
+							goto l335
+						}
+						if buffer[position] != rune('=') {
+							goto l335
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l335
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l335
+						}
+						add(ruleEquality, position336)
+					}
+					goto l334
+				l335:
+					position, tokenIndex = position334, tokenIndex334
+					{
+						position338 := position
+						if !_rules[rule_]() {
+							goto l337
+						}
+						if buffer[position] != rune('!') {
+							goto l337
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l337
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l337
+						}
+						add(ruleNonEquality, position338)
+					}
+					goto l334
+				l337:
+					position, tokenIndex = position334, tokenIndex334
+					{
+						position340 := position
+						if !_rules[rule_]() {
+							goto l339
+						}
+						if buffer[position] != rune('>') {
+							goto l339
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l339
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l339
+						}
+						add(ruleGreaterEqual, position340)
+					}
+					goto l334
+				l339:
+					position, tokenIndex = position334, tokenIndex334
+					{
+						position342 := position
+						if !_rules[rule_]() {
+							goto l341
+						}
+						if buffer[position] != rune('<') {
+							goto l341
+						}
+						position++
+						if buffer[position] != rune('=') {
+							goto l341
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l341
+						}
+						add(ruleLessEqual, position342)
+					}
+					goto l334
+				l341:
+					position, tokenIndex = position334, tokenIndex334
+					{
+						position344 := position
+						if !_rules[rule_]() {
+							goto l343
+						}
+						if buffer[position] != rune('>') {
+							goto l343
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l343
+						}
+						add(ruleGreaterThan, position344)
+					}
+					goto l334
+				l343:
+					position, tokenIndex = position334, tokenIndex334
+					{
+						position346 := position
+						if !_rules[rule_]() {
+							goto l345
+						}
+						if buffer[position] != rune('<') {
+							goto l345
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l345
+						}
+						add(ruleLessThan, position346)
+					}
+					goto l334
+				l345:
+					position, tokenIndex = position334, tokenIndex334
+					{
+						position348 := position
+						if !_rules[rule_]() {
+							goto l347
+						}
+						if buffer[position] != rune('i') {
+							goto l347
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l347
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l347
+						}
+						add(ruleMembership, position348)
+					}
+					goto l334
+				l347:
+					position, tokenIndex = position334, tokenIndex334
+					{
+						position349 := position
+						if !_rules[rule_]() {
+							goto l332
+						}
+						if buffer[position] != rune('n') {
+							goto l332
+						}
+						position++
+						if buffer[position] != rune('o') {
+							goto l332
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l332
 						}
 						position++
-					l221:
+						if !_rules[rule__]() {
+							goto l332
+						}
+						if buffer[position] != rune('i') {
+							goto l332
+						}
+						position++
+						if buffer[position] != rune('n') {
+							goto l332
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l332
+						}
+						add(ruleNonMembership, position349)
+					}
+				}
+			l334:
+				if !_rules[rule_]() {
+					goto l332
+				}
+				add(ruleComparisonOperator, position333)
+			}
+			return true
+		l332:
+			position, tokenIndex = position332, tokenIndex332
+			return false
+		},
+		/* 112 Equality <- <(_ ('=' '=') _)> */
+		nil,
+		/* 113 NonEquality <- <(_ ('!' '=') _)> */
+		nil,
+		/* 114 GreaterThan <- <(_ '>' _)> */
+		nil,
+		/* 115 GreaterEqual <- <(_ ('>' '=') _)> */
+		nil,
+		/* 116 LessEqual <- <(_ ('<' '=') _)> */
+		nil,
+		/* 117 LessThan <- <(_ '<' _)> */
+		nil,
+		/* 118 Membership <- <(_ ('i' 'n') _)> */
+		nil,
+		/* 119 NonMembership <- <(_ ('n' 'o' 't') __ ('i' 'n') _)> */
+		nil,
+		/* 120 Variable <- <(('$' VariableNameSequence) / SKIPVAR)> */
+		func() bool {
+			position358, tokenIndex358 := position, tokenIndex
+			{
+				position359 := position
+				{
+					position360, tokenIndex360 := position, tokenIndex
+					if buffer[position] != rune('$') {
+						goto l361
+					}
+					position++
+					{
+						position362 := position
+					l363:
 						{
-							position222, tokenIndex222 := position, tokenIndex
+							position364, tokenIndex364 := position, tokenIndex
+							if !_rules[ruleVariableName]() {
+								goto l364
+							}
 							{
-								position223, tokenIndex223 := position, tokenIndex
-								if buffer[position] != rune('\n') {
-									goto l223
+								position365, tokenIndex365 := position, tokenIndex
+								{
+									position367 := position
+									if buffer[position] != rune('?') {
+										goto l366
+									}
+									position++
+									if buffer[position] != rune('.') {
+										goto l366
+									}
+									position++
+									add(ruleOPTDOT, position367)
+								}
+								goto l365
+							l366:
+								position, tokenIndex = position365, tokenIndex365
+								{
+									position368 := position
+									if buffer[position] != rune('.') {
+										goto l364
+									}
+									position++
+									add(ruleDOT, position368)
+								}
+							}
+						l365:
+							goto l363
+						l364:
+							position, tokenIndex = position364, tokenIndex364
+						}
+						if !_rules[ruleVariableName]() {
+							goto l361
+						}
+						add(ruleVariableNameSequence, position362)
+					}
+					goto l360
+				l361:
+					position, tokenIndex = position360, tokenIndex360
+					{
+						position369 := position
+						if !_rules[rule_]() {
+							goto l358
+						}
+						if buffer[position] != rune('_') {
+							goto l358
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l358
+						}
+						add(ruleSKIPVAR, position369)
+					}
+				}
+			l360:
+				add(ruleVariable, position359)
+			}
+			return true
+		l358:
+			position, tokenIndex = position358, tokenIndex358
+			return false
+		},
+		/* 121 VariableNameSequence <- <((VariableName (OPTDOT / DOT))* VariableName)> */
+		nil,
+		/* 122 VariableName <- <(Identifier VariableIndex*)> */
+		func() bool {
+			position371, tokenIndex371 := position, tokenIndex
+			{
+				position372 := position
+				if !_rules[ruleIdentifier]() {
+					goto l371
+				}
+			l373:
+				{
+					position374, tokenIndex374 := position, tokenIndex
+					{
+						position375 := position
+						if buffer[position] != rune('[') {
+							goto l374
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l374
+						}
+						{
+							position376, tokenIndex376 := position, tokenIndex
+							{
+								position378 := position
+								{
+									position379, tokenIndex379 := position, tokenIndex
+									{
+										position381 := position
+										if !_rules[ruleExpression]() {
+											goto l379
+										}
+										add(ruleVariableSliceStart, position381)
+									}
+									goto l380
+								l379:
+									position, tokenIndex = position379, tokenIndex379
+								}
+							l380:
+								if !_rules[rule_]() {
+									goto l377
+								}
+								if buffer[position] != rune(':') {
+									goto l377
+								}
+								position++
+								if !_rules[rule_]() {
+									goto l377
+								}
+								{
+									position382, tokenIndex382 := position, tokenIndex
+									{
+										position384 := position
+										if !_rules[ruleExpression]() {
+											goto l382
+										}
+										add(ruleVariableSliceEnd, position384)
+									}
+									goto l383
+								l382:
+									position, tokenIndex = position382, tokenIndex382
+								}
+							l383:
+								add(ruleVariableSlice, position378)
+							}
+							goto l376
+						l377:
+							position, tokenIndex = position376, tokenIndex376
+							if !_rules[ruleExpression]() {
+								goto l374
+							}
+						}
+					l376:
+						if !_rules[rule_]() {
+							goto l374
+						}
+						if buffer[position] != rune(']') {
+							goto l374
+						}
+						position++
+						add(ruleVariableIndex, position375)
+					}
+					goto l373
+				l374:
+					position, tokenIndex = position374, tokenIndex374
+				}
+				add(ruleVariableName, position372)
+			}
+			return true
+		l371:
+			position, tokenIndex = position371, tokenIndex371
+			return false
+		},
+		/* 123 VariableIndex <- <('[' _ (VariableSlice / Expression) _ ']')> */
+		nil,
+		/* 124 VariableSlice <- <(VariableSliceStart? _ ':' _ VariableSliceEnd?)> */
+		nil,
+		/* 125 VariableSliceStart <- <Expression> */
+		nil,
+		/* 126 VariableSliceEnd <- <Expression> */
+		nil,
+		/* 127 Block <- <(_ (COMMENT / FlowControlWord / EventHandler / StatementBlock) SEMI? _)> */
+		func() bool {
+			position389, tokenIndex389 := position, tokenIndex
+			{
+				position390 := position
+				if !_rules[rule_]() {
+					goto l389
+				}
+				{
+					position391, tokenIndex391 := position, tokenIndex
+					{
+						position393 := position
+						if !_rules[rule_]() {
+							goto l392
+						}
+						if buffer[position] != rune('#') {
+							goto l392
+						}
+						position++
+					l394:
+						{
+							position395, tokenIndex395 := position, tokenIndex
+							{
+								position396, tokenIndex396 := position, tokenIndex
+								if buffer[position] != rune('\n') {
+									goto l396
+								}
+								position++
+								goto l395
+							l396:
+								position, tokenIndex = position396, tokenIndex396
+							}
+							if !matchDot() {
+								goto l395
+							}
+							goto l394
+						l395:
+							position, tokenIndex = position395, tokenIndex395
+						}
+						add(ruleCOMMENT, position393)
+					}
+					goto l391
+				l392:
+					position, tokenIndex = position391, tokenIndex391
+					{
+						position398 := position
+						{
+							position399, tokenIndex399 := position, tokenIndex
+							{
+								position401 := position
+								{
+									position402 := position
+									if !_rules[rule_]() {
+										goto l400
+									}
+									if buffer[position] != rune('b') {
+										goto l400
+									}
+									position++
+									if buffer[position] != rune('r') {
+										goto l400
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l400
+									}
+									position++
+									if buffer[position] != rune('a') {
+										goto l400
+									}
+									position++
+									if buffer[position] != rune('k') {
+										goto l400
+									}
+									position++
+								l403:
+									{
+										position404, tokenIndex404 := position, tokenIndex
+										{
+											position405, tokenIndex405 := position, tokenIndex
+											if buffer[position] != rune(' ') {
+												goto l406
+											}
+											position++
+											goto l405
+										l406:
+											position, tokenIndex = position405, tokenIndex405
+											if buffer[position] != rune('\t') {
+												goto l404
+											}
+											position++
+										}
+									l405:
+										goto l403
+									l404:
+										position, tokenIndex = position404, tokenIndex404
+									}
+									add(ruleBREAK, position402)
+								}
+								{
+									position407, tokenIndex407 := position, tokenIndex
+									{
+										position409, tokenIndex409 := position, tokenIndex
+										if !_rules[rulePositiveInteger]() {
+											goto l410
+										}
+										goto l409
+									l410:
+										position, tokenIndex = position409, tokenIndex409
+										if !_rules[ruleFlowControlLabel]() {
+											goto l407
+										}
+									}
+								l409:
+									goto l408
+								l407:
+									position, tokenIndex = position407, tokenIndex407
+								}
+							l408:
+								add(ruleFlowControlBreak, position401)
+							}
+							goto l399
+						l400:
+							position, tokenIndex = position399, tokenIndex399
+							{
+								position412 := position
+								{
+									position413 := position
+									if !_rules[rule_]() {
+										goto l411
+									}
+									if buffer[position] != rune('c') {
+										goto l411
+									}
+									position++
+									if buffer[position] != rune('o') {
+										goto l411
+									}
+									position++
+									if buffer[position] != rune('n') {
+										goto l411
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l411
+									}
+									position++
+									if buffer[position] != rune('i') {
+										goto l411
+									}
+									position++
+									if buffer[position] != rune('n') {
+										goto l411
+									}
+									position++
+									if buffer[position] != rune('u') {
+										goto l411
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l411
+									}
+									position++
+								l414:
+									{
+										position415, tokenIndex415 := position, tokenIndex
+										{
+											position416, tokenIndex416 := position, tokenIndex
+											if buffer[position] != rune(' ') {
+												goto l417
+											}
+											position++
+											goto l416
+										l417:
+											position, tokenIndex = position416, tokenIndex416
+											if buffer[position] != rune('\t') {
+												goto l415
+											}
+											position++
+										}
+									l416:
+										goto l414
+									l415:
+										position, tokenIndex = position415, tokenIndex415
+									}
+									add(ruleCONT, position413)
+								}
+								{
+									position418, tokenIndex418 := position, tokenIndex
+									{
+										position420, tokenIndex420 := position, tokenIndex
+										if !_rules[rulePositiveInteger]() {
+											goto l421
+										}
+										goto l420
+									l421:
+										position, tokenIndex = position420, tokenIndex420
+										if !_rules[ruleFlowControlLabel]() {
+											goto l418
+										}
+									}
+								l420:
+									goto l419
+								l418:
+									position, tokenIndex = position418, tokenIndex418
+								}
+							l419:
+								add(ruleFlowControlContinue, position412)
+							}
+							goto l399
+						l411:
+							position, tokenIndex = position399, tokenIndex399
+							{
+								position423 := position
+								{
+									position424 := position
+									if !_rules[rule_]() {
+										goto l422
+									}
+									if buffer[position] != rune('r') {
+										goto l422
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l422
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l422
+									}
+									position++
+									if buffer[position] != rune('u') {
+										goto l422
+									}
+									position++
+									if buffer[position] != rune('r') {
+										goto l422
+									}
+									position++
+									if buffer[position] != rune('n') {
+										goto l422
+									}
+									position++
+									{
+										position425, tokenIndex425 := position, tokenIndex
+										{
+											position426, tokenIndex426 := position, tokenIndex
+											if c := buffer[position]; c < rune('a') || c > rune('z') {
+												goto l427
+											}
+											position++
+											goto l426
+										l427:
+											position, tokenIndex = position426, tokenIndex426
+											if c := buffer[position]; c < rune('A') || c > rune('Z') {
+												goto l428
+											}
+											position++
+											goto l426
+										l428:
+											position, tokenIndex = position426, tokenIndex426
+											{
+												position430, tokenIndex430 := position, tokenIndex
+												if c := buffer[position]; c < rune('0') || c > rune('9') {
+													goto l431
+												}
+												position++
+												goto l430
+											l431:
+												position, tokenIndex = position430, tokenIndex430
+												if c := buffer[position]; c < rune('0') || c > rune('9') {
+													goto l429
+												}
+												position++
+											}
+										l430:
+											goto l426
+										l429:
+											position, tokenIndex = position426, tokenIndex426
+											if buffer[position] != rune('_') {
+												goto l425
+											}
+											position++
+										}
+									l426:
+										goto l422
+									l425:
+										position, tokenIndex = position425, tokenIndex425
+									}
+								l432:
+									{
+										position433, tokenIndex433 := position, tokenIndex
+										{
+											position434, tokenIndex434 := position, tokenIndex
+											if buffer[position] != rune(' ') {
+												goto l435
+											}
+											position++
+											goto l434
+										l435:
+											position, tokenIndex = position434, tokenIndex434
+											if buffer[position] != rune('\t') {
+												goto l433
+											}
+											position++
+										}
+									l434:
+										goto l432
+									l433:
+										position, tokenIndex = position433, tokenIndex433
+									}
+									add(ruleRETURN, position424)
+								}
+								{
+									position436, tokenIndex436 := position, tokenIndex
+									{
+										position438, tokenIndex438 := position, tokenIndex
+										{
+											position439, tokenIndex439 := position, tokenIndex
+											if buffer[position] != rune('\r') {
+												goto l440
+											}
+											position++
+											goto l439
+										l440:
+											position, tokenIndex = position439, tokenIndex439
+											if buffer[position] != rune('\n') {
+												goto l438
+											}
+											position++
+										}
+									l439:
+										goto l436
+									l438:
+										position, tokenIndex = position438, tokenIndex438
+									}
+									if !_rules[ruleExpressionSequence]() {
+										goto l436
+									}
+									goto l437
+								l436:
+									position, tokenIndex = position436, tokenIndex436
+								}
+							l437:
+								add(ruleFlowControlReturn, position423)
+							}
+							goto l399
+						l422:
+							position, tokenIndex = position399, tokenIndex399
+							{
+								position441 := position
+								{
+									position442 := position
+									if !_rules[rule_]() {
+										goto l397
+									}
+									if buffer[position] != rune('e') {
+										goto l397
+									}
+									position++
+									if buffer[position] != rune('x') {
+										goto l397
+									}
+									position++
+									if buffer[position] != rune('i') {
+										goto l397
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l397
+									}
+									position++
+									{
+										position443, tokenIndex443 := position, tokenIndex
+										{
+											position444, tokenIndex444 := position, tokenIndex
+											if c := buffer[position]; c < rune('a') || c > rune('z') {
+												goto l445
+											}
+											position++
+											goto l444
+										l445:
+											position, tokenIndex = position444, tokenIndex444
+											if c := buffer[position]; c < rune('A') || c > rune('Z') {
+												goto l446
+											}
+											position++
+											goto l444
+										l446:
+											position, tokenIndex = position444, tokenIndex444
+											{
+												position448, tokenIndex448 := position, tokenIndex
+												if c := buffer[position]; c < rune('0') || c > rune('9') {
+													goto l449
+												}
+												position++
+												goto l448
+											l449:
+												position, tokenIndex = position448, tokenIndex448
+												if c := buffer[position]; c < rune('0') || c > rune('9') {
+													goto l447
+												}
+												position++
+											}
+										l448:
+											goto l444
+										l447:
+											position, tokenIndex = position444, tokenIndex444
+											if buffer[position] != rune('_') {
+												goto l450
+											}
+											position++
+											goto l444
+										l450:
+											position, tokenIndex = position444, tokenIndex444
+											if buffer[position] != rune(':') {
+												goto l443
+											}
+											position++
+										}
+									l444:
+										goto l397
+									l443:
+										position, tokenIndex = position443, tokenIndex443
+									}
+								l451:
+									{
+										position452, tokenIndex452 := position, tokenIndex
+										{
+											position453, tokenIndex453 := position, tokenIndex
+											if buffer[position] != rune(' ') {
+												goto l454
+											}
+											position++
+											goto l453
+										l454:
+											position, tokenIndex = position453, tokenIndex453
+											if buffer[position] != rune('\t') {
+												goto l452
+											}
+											position++
+										}
+									l453:
+										goto l451
+									l452:
+										position, tokenIndex = position452, tokenIndex452
+									}
+									add(ruleEXIT, position442)
+								}
+								{
+									position455, tokenIndex455 := position, tokenIndex
+									{
+										position457, tokenIndex457 := position, tokenIndex
+										if !_rules[ruleInteger]() {
+											goto l458
+										}
+										goto l457
+									l458:
+										position, tokenIndex = position457, tokenIndex457
+										if !_rules[ruleVariable]() {
+											goto l455
+										}
+									}
+								l457:
+									goto l456
+								l455:
+									position, tokenIndex = position455, tokenIndex455
+								}
+							l456:
+								add(ruleFlowControlExit, position441)
+							}
+						}
+					l399:
+						add(ruleFlowControlWord, position398)
+					}
+					goto l391
+				l397:
+					position, tokenIndex = position391, tokenIndex391
+					{
+						position460 := position
+						{
+							position461 := position
+							if !_rules[rule_]() {
+								goto l459
+							}
+							if buffer[position] != rune('o') {
+								goto l459
+							}
+							position++
+							if buffer[position] != rune('n') {
+								goto l459
+							}
+							position++
+							if !_rules[rule__]() {
+								goto l459
+							}
+							add(ruleON, position461)
+						}
+						if !_rules[ruleString]() {
+							goto l459
+						}
+						if !_rules[ruleOPEN]() {
+							goto l459
+						}
+					l462:
+						{
+							position463, tokenIndex463 := position, tokenIndex
+							if !_rules[ruleBlock]() {
+								goto l463
+							}
+							goto l462
+						l463:
+							position, tokenIndex = position463, tokenIndex463
+						}
+						if !_rules[ruleCLOSE]() {
+							goto l459
+						}
+						add(ruleEventHandler, position460)
+					}
+					goto l391
+				l459:
+					position, tokenIndex = position391, tokenIndex391
+					{
+						position464 := position
+						{
+							position465, tokenIndex465 := position, tokenIndex
+							{
+								position467 := position
+								if !_rules[ruleSEMI]() {
+									goto l466
+								}
+								add(ruleNOOP, position467)
+							}
+							goto l465
+						l466:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position469 := position
+								{
+									position470 := position
+									if !_rules[rule_]() {
+										goto l468
+									}
+									if buffer[position] != rune('c') {
+										goto l468
+									}
+									position++
+									if buffer[position] != rune('o') {
+										goto l468
+									}
+									position++
+									if buffer[position] != rune('n') {
+										goto l468
+									}
+									position++
+									if buffer[position] != rune('s') {
+										goto l468
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l468
+									}
+									position++
+									if !_rules[rule__]() {
+										goto l468
+									}
+									add(ruleCONST, position470)
+								}
+								if !_rules[ruleAssignmentLHS]() {
+									goto l468
+								}
+								if !_rules[ruleAssignEq]() {
+									goto l468
+								}
+								if !_rules[ruleAssignmentRHS]() {
+									goto l468
+								}
+								add(ruleConstantDeclaration, position469)
+							}
+							goto l465
+						l468:
+							position, tokenIndex = position465, tokenIndex465
+							if !_rules[ruleAssignment]() {
+								goto l471
+							}
+							goto l465
+						l471:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position473 := position
+								{
+									position474, tokenIndex474 := position, tokenIndex
+									{
+										position476 := position
+										{
+											position477 := position
+											if !_rules[rule_]() {
+												goto l475
+											}
+											if buffer[position] != rune('u') {
+												goto l475
+											}
+											position++
+											if buffer[position] != rune('n') {
+												goto l475
+											}
+											position++
+											if buffer[position] != rune('s') {
+												goto l475
+											}
+											position++
+											if buffer[position] != rune('e') {
+												goto l475
+											}
+											position++
+											if buffer[position] != rune('t') {
+												goto l475
+											}
+											position++
+											if !_rules[rule__]() {
+												goto l475
+											}
+											add(ruleUNSET, position477)
+										}
+										if !_rules[ruleVariableSequence]() {
+											goto l475
+										}
+										add(ruleDirectiveUnset, position476)
+									}
+									goto l474
+								l475:
+									position, tokenIndex = position474, tokenIndex474
+									{
+										position479 := position
+										{
+											position480 := position
+											if !_rules[rule_]() {
+												goto l478
+											}
+											if buffer[position] != rune('i') {
+												goto l478
+											}
+											position++
+											if buffer[position] != rune('n') {
+												goto l478
+											}
+											position++
+											if buffer[position] != rune('c') {
+												goto l478
+											}
+											position++
+											if buffer[position] != rune('l') {
+												goto l478
+											}
+											position++
+											if buffer[position] != rune('u') {
+												goto l478
+											}
+											position++
+											if buffer[position] != rune('d') {
+												goto l478
+											}
+											position++
+											if buffer[position] != rune('e') {
+												goto l478
+											}
+											position++
+											if !_rules[rule__]() {
+												goto l478
+											}
+											add(ruleINCLUDE, position480)
+										}
+										if !_rules[ruleString]() {
+											goto l478
+										}
+										add(ruleDirectiveInclude, position479)
+									}
+									goto l474
+								l478:
+									position, tokenIndex = position474, tokenIndex474
+									{
+										position481 := position
+										{
+											position482 := position
+											if !_rules[rule_]() {
+												goto l472
+											}
+											if buffer[position] != rune('d') {
+												goto l472
+											}
+											position++
+											if buffer[position] != rune('e') {
+												goto l472
+											}
+											position++
+											if buffer[position] != rune('c') {
+												goto l472
+											}
+											position++
+											if buffer[position] != rune('l') {
+												goto l472
+											}
+											position++
+											if buffer[position] != rune('a') {
+												goto l472
+											}
+											position++
+											if buffer[position] != rune('r') {
+												goto l472
+											}
+											position++
+											if buffer[position] != rune('e') {
+												goto l472
+											}
+											position++
+											if !_rules[rule__]() {
+												goto l472
+											}
+											add(ruleDECLARE, position482)
+										}
+										if !_rules[ruleVariableSequence]() {
+											goto l472
+										}
+										add(ruleDirectiveDeclare, position481)
+									}
+								}
+							l474:
+								add(ruleDirective, position473)
+							}
+							goto l465
+						l472:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position484 := position
+								{
+									position485 := position
+									if !_rules[rule_]() {
+										goto l483
+									}
+									if buffer[position] != rune('d') {
+										goto l483
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l483
+									}
+									position++
+									if buffer[position] != rune('f') {
+										goto l483
+									}
+									position++
+									if !_rules[rule__]() {
+										goto l483
+									}
+									add(ruleDEF, position485)
+								}
+								if !_rules[ruleIdentifier]() {
+									goto l483
+								}
+								if !_rules[ruleGROUPOPEN]() {
+									goto l483
+								}
+								{
+									position486, tokenIndex486 := position, tokenIndex
+									{
+										position488 := position
+										{
+											position489, tokenIndex489 := position, tokenIndex
+											if !_rules[ruleFunctionArgument]() {
+												goto l490
+											}
+											if !_rules[ruleCOMMA]() {
+												goto l490
+											}
+											if !_rules[ruleFunctionOptions]() {
+												goto l490
+											}
+											goto l489
+										l490:
+											position, tokenIndex = position489, tokenIndex489
+											if !_rules[ruleFunctionArgument]() {
+												goto l491
+											}
+											goto l489
+										l491:
+											position, tokenIndex = position489, tokenIndex489
+											if !_rules[ruleFunctionOptions]() {
+												goto l486
+											}
+										}
+									l489:
+										add(ruleFunctionParameters, position488)
+									}
+									goto l487
+								l486:
+									position, tokenIndex = position486, tokenIndex486
+								}
+							l487:
+								if !_rules[ruleGROUPCLOSE]() {
+									goto l483
+								}
+								if !_rules[ruleOPEN]() {
+									goto l483
+								}
+							l492:
+								{
+									position493, tokenIndex493 := position, tokenIndex
+									if !_rules[ruleBlock]() {
+										goto l493
+									}
+									goto l492
+								l493:
+									position, tokenIndex = position493, tokenIndex493
+								}
+								if !_rules[ruleCLOSE]() {
+									goto l483
+								}
+								add(ruleFunctionDefinition, position484)
+							}
+							goto l465
+						l483:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position495 := position
+								if !_rules[ruleIfStanza]() {
+									goto l494
+								}
+							l496:
+								{
+									position497, tokenIndex497 := position, tokenIndex
+									{
+										position498 := position
+										if !_rules[ruleELSE]() {
+											goto l497
+										}
+										if !_rules[ruleIfStanza]() {
+											goto l497
+										}
+										add(ruleElseIfStanza, position498)
+									}
+									goto l496
+								l497:
+									position, tokenIndex = position497, tokenIndex497
+								}
+								{
+									position499, tokenIndex499 := position, tokenIndex
+									{
+										position501 := position
+										if !_rules[ruleELSE]() {
+											goto l499
+										}
+										if !_rules[ruleOPEN]() {
+											goto l499
+										}
+									l502:
+										{
+											position503, tokenIndex503 := position, tokenIndex
+											if !_rules[ruleBlock]() {
+												goto l503
+											}
+											goto l502
+										l503:
+											position, tokenIndex = position503, tokenIndex503
+										}
+										if !_rules[ruleCLOSE]() {
+											goto l499
+										}
+										add(ruleElseStanza, position501)
+									}
+									goto l500
+								l499:
+									position, tokenIndex = position499, tokenIndex499
+								}
+							l500:
+								add(ruleConditional, position495)
+							}
+							goto l465
+						l494:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position505 := position
+								{
+									position506 := position
+									if !_rules[rule_]() {
+										goto l504
+									}
+									if buffer[position] != rune('l') {
+										goto l504
+									}
+									position++
+									if buffer[position] != rune('o') {
+										goto l504
+									}
+									position++
+									if buffer[position] != rune('o') {
+										goto l504
+									}
+									position++
+									if buffer[position] != rune('p') {
+										goto l504
+									}
+									position++
+									if !_rules[rule_]() {
+										goto l504
+									}
+									add(ruleLOOP, position506)
+								}
+								{
+									position507, tokenIndex507 := position, tokenIndex
+									{
+										position509 := position
+										if buffer[position] != rune(':') {
+											goto l507
+										}
+										position++
+										if !_rules[ruleIdentifier]() {
+											goto l507
+										}
+										if !_rules[rule_]() {
+											goto l507
+										}
+										add(ruleLoopLabel, position509)
+									}
+									goto l508
+								l507:
+									position, tokenIndex = position507, tokenIndex507
+								}
+							l508:
+								{
+									position510, tokenIndex510 := position, tokenIndex
+									if !_rules[ruleOPEN]() {
+										goto l511
+									}
+								l512:
+									{
+										position513, tokenIndex513 := position, tokenIndex
+										if !_rules[ruleBlock]() {
+											goto l513
+										}
+										goto l512
+									l513:
+										position, tokenIndex = position513, tokenIndex513
+									}
+									if !_rules[ruleCLOSE]() {
+										goto l511
+									}
+									goto l510
+								l511:
+									position, tokenIndex = position510, tokenIndex510
+									{
+										position515 := position
+										{
+											position516 := position
+											if !_rules[rule_]() {
+												goto l514
+											}
+											if buffer[position] != rune('c') {
+												goto l514
+											}
+											position++
+											if buffer[position] != rune('o') {
+												goto l514
+											}
+											position++
+											if buffer[position] != rune('u') {
+												goto l514
+											}
+											position++
+											if buffer[position] != rune('n') {
+												goto l514
+											}
+											position++
+											if buffer[position] != rune('t') {
+												goto l514
+											}
+											position++
+											if !_rules[rule_]() {
+												goto l514
+											}
+											add(ruleCOUNT, position516)
+										}
+										{
+											position517, tokenIndex517 := position, tokenIndex
+											if !_rules[ruleInteger]() {
+												goto l518
+											}
+											goto l517
+										l518:
+											position, tokenIndex = position517, tokenIndex517
+											if !_rules[ruleVariable]() {
+												goto l514
+											}
+										}
+									l517:
+										add(ruleLoopConditionFixedLength, position515)
+									}
+									if !_rules[ruleOPEN]() {
+										goto l514
+									}
+								l519:
+									{
+										position520, tokenIndex520 := position, tokenIndex
+										if !_rules[ruleBlock]() {
+											goto l520
+										}
+										goto l519
+									l520:
+										position, tokenIndex = position520, tokenIndex520
+									}
+									if !_rules[ruleCLOSE]() {
+										goto l514
+									}
+									goto l510
+								l514:
+									position, tokenIndex = position510, tokenIndex510
+									{
+										position522 := position
+										if !_rules[rulePARALLEL]() {
+											goto l521
+										}
+										{
+											position523, tokenIndex523 := position, tokenIndex
+											{
+												position525 := position
+												{
+													position526, tokenIndex526 := position, tokenIndex
+													if !_rules[rulePositiveInteger]() {
+														goto l527
+													}
+													goto l526
+												l527:
+													position, tokenIndex = position526, tokenIndex526
+													if !_rules[ruleVariable]() {
+														goto l523
+													}
+												}
+											l526:
+												add(ruleLoopParallelLimit, position525)
+											}
+											{
+												position528, tokenIndex528 := position, tokenIndex
+												if !_rules[ruleIN]() {
+													goto l528
+												}
+												goto l523
+											l528:
+												position, tokenIndex = position528, tokenIndex528
+											}
+											if !_rules[rule__]() {
+												goto l523
+											}
+											goto l524
+										l523:
+											position, tokenIndex = position523, tokenIndex523
+										}
+									l524:
+										add(ruleLoopParallel, position522)
+									}
+									if !_rules[ruleLoopConditionIterable]() {
+										goto l521
+									}
+									{
+										position529, tokenIndex529 := position, tokenIndex
+										{
+											position531 := position
+											{
+												position532 := position
+												if !_rules[rule_]() {
+													goto l529
+												}
+												if buffer[position] != rune('i') {
+													goto l529
+												}
+												position++
+												if buffer[position] != rune('n') {
+													goto l529
+												}
+												position++
+												if buffer[position] != rune('t') {
+													goto l529
+												}
+												position++
+												if buffer[position] != rune('o') {
+													goto l529
+												}
+												position++
+												if !_rules[rule__]() {
+													goto l529
+												}
+												add(ruleINTO, position532)
+											}
+											if !_rules[ruleVariable]() {
+												goto l529
+											}
+											{
+												position533, tokenIndex533 := position, tokenIndex
+												if !_rules[ruleCOMMA]() {
+													goto l533
+												}
+												if !_rules[ruleVariable]() {
+													goto l533
+												}
+												goto l534
+											l533:
+												position, tokenIndex = position533, tokenIndex533
+											}
+										l534:
+											add(ruleLoopParallelResults, position531)
+										}
+										goto l530
+									l529:
+										position, tokenIndex = position529, tokenIndex529
+									}
+								l530:
+									if !_rules[ruleOPEN]() {
+										goto l521
+									}
+								l535:
+									{
+										position536, tokenIndex536 := position, tokenIndex
+										if !_rules[ruleBlock]() {
+											goto l536
+										}
+										goto l535
+									l536:
+										position, tokenIndex = position536, tokenIndex536
+									}
+									if !_rules[ruleCLOSE]() {
+										goto l521
+									}
+									goto l510
+								l521:
+									position, tokenIndex = position510, tokenIndex510
+									if !_rules[ruleLoopConditionIterable]() {
+										goto l537
+									}
+									if !_rules[ruleOPEN]() {
+										goto l537
+									}
+								l538:
+									{
+										position539, tokenIndex539 := position, tokenIndex
+										if !_rules[ruleBlock]() {
+											goto l539
+										}
+										goto l538
+									l539:
+										position, tokenIndex = position539, tokenIndex539
+									}
+									if !_rules[ruleCLOSE]() {
+										goto l537
+									}
+									goto l510
+								l537:
+									position, tokenIndex = position510, tokenIndex510
+									{
+										position541 := position
+										if !_rules[ruleCommand]() {
+											goto l540
+										}
+										if !_rules[ruleSEMI]() {
+											goto l540
+										}
+										if !_rules[ruleConditionalExpression]() {
+											goto l540
+										}
+										if !_rules[ruleSEMI]() {
+											goto l540
+										}
+										if !_rules[ruleCommand]() {
+											goto l540
+										}
+										add(ruleLoopConditionBounded, position541)
+									}
+									if !_rules[ruleOPEN]() {
+										goto l540
+									}
+								l542:
+									{
+										position543, tokenIndex543 := position, tokenIndex
+										if !_rules[ruleBlock]() {
+											goto l543
+										}
+										goto l542
+									l543:
+										position, tokenIndex = position543, tokenIndex543
+									}
+									if !_rules[ruleCLOSE]() {
+										goto l540
+									}
+									goto l510
+								l540:
+									position, tokenIndex = position510, tokenIndex510
+									{
+										position544 := position
+										if !_rules[ruleConditionalExpression]() {
+											goto l504
+										}
+										add(ruleLoopConditionTruthy, position544)
+									}
+									if !_rules[ruleOPEN]() {
+										goto l504
+									}
+								l545:
+									{
+										position546, tokenIndex546 := position, tokenIndex
+										if !_rules[ruleBlock]() {
+											goto l546
+										}
+										goto l545
+									l546:
+										position, tokenIndex = position546, tokenIndex546
+									}
+									if !_rules[ruleCLOSE]() {
+										goto l504
+									}
 								}
-								position++
-								goto l222
-							l223:
-								position, tokenIndex = position223, tokenIndex223
+							l510:
+								add(ruleLoop, position505)
 							}
-							if !matchDot() {
-								goto l222
+							goto l465
+						l504:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position548 := position
+								{
+									position549 := position
+									{
+										position550 := position
+										if !_rules[rule_]() {
+											goto l547
+										}
+										if buffer[position] != rune('t') {
+											goto l547
+										}
+										position++
+										if buffer[position] != rune('r') {
+											goto l547
+										}
+										position++
+										if buffer[position] != rune('y') {
+											goto l547
+										}
+										position++
+										if !_rules[rule_]() {
+											goto l547
+										}
+										add(ruleTRY, position550)
+									}
+									if !_rules[ruleOPEN]() {
+										goto l547
+									}
+								l551:
+									{
+										position552, tokenIndex552 := position, tokenIndex
+										if !_rules[ruleBlock]() {
+											goto l552
+										}
+										goto l551
+									l552:
+										position, tokenIndex = position552, tokenIndex552
+									}
+									if !_rules[ruleCLOSE]() {
+										goto l547
+									}
+									add(ruleTryStanza, position549)
+								}
+								{
+									position553, tokenIndex553 := position, tokenIndex
+									{
+										position555 := position
+										{
+											position556 := position
+											if !_rules[rule_]() {
+												goto l554
+											}
+											if buffer[position] != rune('c') {
+												goto l554
+											}
+											position++
+											if buffer[position] != rune('a') {
+												goto l554
+											}
+											position++
+											if buffer[position] != rune('t') {
+												goto l554
+											}
+											position++
+											if buffer[position] != rune('c') {
+												goto l554
+											}
+											position++
+											if buffer[position] != rune('h') {
+												goto l554
+											}
+											position++
+											if !_rules[rule_]() {
+												goto l554
+											}
+											add(ruleCATCH, position556)
+										}
+										{
+											position557, tokenIndex557 := position, tokenIndex
+											if !_rules[ruleVariable]() {
+												goto l557
+											}
+											goto l558
+										l557:
+											position, tokenIndex = position557, tokenIndex557
+										}
+									l558:
+										if !_rules[ruleOPEN]() {
+											goto l554
+										}
+									l559:
+										{
+											position560, tokenIndex560 := position, tokenIndex
+											if !_rules[ruleBlock]() {
+												goto l560
+											}
+											goto l559
+										l560:
+											position, tokenIndex = position560, tokenIndex560
+										}
+										if !_rules[ruleCLOSE]() {
+											goto l554
+										}
+										add(ruleCatchStanza, position555)
+									}
+									{
+										position561, tokenIndex561 := position, tokenIndex
+										if !_rules[ruleFinallyStanza]() {
+											goto l561
+										}
+										goto l562
+									l561:
+										position, tokenIndex = position561, tokenIndex561
+									}
+								l562:
+									goto l553
+								l554:
+									position, tokenIndex = position553, tokenIndex553
+									if !_rules[ruleFinallyStanza]() {
+										goto l547
+									}
+								}
+							l553:
+								add(ruleTryCatch, position548)
 							}
-							goto l221
-						l222:
-							position, tokenIndex = position222, tokenIndex222
-						}
-						add(ruleCOMMENT, position220)
-					}
-					goto l218
-				l219:
-					position, tokenIndex = position218, tokenIndex218
-					{
-						position225 := position
-						{
-							position226, tokenIndex226 := position, tokenIndex
+							goto l465
+						l547:
+							position, tokenIndex = position465, tokenIndex465
 							{
-								position228 := position
+								position564 := position
 								{
-									position229 := position
+									position565 := position
 									if !_rules[rule_]() {
-										goto l227
+										goto l563
 									}
-									if buffer[position] != rune('b') {
-										goto l227
+									if buffer[position] != rune('m') {
+										goto l563
 									}
 									position++
-									if buffer[position] != rune('r') {
-										goto l227
+									if buffer[position] != rune('a') {
+										goto l563
 									}
 									position++
-									if buffer[position] != rune('e') {
-										goto l227
+									if buffer[position] != rune('t') {
+										goto l563
 									}
 									position++
-									if buffer[position] != rune('a') {
-										goto l227
+									if buffer[position] != rune('c') {
+										goto l563
 									}
 									position++
-									if buffer[position] != rune('k') {
-										goto l227
+									if buffer[position] != rune('h') {
+										goto l563
 									}
 									position++
-									if !_rules[rule_]() {
-										goto l227
+									if !_rules[rule__]() {
+										goto l563
 									}
-									add(ruleBREAK, position229)
+									add(ruleMATCH, position565)
 								}
+								if !_rules[ruleExpression]() {
+									goto l563
+								}
+								if !_rules[ruleOPEN]() {
+									goto l563
+								}
+							l566:
 								{
-									position230, tokenIndex230 := position, tokenIndex
-									if !_rules[rulePositiveInteger]() {
-										goto l230
+									position567, tokenIndex567 := position, tokenIndex
+									{
+										position568 := position
+										if !_rules[rule_]() {
+											goto l567
+										}
+										if !_rules[ruleMatchPattern]() {
+											goto l567
+										}
+									l569:
+										{
+											position570, tokenIndex570 := position, tokenIndex
+											if !_rules[ruleCOMMA]() {
+												goto l570
+											}
+											if !_rules[ruleMatchPattern]() {
+												goto l570
+											}
+											goto l569
+										l570:
+											position, tokenIndex = position570, tokenIndex570
+										}
+										if !_rules[ruleASSIGN]() {
+											goto l567
+										}
+										if !_rules[ruleOPEN]() {
+											goto l567
+										}
+									l571:
+										{
+											position572, tokenIndex572 := position, tokenIndex
+											if !_rules[ruleBlock]() {
+												goto l572
+											}
+											goto l571
+										l572:
+											position, tokenIndex = position572, tokenIndex572
+										}
+										if !_rules[ruleCLOSE]() {
+											goto l567
+										}
+										add(ruleMatchArm, position568)
 									}
-									goto l231
-								l230:
-									position, tokenIndex = position230, tokenIndex230
+									goto l566
+								l567:
+									position, tokenIndex = position567, tokenIndex567
 								}
-							l231:
-								add(ruleFlowControlBreak, position228)
+								if !_rules[ruleCLOSE]() {
+									goto l563
+								}
+								add(ruleMatchStatement, position564)
 							}
-							goto l226
-						l227:
-							position, tokenIndex = position226, tokenIndex226
+							goto l465
+						l563:
+							position, tokenIndex = position465, tokenIndex465
 							{
-								position232 := position
+								position574 := position
+								if !_rules[rulePARALLEL]() {
+									goto l573
+								}
+								if !_rules[ruleOPEN]() {
+									goto l573
+								}
+							l575:
 								{
-									position233 := position
-									if !_rules[rule_]() {
-										goto l224
+									position576, tokenIndex576 := position, tokenIndex
+									if !_rules[ruleBlock]() {
+										goto l576
 									}
-									if buffer[position] != rune('c') {
-										goto l224
+									goto l575
+								l576:
+									position, tokenIndex = position576, tokenIndex576
+								}
+								if !_rules[ruleCLOSE]() {
+									goto l573
+								}
+								add(ruleParallelStatement, position574)
+							}
+							goto l465
+						l573:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position578 := position
+								{
+									position579 := position
+									if !_rules[rule_]() {
+										goto l577
 									}
-									position++
-									if buffer[position] != rune('o') {
-										goto l224
+									if buffer[position] != rune('t') {
+										goto l577
 									}
 									position++
-									if buffer[position] != rune('n') {
-										goto l224
+									if buffer[position] != rune('i') {
+										goto l577
 									}
 									position++
-									if buffer[position] != rune('t') {
-										goto l224
+									if buffer[position] != rune('m') {
+										goto l577
 									}
 									position++
-									if buffer[position] != rune('i') {
-										goto l224
+									if buffer[position] != rune('e') {
+										goto l577
 									}
 									position++
-									if buffer[position] != rune('n') {
-										goto l224
+									if buffer[position] != rune('o') {
+										goto l577
 									}
 									position++
 									if buffer[position] != rune('u') {
-										goto l224
+										goto l577
 									}
 									position++
-									if buffer[position] != rune('e') {
-										goto l224
+									if buffer[position] != rune('t') {
+										goto l577
 									}
 									position++
-									if !_rules[rule_]() {
-										goto l224
+									if !_rules[rule__]() {
+										goto l577
 									}
-									add(ruleCONT, position233)
+									add(ruleTIMEOUT, position579)
+								}
+								if !_rules[ruleExpression]() {
+									goto l577
+								}
+								if !_rules[ruleOPEN]() {
+									goto l577
 								}
+							l580:
 								{
-									position234, tokenIndex234 := position, tokenIndex
-									if !_rules[rulePositiveInteger]() {
-										goto l234
+									position581, tokenIndex581 := position, tokenIndex
+									if !_rules[ruleBlock]() {
+										goto l581
 									}
-									goto l235
-								l234:
-									position, tokenIndex = position234, tokenIndex234
+									goto l580
+								l581:
+									position, tokenIndex = position581, tokenIndex581
 								}
-							l235:
-								add(ruleFlowControlContinue, position232)
-							}
-						}
-					l226:
-						add(ruleFlowControlWord, position225)
-					}
-					goto l218
-				l224:
-					position, tokenIndex = position218, tokenIndex218
-					{
-						position236 := position
-						{
-							position237, tokenIndex237 := position, tokenIndex
-							{
-								position239 := position
-								if !_rules[ruleSEMI]() {
-									goto l238
+								if !_rules[ruleCLOSE]() {
+									goto l577
 								}
-								add(ruleNOOP, position239)
-							}
-							goto l237
-						l238:
-							position, tokenIndex = position237, tokenIndex237
-							if !_rules[ruleAssignment]() {
-								goto l240
+								add(ruleTimeoutStatement, position578)
 							}
-							goto l237
-						l240:
-							position, tokenIndex = position237, tokenIndex237
+							goto l465
+						l577:
+							position, tokenIndex = position465, tokenIndex465
 							{
-								position242 := position
+								position583 := position
+								{
+									position584 := position
+									if !_rules[rule_]() {
+										goto l582
+									}
+									if buffer[position] != rune('r') {
+										goto l582
+									}
+									position++
+									if buffer[position] != rune('e') {
+										goto l582
+									}
+									position++
+									if buffer[position] != rune('t') {
+										goto l582
+									}
+									position++
+									if buffer[position] != rune('r') {
+										goto l582
+									}
+									position++
+									if buffer[position] != rune('y') {
+										goto l582
+									}
+									position++
+									if !_rules[rule__]() {
+										goto l582
+									}
+									add(ruleRETRY, position584)
+								}
+								{
+									position585 := position
+									{
+										position586, tokenIndex586 := position, tokenIndex
+										if !_rules[rulePositiveInteger]() {
+											goto l587
+										}
+										goto l586
+									l587:
+										position, tokenIndex = position586, tokenIndex586
+										if !_rules[ruleVariable]() {
+											goto l582
+										}
+									}
+								l586:
+									add(ruleRetryAttempts, position585)
+								}
 								{
-									position243, tokenIndex243 := position, tokenIndex
+									position588, tokenIndex588 := position, tokenIndex
 									{
-										position245 := position
+										position590 := position
 										{
-											position246 := position
+											position591 := position
 											if !_rules[rule_]() {
-												goto l244
+												goto l588
 											}
-											if buffer[position] != rune('u') {
-												goto l244
+											if buffer[position] != rune('b') {
+												goto l588
 											}
 											position++
-											if buffer[position] != rune('n') {
-												goto l244
+											if buffer[position] != rune('a') {
+												goto l588
 											}
 											position++
-											if buffer[position] != rune('s') {
-												goto l244
+											if buffer[position] != rune('c') {
+												goto l588
 											}
 											position++
-											if buffer[position] != rune('e') {
-												goto l244
+											if buffer[position] != rune('k') {
+												goto l588
 											}
 											position++
-											if buffer[position] != rune('t') {
-												goto l244
+											if buffer[position] != rune('o') {
+												goto l588
+											}
+											position++
+											if buffer[position] != rune('f') {
+												goto l588
+											}
+											position++
+											if buffer[position] != rune('f') {
+												goto l588
 											}
 											position++
 											if !_rules[rule__]() {
-												goto l244
+												goto l588
 											}
-											add(ruleUNSET, position246)
+											add(ruleBACKOFF, position591)
 										}
-										if !_rules[ruleVariableSequence]() {
-											goto l244
+										{
+											position592, tokenIndex592 := position, tokenIndex
+											{
+												position594 := position
+												{
+													position595, tokenIndex595 := position, tokenIndex
+													if buffer[position] != rune('e') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('x') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('p') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('o') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('n') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('e') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('n') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('t') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('i') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('a') {
+														goto l596
+													}
+													position++
+													if buffer[position] != rune('l') {
+														goto l596
+													}
+													position++
+													goto l595
+												l596:
+													position, tokenIndex = position595, tokenIndex595
+													if buffer[position] != rune('l') {
+														goto l597
+													}
+													position++
+													if buffer[position] != rune('i') {
+														goto l597
+													}
+													position++
+													if buffer[position] != rune('n') {
+														goto l597
+													}
+													position++
+													if buffer[position] != rune('e') {
+														goto l597
+													}
+													position++
+													if buffer[position] != rune('a') {
+														goto l597
+													}
+													position++
+													if buffer[position] != rune('r') {
+														goto l597
+													}
+													position++
+													goto l595
+												l597:
+													position, tokenIndex = position595, tokenIndex595
+													if buffer[position] != rune('c') {
+														goto l592
+													}
+													position++
+													if buffer[position] != rune('o') {
+														goto l592
+													}
+													position++
+													if buffer[position] != rune('n') {
+														goto l592
+													}
+													position++
+													if buffer[position] != rune('s') {
+														goto l592
+													}
+													position++
+													if buffer[position] != rune('t') {
+														goto l592
+													}
+													position++
+													if buffer[position] != rune('a') {
+														goto l592
+													}
+													position++
+													if buffer[position] != rune('n') {
+														goto l592
+													}
+													position++
+													if buffer[position] != rune('t') {
+														goto l592
+													}
+													position++
+												}
+											l595:
+												if !_rules[rule__]() {
+													goto l592
+												}
+												add(ruleRetryBackoffType, position594)
+											}
+											goto l593
+										l592:
+											position, tokenIndex = position592, tokenIndex592
+										}
+									l593:
+										{
+											position598, tokenIndex598 := position, tokenIndex
+											if !_rules[ruleRange]() {
+												goto l599
+											}
+											goto l598
+										l599:
+											position, tokenIndex = position598, tokenIndex598
+											if !_rules[ruleExpression]() {
+												goto l588
+											}
 										}
-										add(ruleDirectiveUnset, position245)
+									l598:
+										add(ruleRetryBackoff, position590)
 									}
-									goto l243
-								l244:
-									position, tokenIndex = position243, tokenIndex243
+									goto l589
+								l588:
+									position, tokenIndex = position588, tokenIndex588
+								}
+							l589:
+								{
+									position600, tokenIndex600 := position, tokenIndex
 									{
-										position248 := position
+										position602 := position
 										{
-											position249 := position
+											position603 := position
 											if !_rules[rule_]() {
-												goto l247
+												goto l600
 											}
-											if buffer[position] != rune('i') {
-												goto l247
+											if buffer[position] != rune('j') {
+												goto l600
 											}
 											position++
-											if buffer[position] != rune('n') {
-												goto l247
+											if buffer[position] != rune('i') {
+												goto l600
 											}
 											position++
-											if buffer[position] != rune('c') {
-												goto l247
+											if buffer[position] != rune('t') {
+												goto l600
 											}
 											position++
-											if buffer[position] != rune('l') {
-												goto l247
+											if buffer[position] != rune('t') {
+												goto l600
 											}
 											position++
-											if buffer[position] != rune('u') {
-												goto l247
+											if buffer[position] != rune('e') {
+												goto l600
 											}
 											position++
-											if buffer[position] != rune('d') {
-												goto l247
+											if buffer[position] != rune('r') {
+												goto l600
 											}
 											position++
-											if buffer[position] != rune('e') {
-												goto l247
+											{
+												position604, tokenIndex604 := position, tokenIndex
+												{
+													position605, tokenIndex605 := position, tokenIndex
+													if c := buffer[position]; c < rune('a') || c > rune('z') {
+														goto l606
+													}
+													position++
+													goto l605
+												l606:
+													position, tokenIndex = position605, tokenIndex605
+													if c := buffer[position]; c < rune('A') || c > rune('Z') {
+														goto l607
+													}
+													position++
+													goto l605
+												l607:
+													position, tokenIndex = position605, tokenIndex605
+													{
+														position609, tokenIndex609 := position, tokenIndex
+														if c := buffer[position]; c < rune('0') || c > rune('9') {
+															goto l610
+														}
+														position++
+														goto l609
+													l610:
+														position, tokenIndex = position609, tokenIndex609
+														if c := buffer[position]; c < rune('0') || c > rune('9') {
+															goto l608
+														}
+														position++
+													}
+												l609:
+													goto l605
+												l608:
+													position, tokenIndex = position605, tokenIndex605
+													if buffer[position] != rune('_') {
+														goto l604
+													}
+													position++
+												}
+											l605:
+												goto l600
+											l604:
+												position, tokenIndex = position604, tokenIndex604
 											}
-											position++
-											if !_rules[rule__]() {
-												goto l247
+											if !_rules[rule_]() {
+												goto l600
 											}
-											add(ruleINCLUDE, position249)
+											add(ruleJITTER, position603)
 										}
-										if !_rules[ruleString]() {
-											goto l247
+										{
+											position611, tokenIndex611 := position, tokenIndex
+											{
+												position613, tokenIndex613 := position, tokenIndex
+												if !_rules[ruleFloat]() {
+													goto l614
+												}
+												goto l613
+											l614:
+												position, tokenIndex = position613, tokenIndex613
+												if !_rules[ruleVariable]() {
+													goto l611
+												}
+											}
+										l613:
+											goto l612
+										l611:
+											position, tokenIndex = position611, tokenIndex611
 										}
-										add(ruleDirectiveInclude, position248)
+									l612:
+										add(ruleRetryJitter, position602)
 									}
-									goto l243
-								l247:
-									position, tokenIndex = position243, tokenIndex243
+									goto l601
+								l600:
+									position, tokenIndex = position600, tokenIndex600
+								}
+							l601:
+								{
+									position615, tokenIndex615 := position, tokenIndex
 									{
-										position250 := position
+										position617 := position
 										{
-											position251 := position
+											position618 := position
 											if !_rules[rule_]() {
-												goto l241
-											}
-											if buffer[position] != rune('d') {
-												goto l241
-											}
-											position++
-											if buffer[position] != rune('e') {
-												goto l241
+												goto l615
 											}
-											position++
-											if buffer[position] != rune('c') {
-												goto l241
+											if buffer[position] != rune('u') {
+												goto l615
 											}
 											position++
-											if buffer[position] != rune('l') {
-												goto l241
+											if buffer[position] != rune('n') {
+												goto l615
 											}
 											position++
-											if buffer[position] != rune('a') {
-												goto l241
+											if buffer[position] != rune('t') {
+												goto l615
 											}
 											position++
-											if buffer[position] != rune('r') {
-												goto l241
+											if buffer[position] != rune('i') {
+												goto l615
 											}
 											position++
-											if buffer[position] != rune('e') {
-												goto l241
+											if buffer[position] != rune('l') {
+												goto l615
 											}
 											position++
 											if !_rules[rule__]() {
-												goto l241
+												goto l615
 											}
-											add(ruleDECLARE, position251)
+											add(ruleUNTIL, position618)
 										}
-										if !_rules[ruleVariableSequence]() {
-											goto l241
+										if !_rules[ruleConditionalExpression]() {
+											goto l615
 										}
-										add(ruleDirectiveDeclare, position250)
+										add(ruleRetryUntil, position617)
 									}
+									goto l616
+								l615:
+									position, tokenIndex = position615, tokenIndex615
 								}
-							l243:
-								add(ruleDirective, position242)
-							}
-							goto l237
-						l241:
-							position, tokenIndex = position237, tokenIndex237
-							{
-								position253 := position
-								if !_rules[ruleIfStanza]() {
-									goto l252
+							l616:
+								if !_rules[ruleOPEN]() {
+									goto l582
 								}
-							l254:
+							l619:
 								{
-									position255, tokenIndex255 := position, tokenIndex
-									{
-										position256 := position
-										if !_rules[ruleELSE]() {
-											goto l255
-										}
-										if !_rules[ruleIfStanza]() {
-											goto l255
-										}
-										add(ruleElseIfStanza, position256)
+									position620, tokenIndex620 := position, tokenIndex
+									if !_rules[ruleBlock]() {
+										goto l620
 									}
-									goto l254
-								l255:
-									position, tokenIndex = position255, tokenIndex255
+									goto l619
+								l620:
+									position, tokenIndex = position620, tokenIndex620
 								}
-								{
-									position257, tokenIndex257 := position, tokenIndex
-									{
-										position259 := position
-										if !_rules[ruleELSE]() {
-											goto l257
-										}
-										if !_rules[ruleOPEN]() {
-											goto l257
-										}
-									l260:
-										{
-											position261, tokenIndex261 := position, tokenIndex
-											if !_rules[ruleBlock]() {
-												goto l261
-											}
-											goto l260
-										l261:
-											position, tokenIndex = position261, tokenIndex261
-										}
-										if !_rules[ruleCLOSE]() {
-											goto l257
-										}
-										add(ruleElseStanza, position259)
-									}
-									goto l258
-								l257:
-									position, tokenIndex = position257, tokenIndex257
+								if !_rules[ruleCLOSE]() {
+									goto l582
 								}
-							l258:
-								add(ruleConditional, position253)
+								add(ruleRetryStatement, position583)
 							}
-							goto l237
-						l252:
-							position, tokenIndex = position237, tokenIndex237
+							goto l465
+						l582:
+							position, tokenIndex = position465, tokenIndex465
 							{
-								position263 := position
+								position622 := position
 								{
-									position264 := position
+									position623 := position
 									if !_rules[rule_]() {
-										goto l262
+										goto l621
 									}
-									if buffer[position] != rune('l') {
-										goto l262
+									if buffer[position] != rune('d') {
+										goto l621
 									}
 									position++
-									if buffer[position] != rune('o') {
-										goto l262
+									if buffer[position] != rune('e') {
+										goto l621
 									}
 									position++
-									if buffer[position] != rune('o') {
-										goto l262
+									if buffer[position] != rune('f') {
+										goto l621
 									}
 									position++
-									if buffer[position] != rune('p') {
-										goto l262
+									if buffer[position] != rune('e') {
+										goto l621
 									}
 									position++
-									if !_rules[rule_]() {
-										goto l262
+									if buffer[position] != rune('r') {
+										goto l621
+									}
+									position++
+									if !_rules[rule__]() {
+										goto l621
 									}
-									add(ruleLOOP, position264)
+									add(ruleDEFER, position623)
 								}
 								{
-									position265, tokenIndex265 := position, tokenIndex
+									position624, tokenIndex624 := position, tokenIndex
 									if !_rules[ruleOPEN]() {
-										goto l266
+										goto l625
 									}
-								l267:
+								l626:
 									{
-										position268, tokenIndex268 := position, tokenIndex
+										position627, tokenIndex627 := position, tokenIndex
 										if !_rules[ruleBlock]() {
-											goto l268
+											goto l627
 										}
-										goto l267
-									l268:
-										position, tokenIndex = position268, tokenIndex268
+										goto l626
+									l627:
+										position, tokenIndex = position627, tokenIndex627
+									}
+									if !_rules[ruleCLOSE]() {
+										goto l625
+									}
+									goto l624
+								l625:
+									position, tokenIndex = position624, tokenIndex624
+									if !_rules[ruleCommand]() {
+										goto l621
+									}
+								}
+							l624:
+								add(ruleDeferStatement, position622)
+							}
+							goto l465
+						l621:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position629 := position
+								{
+									position630 := position
+									{
+										position631 := position
+										if !_rules[rule_]() {
+											goto l628
+										}
+										if buffer[position] != rune('t') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('r') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('a') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('n') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('s') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('a') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('c') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('t') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('i') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('o') {
+											goto l628
+										}
+										position++
+										if buffer[position] != rune('n') {
+											goto l628
+										}
+										position++
+										if !_rules[rule_]() {
+											goto l628
+										}
+										add(ruleTRANSACTION, position631)
+									}
+									if !_rules[ruleOPEN]() {
+										goto l628
+									}
+								l632:
+									{
+										position633, tokenIndex633 := position, tokenIndex
+										if !_rules[ruleBlock]() {
+											goto l633
+										}
+										goto l632
+									l633:
+										position, tokenIndex = position633, tokenIndex633
 									}
 									if !_rules[ruleCLOSE]() {
-										goto l266
+										goto l628
 									}
-									goto l265
-								l266:
-									position, tokenIndex = position265, tokenIndex265
+									add(ruleTransactionStanza, position630)
+								}
+								{
+									position634, tokenIndex634 := position, tokenIndex
 									{
-										position270 := position
+										position636 := position
 										{
-											position271 := position
+											position637 := position
 											if !_rules[rule_]() {
-												goto l269
+												goto l634
 											}
-											if buffer[position] != rune('c') {
-												goto l269
+											if buffer[position] != rune('r') {
+												goto l634
 											}
 											position++
 											if buffer[position] != rune('o') {
-												goto l269
+												goto l634
 											}
 											position++
-											if buffer[position] != rune('u') {
-												goto l269
+											if buffer[position] != rune('l') {
+												goto l634
 											}
 											position++
-											if buffer[position] != rune('n') {
-												goto l269
+											if buffer[position] != rune('l') {
+												goto l634
 											}
 											position++
-											if buffer[position] != rune('t') {
-												goto l269
+											if buffer[position] != rune('b') {
+												goto l634
 											}
 											position++
-											if !_rules[rule_]() {
-												goto l269
+											if buffer[position] != rune('a') {
+												goto l634
 											}
-											add(ruleCOUNT, position271)
-										}
-										{
-											position272, tokenIndex272 := position, tokenIndex
-											if !_rules[ruleInteger]() {
-												goto l273
+											position++
+											if buffer[position] != rune('c') {
+												goto l634
 											}
-											goto l272
-										l273:
-											position, tokenIndex = position272, tokenIndex272
-											if !_rules[ruleVariable]() {
-												goto l269
+											position++
+											if buffer[position] != rune('k') {
+												goto l634
 											}
-										}
-									l272:
-										add(ruleLoopConditionFixedLength, position270)
-									}
-									if !_rules[ruleOPEN]() {
-										goto l269
-									}
-								l274:
-									{
-										position275, tokenIndex275 := position, tokenIndex
-										if !_rules[ruleBlock]() {
-											goto l275
-										}
-										goto l274
-									l275:
-										position, tokenIndex = position275, tokenIndex275
-									}
-									if !_rules[ruleCLOSE]() {
-										goto l269
-									}
-									goto l265
-								l269:
-									position, tokenIndex = position265, tokenIndex265
-									{
-										position277 := position
-										{
-											position278 := position
-											if !_rules[ruleVariableSequence]() {
-												goto l276
+											position++
+											if !_rules[rule_]() {
+												goto l634
 											}
-											add(ruleLoopIterableLHS, position278)
+											add(ruleROLLBACK, position637)
 										}
 										{
-											position279 := position
-											if !_rules[rule__]() {
-												goto l276
-											}
-											if buffer[position] != rune('i') {
-												goto l276
-											}
-											position++
-											if buffer[position] != rune('n') {
-												goto l276
-											}
-											position++
-											if !_rules[rule__]() {
-												goto l276
+											position638, tokenIndex638 := position, tokenIndex
+											if !_rules[ruleVariable]() {
+												goto l638
 											}
-											add(ruleIN, position279)
+											goto l639
+										l638:
+											position, tokenIndex = position638, tokenIndex638
+										}
+									l639:
+										if !_rules[ruleOPEN]() {
+											goto l634
 										}
+									l640:
 										{
-											position280 := position
-											{
-												position281, tokenIndex281 := position, tokenIndex
-												if !_rules[ruleCommand]() {
-													goto l282
-												}
-												goto l281
-											l282:
-												position, tokenIndex = position281, tokenIndex281
-												if !_rules[ruleVariable]() {
-													goto l276
-												}
+											position641, tokenIndex641 := position, tokenIndex
+											if !_rules[ruleBlock]() {
+												goto l641
 											}
-										l281:
-											add(ruleLoopIterableRHS, position280)
+											goto l640
+										l641:
+											position, tokenIndex = position641, tokenIndex641
 										}
-										add(ruleLoopConditionIterable, position277)
-									}
-									if !_rules[ruleOPEN]() {
-										goto l276
-									}
-								l283:
-									{
-										position284, tokenIndex284 := position, tokenIndex
-										if !_rules[ruleBlock]() {
-											goto l284
+										if !_rules[ruleCLOSE]() {
+											goto l634
 										}
-										goto l283
-									l284:
-										position, tokenIndex = position284, tokenIndex284
+										add(ruleRollbackStanza, position636)
 									}
-									if !_rules[ruleCLOSE]() {
-										goto l276
+									goto l635
+								l634:
+									position, tokenIndex = position634, tokenIndex634
+								}
+							l635:
+								add(ruleTransactionStatement, position629)
+							}
+							goto l465
+						l628:
+							position, tokenIndex = position465, tokenIndex465
+							{
+								position643 := position
+								if !_rules[ruleCommand]() {
+									goto l642
+								}
+								{
+									position644 := position
+									if !_rules[rule_]() {
+										goto l642
 									}
-									goto l265
-								l276:
-									position, tokenIndex = position265, tokenIndex265
-									{
-										position286 := position
-										if !_rules[ruleCommand]() {
-											goto l285
-										}
-										if !_rules[ruleSEMI]() {
-											goto l285
-										}
-										if !_rules[ruleConditionalExpression]() {
-											goto l285
-										}
-										if !_rules[ruleSEMI]() {
-											goto l285
-										}
-										if !_rules[ruleCommand]() {
-											goto l285
-										}
-										add(ruleLoopConditionBounded, position286)
+									if buffer[position] != rune('u') {
+										goto l642
 									}
-									if !_rules[ruleOPEN]() {
-										goto l285
+									position++
+									if buffer[position] != rune('n') {
+										goto l642
 									}
-								l287:
-									{
-										position288, tokenIndex288 := position, tokenIndex
-										if !_rules[ruleBlock]() {
-											goto l288
-										}
-										goto l287
-									l288:
-										position, tokenIndex = position288, tokenIndex288
+									position++
+									if buffer[position] != rune('d') {
+										goto l642
 									}
-									if !_rules[ruleCLOSE]() {
-										goto l285
+									position++
+									if buffer[position] != rune('o') {
+										goto l642
 									}
-									goto l265
-								l285:
-									position, tokenIndex = position265, tokenIndex265
-									{
-										position289 := position
-										if !_rules[ruleConditionalExpression]() {
-											goto l262
-										}
-										add(ruleLoopConditionTruthy, position289)
+									position++
+									if !_rules[rule__]() {
+										goto l642
 									}
+									add(ruleUNDO, position644)
+								}
+								{
+									position645, tokenIndex645 := position, tokenIndex
 									if !_rules[ruleOPEN]() {
-										goto l262
+										goto l646
 									}
-								l290:
+								l647:
 									{
-										position291, tokenIndex291 := position, tokenIndex
+										position648, tokenIndex648 := position, tokenIndex
 										if !_rules[ruleBlock]() {
-											goto l291
+											goto l648
 										}
-										goto l290
-									l291:
-										position, tokenIndex = position291, tokenIndex291
+										goto l647
+									l648:
+										position, tokenIndex = position648, tokenIndex648
 									}
 									if !_rules[ruleCLOSE]() {
-										goto l262
+										goto l646
+									}
+									goto l645
+								l646:
+									position, tokenIndex = position645, tokenIndex645
+									if !_rules[ruleCommand]() {
+										goto l642
 									}
 								}
-							l265:
-								add(ruleLoop, position263)
+							l645:
+								add(ruleUndoableCommand, position643)
 							}
-							goto l237
-						l262:
-							position, tokenIndex = position237, tokenIndex237
+							goto l465
+						l642:
+							position, tokenIndex = position465, tokenIndex465
 							if !_rules[ruleCommand]() {
-								goto l216
+								goto l389
 							}
 						}
-					l237:
-						add(ruleStatementBlock, position236)
+					l465:
+						add(ruleStatementBlock, position464)
 					}
 				}
-			l218:
+			l391:
 				{
-					position292, tokenIndex292 := position, tokenIndex
+					position649, tokenIndex649 := position, tokenIndex
 					if !_rules[ruleSEMI]() {
-						goto l292
+						goto l649
 					}
-					goto l293
-				l292:
-					position, tokenIndex = position292, tokenIndex292
+					goto l650
+				l649:
+					position, tokenIndex = position649, tokenIndex649
 				}
-			l293:
+			l650:
 				if !_rules[rule_]() {
-					goto l216
+					goto l389
+				}
+				add(ruleBlock, position390)
+			}
+			return true
+		l389:
+			position, tokenIndex = position389, tokenIndex389
+			return false
+		},
+		/* 128 FlowControlWord <- <(FlowControlBreak / FlowControlContinue / FlowControlReturn / FlowControlExit)> */
+		nil,
+		/* 129 FlowControlBreak <- <(BREAK (PositiveInteger / FlowControlLabel)?)> */
+		nil,
+		/* 130 FlowControlContinue <- <(CONT (PositiveInteger / FlowControlLabel)?)> */
+		nil,
+		/* 131 FlowControlLabel <- <Identifier> */
+		func() bool {
+			position654, tokenIndex654 := position, tokenIndex
+			{
+				position655 := position
+				if !_rules[ruleIdentifier]() {
+					goto l654
 				}
-				add(ruleBlock, position217)
+				add(ruleFlowControlLabel, position655)
 			}
 			return true
-		l216:
-			position, tokenIndex = position216, tokenIndex216
+		l654:
+			position, tokenIndex = position654, tokenIndex654
 			return false
 		},
-		/* 85 FlowControlWord <- <(FlowControlBreak / FlowControlContinue)> */
+		/* 132 FlowControlReturn <- <(RETURN (!('\r' / '\n') ExpressionSequence)?)> */
 		nil,
-		/* 86 FlowControlBreak <- <(BREAK PositiveInteger?)> */
+		/* 133 FlowControlExit <- <(EXIT (Integer / Variable)?)> */
 		nil,
-		/* 87 FlowControlContinue <- <(CONT PositiveInteger?)> */
+		/* 134 StatementBlock <- <(NOOP / ConstantDeclaration / Assignment / Directive / FunctionDefinition / Conditional / Loop / TryCatch / MatchStatement / ParallelStatement / TimeoutStatement / RetryStatement / DeferStatement / TransactionStatement / UndoableCommand / Command)> */
 		nil,
-		/* 88 StatementBlock <- <(NOOP / Assignment / Directive / Conditional / Loop / Command)> */
+		/* 135 EventHandler <- <(ON String OPEN Block* CLOSE)> */
 		nil,
-		/* 89 Assignment <- <(AssignmentLHS AssignmentOperator AssignmentRHS)> */
+		/* 136 Assignment <- <(AssignmentLHS AssignmentOperator AssignmentRHS)> */
 		func() bool {
-			position298, tokenIndex298 := position, tokenIndex
+			position660, tokenIndex660 := position, tokenIndex
 			{
-				position299 := position
-				{
-					position300 := position
-					if !_rules[ruleVariableSequence]() {
-						goto l298
-					}
-					add(ruleAssignmentLHS, position300)
+				position661 := position
+				if !_rules[ruleAssignmentLHS]() {
+					goto l660
 				}
 				{
-					position301 := position
+					position662 := position
 					if !_rules[rule_]() {
-						goto l298
+						goto l660
 					}
 					{
-						position302, tokenIndex302 := position, tokenIndex
-						{
-							position304 := position
-							if !_rules[rule_]() {
-								goto l303
-							}
-							if buffer[position] != rune('=') {
-								goto l303
-							}
-							position++
-							if !_rules[rule_]() {
-								goto l303
-							}
-							add(ruleAssignEq, position304)
+						position663, tokenIndex663 := position, tokenIndex
+						if !_rules[ruleAssignEq]() {
+							goto l664
 						}
-						goto l302
-					l303:
-						position, tokenIndex = position302, tokenIndex302
+						goto l663
+					l664:
+						position, tokenIndex = position663, tokenIndex663
 						{
-							position306 := position
+							position666 := position
 							if !_rules[rule_]() {
-								goto l305
+								goto l665
 							}
 							if buffer[position] != rune('*') {
-								goto l305
+								goto l665
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l305
+								goto l665
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l305
+								goto l665
 							}
-							add(ruleStarEq, position306)
+							add(ruleStarEq, position666)
 						}
-						goto l302
-					l305:
-						position, tokenIndex = position302, tokenIndex302
+						goto l663
+					l665:
+						position, tokenIndex = position663, tokenIndex663
 						{
-							position308 := position
+							position668 := position
 							if !_rules[rule_]() {
-								goto l307
+								goto l667
 							}
 							if buffer[position] != rune('/') {
-								goto l307
+								goto l667
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l307
+								goto l667
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l307
+								goto l667
 							}
-							add(ruleDivEq, position308)
+							add(ruleDivEq, position668)
 						}
-						goto l302
-					l307:
-						position, tokenIndex = position302, tokenIndex302
+						goto l663
+					l667:
+						position, tokenIndex = position663, tokenIndex663
 						{
-							position310 := position
+							position670 := position
 							if !_rules[rule_]() {
-								goto l309
+								goto l669
 							}
 							if buffer[position] != rune('+') {
-								goto l309
+								goto l669
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l309
+								goto l669
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l309
+								goto l669
 							}
-							add(rulePlusEq, position310)
+							add(rulePlusEq, position670)
 						}
-						goto l302
-					l309:
-						position, tokenIndex = position302, tokenIndex302
+						goto l663
+					l669:
+						position, tokenIndex = position663, tokenIndex663
 						{
-							position312 := position
+							position672 := position
 							if !_rules[rule_]() {
-								goto l311
+								goto l671
 							}
 							if buffer[position] != rune('-') {
-								goto l311
+								goto l671
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l311
+								goto l671
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l311
+								goto l671
 							}
-							add(ruleMinusEq, position312)
+							add(ruleMinusEq, position672)
 						}
-						goto l302
-					l311:
-						position, tokenIndex = position302, tokenIndex302
+						goto l663
+					l671:
+						position, tokenIndex = position663, tokenIndex663
 						{
-							position314 := position
+							position674 := position
 							if !_rules[rule_]() {
-								goto l313
+								goto l673
 							}
 							if buffer[position] != rune('&') {
-								goto l313
+								goto l673
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l313
+								goto l673
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l313
+								goto l673
 							}
-							add(ruleAndEq, position314)
+							add(ruleAndEq, position674)
 						}
-						goto l302
-					l313:
-						position, tokenIndex = position302, tokenIndex302
+						goto l663
+					l673:
+						position, tokenIndex = position663, tokenIndex663
 						{
-							position316 := position
+							position676 := position
 							if !_rules[rule_]() {
-								goto l315
+								goto l675
 							}
 							if buffer[position] != rune('|') {
-								goto l315
+								goto l675
 							}
 							position++
 							if buffer[position] != rune('=') {
-								goto l315
+								goto l675
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l315
+								goto l675
 							}
-							add(ruleOrEq, position316)
+							add(ruleOrEq, position676)
 						}
-						goto l302
-					l315:
-						position, tokenIndex = position302, tokenIndex302
+						goto l663
+					l675:
+						position, tokenIndex = position663, tokenIndex663
 						{
-							position317 := position
+							position677 := position
 							if !_rules[rule_]() {
-								goto l298
+								goto l660
 							}
 							if buffer[position] != rune('<') {
-								goto l298
+								goto l660
 							}
 							position++
 							if buffer[position] != rune('<') {
-								goto l298
+								goto l660
 							}
 							position++
 							if !_rules[rule_]() {
-								goto l298
+								goto l660
 							}
-							add(ruleAppend, position317)
+							add(ruleAppend, position677)
 						}
 					}
-				l302:
+				l663:
 					if !_rules[rule_]() {
-						goto l298
+						goto l660
 					}
-					add(ruleAssignmentOperator, position301)
+					add(ruleAssignmentOperator, position662)
 				}
-				{
-					position318 := position
-					if !_rules[ruleExpressionSequence]() {
-						goto l298
-					}
-					add(ruleAssignmentRHS, position318)
+				if !_rules[ruleAssignmentRHS]() {
+					goto l660
 				}
-				add(ruleAssignment, position299)
+				add(ruleAssignment, position661)
 			}
 			return true
-		l298:
-			position, tokenIndex = position298, tokenIndex298
+		l660:
+			position, tokenIndex = position660, tokenIndex660
 			return false
 		},
-		/* 90 AssignmentLHS <- <VariableSequence> */
-		nil,
-		/* 91 AssignmentRHS <- <ExpressionSequence> */
+		/* 137 AssignmentLHS <- <AssignmentTarget> */
+		func() bool {
+			position678, tokenIndex678 := position, tokenIndex
+			{
+				position679 := position
+				if !_rules[ruleAssignmentTarget]() {
+					goto l678
+				}
+				add(ruleAssignmentLHS, position679)
+			}
+			return true
+		l678:
+			position, tokenIndex = position678, tokenIndex678
+			return false
+		},
+		/* 138 ConstantDeclaration <- <(CONST AssignmentLHS AssignEq AssignmentRHS)> */
 		nil,
-		/* 92 VariableSequence <- <((Variable COMMA)* Variable)> */
+		/* 139 AssignmentRHS <- <ExpressionSequence> */
+		func() bool {
+			position681, tokenIndex681 := position, tokenIndex
+			{
+				position682 := position
+				if !_rules[ruleExpressionSequence]() {
+					goto l681
+				}
+				add(ruleAssignmentRHS, position682)
+			}
+			return true
+		l681:
+			position, tokenIndex = position681, tokenIndex681
+			return false
+		},
+		/* 140 VariableSequence <- <((Variable COMMA)* Variable)> */
 		func() bool {
-			position321, tokenIndex321 := position, tokenIndex
+			position683, tokenIndex683 := position, tokenIndex
 			{
-				position322 := position
-			l323:
+				position684 := position
+			l685:
 				{
-					position324, tokenIndex324 := position, tokenIndex
+					position686, tokenIndex686 := position, tokenIndex
 					if !_rules[ruleVariable]() {
-						goto l324
+						goto l686
 					}
 					if !_rules[ruleCOMMA]() {
-						goto l324
+						goto l686
 					}
-					goto l323
-				l324:
-					position, tokenIndex = position324, tokenIndex324
+					goto l685
+				l686:
+					position, tokenIndex = position686, tokenIndex686
+				}
+				if !_rules[ruleVariable]() {
+					goto l683
+				}
+				add(ruleVariableSequence, position684)
+			}
+			return true
+		l683:
+			position, tokenIndex = position683, tokenIndex683
+			return false
+		},
+		/* 141 AssignmentTarget <- <(ObjectTarget / ArrayTarget)> */
+		func() bool {
+			position687, tokenIndex687 := position, tokenIndex
+			{
+				position688 := position
+				{
+					position689, tokenIndex689 := position, tokenIndex
+					{
+						position691 := position
+						if !_rules[ruleOPEN]() {
+							goto l690
+						}
+					l692:
+						{
+							position693, tokenIndex693 := position, tokenIndex
+							if !_rules[rule_]() {
+								goto l693
+							}
+							{
+								position694 := position
+								if !_rules[ruleKey]() {
+									goto l693
+								}
+								{
+									position695, tokenIndex695 := position, tokenIndex
+									if !_rules[ruleCOLON]() {
+										goto l695
+									}
+									if !_rules[ruleVariable]() {
+										goto l695
+									}
+									goto l696
+								l695:
+									position, tokenIndex = position695, tokenIndex695
+								}
+							l696:
+								{
+									position697, tokenIndex697 := position, tokenIndex
+									if !_rules[ruleCOMMA]() {
+										goto l697
+									}
+									goto l698
+								l697:
+									position, tokenIndex = position697, tokenIndex697
+								}
+							l698:
+								add(ruleObjectTargetField, position694)
+							}
+							if !_rules[rule_]() {
+								goto l693
+							}
+							goto l692
+						l693:
+							position, tokenIndex = position693, tokenIndex693
+						}
+						{
+							position699, tokenIndex699 := position, tokenIndex
+							if !_rules[rule_]() {
+								goto l699
+							}
+							if !_rules[ruleRestVariable]() {
+								goto l699
+							}
+							{
+								position701, tokenIndex701 := position, tokenIndex
+								if !_rules[ruleCOMMA]() {
+									goto l701
+								}
+								goto l702
+							l701:
+								position, tokenIndex = position701, tokenIndex701
+							}
+						l702:
+							if !_rules[rule_]() {
+								goto l699
+							}
+							goto l700
+						l699:
+							position, tokenIndex = position699, tokenIndex699
+						}
+					l700:
+						if !_rules[rule_]() {
+							goto l690
+						}
+						if buffer[position] != rune('}') {
+							goto l690
+						}
+						position++
+						add(ruleObjectTarget, position691)
+					}
+					goto l689
+				l690:
+					position, tokenIndex = position689, tokenIndex689
+					{
+						position703 := position
+					l704:
+						{
+							position705, tokenIndex705 := position, tokenIndex
+							if !_rules[ruleVariable]() {
+								goto l705
+							}
+							if !_rules[ruleCOMMA]() {
+								goto l705
+							}
+							goto l704
+						l705:
+							position, tokenIndex = position705, tokenIndex705
+						}
+						{
+							position706, tokenIndex706 := position, tokenIndex
+							if !_rules[ruleRestVariable]() {
+								goto l707
+							}
+							goto l706
+						l707:
+							position, tokenIndex = position706, tokenIndex706
+							if !_rules[ruleVariable]() {
+								goto l687
+							}
+						}
+					l706:
+						add(ruleArrayTarget, position703)
+					}
+				}
+			l689:
+				add(ruleAssignmentTarget, position688)
+			}
+			return true
+		l687:
+			position, tokenIndex = position687, tokenIndex687
+			return false
+		},
+		/* 142 ArrayTarget <- <((Variable COMMA)* (RestVariable / Variable))> */
+		nil,
+		/* 143 ObjectTarget <- <(OPEN (_ ObjectTargetField _)* (_ RestVariable COMMA? _)? _ '}')> */
+		nil,
+		/* 144 ObjectTargetField <- <(Key (COLON Variable)? COMMA?)> */
+		nil,
+		/* 145 RestVariable <- <('.' '.' '.' Variable)> */
+		func() bool {
+			position711, tokenIndex711 := position, tokenIndex
+			{
+				position712 := position
+				if buffer[position] != rune('.') {
+					goto l711
+				}
+				position++
+				if buffer[position] != rune('.') {
+					goto l711
+				}
+				position++
+				if buffer[position] != rune('.') {
+					goto l711
 				}
+				position++
 				if !_rules[ruleVariable]() {
-					goto l321
+					goto l711
 				}
-				add(ruleVariableSequence, position322)
+				add(ruleRestVariable, position712)
 			}
 			return true
-		l321:
-			position, tokenIndex = position321, tokenIndex321
+		l711:
+			position, tokenIndex = position711, tokenIndex711
 			return false
 		},
-		/* 93 ExpressionSequence <- <((Expression COMMA)* Expression)> */
+		/* 146 ExpressionSequence <- <((Expression COMMA)* Expression)> */
 		func() bool {
-			position325, tokenIndex325 := position, tokenIndex
+			position713, tokenIndex713 := position, tokenIndex
 			{
-				position326 := position
-			l327:
+				position714 := position
+			l715:
 				{
-					position328, tokenIndex328 := position, tokenIndex
+					position716, tokenIndex716 := position, tokenIndex
 					if !_rules[ruleExpression]() {
-						goto l328
+						goto l716
 					}
 					if !_rules[ruleCOMMA]() {
-						goto l328
+						goto l716
 					}
-					goto l327
-				l328:
-					position, tokenIndex = position328, tokenIndex328
+					goto l715
+				l716:
+					position, tokenIndex = position716, tokenIndex716
 				}
 				if !_rules[ruleExpression]() {
-					goto l325
+					goto l713
 				}
-				add(ruleExpressionSequence, position326)
+				add(ruleExpressionSequence, position714)
 			}
 			return true
-		l325:
-			position, tokenIndex = position325, tokenIndex325
+		l713:
+			position, tokenIndex = position713, tokenIndex713
 			return false
 		},
-		/* 94 Expression <- <(_ ExpressionLHS ExpressionRHS? _)> */
+		/* 147 Expression <- <(_ ExpressionTernary _)> */
 		func() bool {
-			position329, tokenIndex329 := position, tokenIndex
+			position717, tokenIndex717 := position, tokenIndex
 			{
-				position330 := position
+				position718 := position
 				if !_rules[rule_]() {
-					goto l329
+					goto l717
 				}
 				{
-					position331 := position
+					position719 := position
+					if !_rules[ruleExpressionCoalesce]() {
+						goto l717
+					}
 					{
-						position332 := position
+						position720, tokenIndex720 := position, tokenIndex
 						{
-							position333, tokenIndex333 := position, tokenIndex
+							position722, tokenIndex722 := position, tokenIndex
 							{
-								position335 := position
-								{
-									position336 := position
-									if !_rules[rule_]() {
-										goto l334
-									}
-									if buffer[position] != rune('(') {
-										goto l334
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l334
-									}
-									add(ruleGROUPOPEN, position336)
+								position724 := position
+								if !_rules[ruleComparisonOperator]() {
+									goto l722
 								}
-								if !_rules[ruleCommand]() {
-									goto l334
-								}
-								{
-									position337 := position
-									if !_rules[rule_]() {
-										goto l334
-									}
-									if buffer[position] != rune(')') {
-										goto l334
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l334
-									}
-									add(ruleGROUPCLOSE, position337)
+								if !_rules[ruleExpressionCoalesce]() {
+									goto l722
 								}
-								add(ruleInlineCommand, position335)
+								add(ruleExpressionTernaryComparison, position724)
+							}
+							goto l723
+						l722:
+							position, tokenIndex = position722, tokenIndex722
+						}
+					l723:
+						{
+							position725 := position
+							if !_rules[rule_]() {
+								goto l720
 							}
-							goto l333
-						l334:
-							position, tokenIndex = position333, tokenIndex333
-							if !_rules[ruleType]() {
-								goto l338
+							if buffer[position] != rune('?') {
+								goto l720
 							}
-							goto l333
-						l338:
-							position, tokenIndex = position333, tokenIndex333
-							if !_rules[ruleVariable]() {
-								goto l329
+							position++
+							if !_rules[rule_]() {
+								goto l720
 							}
+							add(ruleQUESTION, position725)
+						}
+						if !_rules[ruleExpression]() {
+							goto l720
+						}
+						if !_rules[ruleCOLON]() {
+							goto l720
+						}
+						if !_rules[ruleExpression]() {
+							goto l720
+						}
+						goto l721
+					l720:
+						position, tokenIndex = position720, tokenIndex720
+					}
+				l721:
+					add(ruleExpressionTernary, position719)
+				}
+				if !_rules[rule_]() {
+					goto l717
+				}
+				add(ruleExpression, position718)
+			}
+			return true
+		l717:
+			position, tokenIndex = position717, tokenIndex717
+			return false
+		},
+		/* 148 ExpressionTernary <- <(ExpressionCoalesce (ExpressionTernaryComparison? QUESTION Expression COLON Expression)?)> */
+		nil,
+		/* 149 ExpressionTernaryComparison <- <(ComparisonOperator ExpressionCoalesce)> */
+		nil,
+		/* 150 ExpressionCoalesce <- <(ExpressionBitwise (COALESCE ExpressionBitwise)*)> */
+		func() bool {
+			position728, tokenIndex728 := position, tokenIndex
+			{
+				position729 := position
+				if !_rules[ruleExpressionBitwise]() {
+					goto l728
+				}
+			l730:
+				{
+					position731, tokenIndex731 := position, tokenIndex
+					{
+						position732 := position
+						if !_rules[rule_]() {
+							goto l731
+						}
+						if buffer[position] != rune('?') {
+							goto l731
+						}
+						position++
+						if buffer[position] != rune('?') {
+							goto l731
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l731
 						}
-					l333:
-						add(ruleValueYielding, position332)
+						add(ruleCOALESCE, position732)
+					}
+					if !_rules[ruleExpressionBitwise]() {
+						goto l731
+					}
+					goto l730
+				l731:
+					position, tokenIndex = position731, tokenIndex731
+				}
+				add(ruleExpressionCoalesce, position729)
+			}
+			return true
+		l728:
+			position, tokenIndex = position728, tokenIndex728
+			return false
+		},
+		/* 151 ExpressionBitwise <- <(ExpressionAdditive (BitwiseOperator ExpressionAdditive)*)> */
+		func() bool {
+			position733, tokenIndex733 := position, tokenIndex
+			{
+				position734 := position
+				if !_rules[ruleExpressionAdditive]() {
+					goto l733
+				}
+			l735:
+				{
+					position736, tokenIndex736 := position, tokenIndex
+					if !_rules[ruleBitwiseOperator]() {
+						goto l736
+					}
+					if !_rules[ruleExpressionAdditive]() {
+						goto l736
+					}
+					goto l735
+				l736:
+					position, tokenIndex = position736, tokenIndex736
+				}
+				add(ruleExpressionBitwise, position734)
+			}
+			return true
+		l733:
+			position, tokenIndex = position733, tokenIndex733
+			return false
+		},
+		/* 152 ExpressionAdditive <- <(ExpressionMultiplicative (AdditiveOperator ExpressionMultiplicative)*)> */
+		func() bool {
+			position737, tokenIndex737 := position, tokenIndex
+			{
+				position738 := position
+				if !_rules[ruleExpressionMultiplicative]() {
+					goto l737
+				}
+			l739:
+				{
+					position740, tokenIndex740 := position, tokenIndex
+					if !_rules[ruleAdditiveOperator]() {
+						goto l740
+					}
+					if !_rules[ruleExpressionMultiplicative]() {
+						goto l740
+					}
+					goto l739
+				l740:
+					position, tokenIndex = position740, tokenIndex740
+				}
+				add(ruleExpressionAdditive, position738)
+			}
+			return true
+		l737:
+			position, tokenIndex = position737, tokenIndex737
+			return false
+		},
+		/* 153 ExpressionMultiplicative <- <(ExpressionUnary (MultiplicativeOperator ExpressionUnary)*)> */
+		func() bool {
+			position741, tokenIndex741 := position, tokenIndex
+			{
+				position742 := position
+				if !_rules[ruleExpressionUnary]() {
+					goto l741
+				}
+			l743:
+				{
+					position744, tokenIndex744 := position, tokenIndex
+					if !_rules[ruleMultiplicativeOperator]() {
+						goto l744
+					}
+					if !_rules[ruleExpressionUnary]() {
+						goto l744
 					}
-					add(ruleExpressionLHS, position331)
+					goto l743
+				l744:
+					position, tokenIndex = position744, tokenIndex744
 				}
+				add(ruleExpressionMultiplicative, position742)
+			}
+			return true
+		l741:
+			position, tokenIndex = position741, tokenIndex741
+			return false
+		},
+		/* 154 ExpressionUnary <- <((UnaryOperator ExpressionUnary) / ExpressionExponent)> */
+		func() bool {
+			position745, tokenIndex745 := position, tokenIndex
+			{
+				position746 := position
 				{
-					position339, tokenIndex339 := position, tokenIndex
+					position747, tokenIndex747 := position, tokenIndex
 					{
-						position341 := position
+						position749 := position
+						if !_rules[rule_]() {
+							goto l748
+						}
 						{
-							position342 := position
-							if !_rules[rule_]() {
-								goto l339
-							}
+							position750, tokenIndex750 := position, tokenIndex
 							{
-								position343, tokenIndex343 := position, tokenIndex
-								{
-									position345 := position
-									if !_rules[rule_]() {
-										goto l344
-									}
-									if buffer[position] != rune('*') {
-										goto l344
-									}
-									position++
-									if buffer[position] != rune('*') {
-										goto l344
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l344
-									}
-									add(ruleExponentiate, position345)
+								position752 := position
+								if !_rules[rule_]() {
+									goto l751
 								}
-								goto l343
-							l344:
-								position, tokenIndex = position343, tokenIndex343
-								{
-									position347 := position
-									if !_rules[rule_]() {
-										goto l346
-									}
-									if buffer[position] != rune('*') {
-										goto l346
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l346
-									}
-									add(ruleMultiply, position347)
+								if buffer[position] != rune('-') {
+									goto l751
 								}
-								goto l343
-							l346:
-								position, tokenIndex = position343, tokenIndex343
-								{
-									position349 := position
-									if !_rules[rule_]() {
-										goto l348
-									}
-									if buffer[position] != rune('/') {
-										goto l348
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l348
-									}
-									add(ruleDivide, position349)
+								position++
+								if !_rules[rule_]() {
+									goto l751
 								}
-								goto l343
-							l348:
-								position, tokenIndex = position343, tokenIndex343
-								{
-									position351 := position
-									if !_rules[rule_]() {
-										goto l350
-									}
-									if buffer[position] != rune('%') {
-										goto l350
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l350
-									}
-									add(ruleModulus, position351)
+								add(ruleNegate, position752)
+							}
+							goto l750
+						l751:
+							position, tokenIndex = position750, tokenIndex750
+							{
+								position754 := position
+								if !_rules[rule_]() {
+									goto l753
 								}
-								goto l343
-							l350:
-								position, tokenIndex = position343, tokenIndex343
-								{
-									position353 := position
-									if !_rules[rule_]() {
-										goto l352
-									}
-									if buffer[position] != rune('+') {
-										goto l352
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l352
-									}
-									add(ruleAdd, position353)
+								if buffer[position] != rune('~') {
+									goto l753
 								}
-								goto l343
-							l352:
-								position, tokenIndex = position343, tokenIndex343
-								{
-									position355 := position
-									if !_rules[rule_]() {
-										goto l354
-									}
-									if buffer[position] != rune('-') {
-										goto l354
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l354
-									}
-									add(ruleSubtract, position355)
+								position++
+								if !_rules[rule_]() {
+									goto l753
+								}
+								add(ruleBitwiseNot, position754)
+							}
+							goto l750
+						l753:
+							position, tokenIndex = position750, tokenIndex750
+							{
+								position755 := position
+								if !_rules[rule_]() {
+									goto l748
 								}
-								goto l343
-							l354:
-								position, tokenIndex = position343, tokenIndex343
 								{
-									position357 := position
-									if !_rules[rule_]() {
-										goto l356
+									position756, tokenIndex756 := position, tokenIndex
+									if buffer[position] != rune('n') {
+										goto l757
 									}
-									if buffer[position] != rune('&') {
-										goto l356
+									position++
+									if buffer[position] != rune('o') {
+										goto l757
 									}
 									position++
-									if !_rules[rule_]() {
-										goto l356
+									if buffer[position] != rune('t') {
+										goto l757
 									}
-									add(ruleBitwiseAnd, position357)
-								}
-								goto l343
-							l356:
-								position, tokenIndex = position343, tokenIndex343
-								{
-									position359 := position
-									if !_rules[rule_]() {
-										goto l358
+									position++
+									if !_rules[rule__]() {
+										goto l757
 									}
-									if buffer[position] != rune('|') {
-										goto l358
+									goto l756
+								l757:
+									position, tokenIndex = position756, tokenIndex756
+									if buffer[position] != rune('!') {
+										goto l748
 									}
 									position++
-									if !_rules[rule_]() {
-										goto l358
+									{
+										position758, tokenIndex758 := position, tokenIndex
+										{
+											position759, tokenIndex759 := position, tokenIndex
+											if buffer[position] != rune('=') {
+												goto l760
+											}
+											position++
+											goto l759
+										l760:
+											position, tokenIndex = position759, tokenIndex759
+											if buffer[position] != rune('~') {
+												goto l758
+											}
+											position++
+										}
+									l759:
+										goto l748
+									l758:
+										position, tokenIndex = position758, tokenIndex758
 									}
-									add(ruleBitwiseOr, position359)
 								}
-								goto l343
-							l358:
-								position, tokenIndex = position343, tokenIndex343
+							l756:
+								if !_rules[rule_]() {
+									goto l748
+								}
+								add(ruleLogicalNot, position755)
+							}
+						}
+					l750:
+						if !_rules[rule_]() {
+							goto l748
+						}
+						add(ruleUnaryOperator, position749)
+					}
+					if !_rules[ruleExpressionUnary]() {
+						goto l748
+					}
+					goto l747
+				l748:
+					position, tokenIndex = position747, tokenIndex747
+					{
+						position761 := position
+						{
+							position762 := position
+							{
+								position763, tokenIndex763 := position, tokenIndex
 								{
-									position361 := position
-									if !_rules[rule_]() {
-										goto l360
+									position765 := position
+									if !_rules[ruleGROUPOPEN]() {
+										goto l764
 									}
-									if buffer[position] != rune('~') {
-										goto l360
+									if !_rules[ruleExpression]() {
+										goto l764
 									}
-									position++
-									if !_rules[rule_]() {
-										goto l360
+									if !_rules[ruleGROUPCLOSE]() {
+										goto l764
 									}
-									add(ruleBitwiseNot, position361)
+									add(ruleExpressionGroup, position765)
 								}
-								goto l343
-							l360:
-								position, tokenIndex = position343, tokenIndex343
+								goto l763
+							l764:
+								position, tokenIndex = position763, tokenIndex763
 								{
-									position362 := position
-									if !_rules[rule_]() {
-										goto l339
-									}
-									if buffer[position] != rune('^') {
-										goto l339
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l339
+									position766 := position
+									{
+										position767, tokenIndex767 := position, tokenIndex
+										{
+											position769 := position
+											if !_rules[ruleGROUPOPEN]() {
+												goto l768
+											}
+											if !_rules[ruleCommand]() {
+												goto l768
+											}
+											if !_rules[ruleGROUPCLOSE]() {
+												goto l768
+											}
+											add(ruleInlineCommand, position769)
+										}
+										goto l767
+									l768:
+										position, tokenIndex = position767, tokenIndex767
+										if !_rules[ruleType]() {
+											goto l770
+										}
+										goto l767
+									l770:
+										position, tokenIndex = position767, tokenIndex767
+										if !_rules[ruleVariable]() {
+											goto l745
+										}
 									}
-									add(ruleBitwiseXor, position362)
+								l767:
+									add(ruleValueYielding, position766)
 								}
 							}
-						l343:
-							if !_rules[rule_]() {
-								goto l339
-							}
-							add(ruleOperator, position342)
+						l763:
+							add(ruleExpressionOperand, position762)
 						}
-						if !_rules[ruleExpression]() {
-							goto l339
+						{
+							position771, tokenIndex771 := position, tokenIndex
+							if !_rules[ruleExponentOperator]() {
+								goto l771
+							}
+							if !_rules[ruleExpressionUnary]() {
+								goto l771
+							}
+							goto l772
+						l771:
+							position, tokenIndex = position771, tokenIndex771
 						}
-						add(ruleExpressionRHS, position341)
+					l772:
+						add(ruleExpressionExponent, position761)
 					}
-					goto l340
-				l339:
-					position, tokenIndex = position339, tokenIndex339
-				}
-			l340:
-				if !_rules[rule_]() {
-					goto l329
 				}
-				add(ruleExpression, position330)
+			l747:
+				add(ruleExpressionUnary, position746)
 			}
 			return true
-		l329:
-			position, tokenIndex = position329, tokenIndex329
+		l745:
+			position, tokenIndex = position745, tokenIndex745
 			return false
 		},
-		/* 95 ExpressionLHS <- <ValueYielding> */
+		/* 155 ExpressionExponent <- <(ExpressionOperand (ExponentOperator ExpressionUnary)?)> */
+		nil,
+		/* 156 ExpressionOperand <- <(ExpressionGroup / ValueYielding)> */
 		nil,
-		/* 96 ExpressionRHS <- <(Operator Expression)> */
+		/* 157 ExpressionGroup <- <(GROUPOPEN Expression GROUPCLOSE)> */
 		nil,
-		/* 97 InlineCommand <- <(GROUPOPEN Command GROUPCLOSE)> */
+		/* 158 InlineCommand <- <(GROUPOPEN Command GROUPCLOSE)> */
 		nil,
-		/* 98 ValueYielding <- <(InlineCommand / Type / Variable)> */
+		/* 159 ValueYielding <- <(InlineCommand / Type / Variable)> */
 		nil,
-		/* 99 Directive <- <(DirectiveUnset / DirectiveInclude / DirectiveDeclare)> */
+		/* 160 Directive <- <(DirectiveUnset / DirectiveInclude / DirectiveDeclare)> */
 		nil,
-		/* 100 DirectiveUnset <- <(UNSET VariableSequence)> */
+		/* 161 DirectiveUnset <- <(UNSET VariableSequence)> */
 		nil,
-		/* 101 DirectiveInclude <- <(INCLUDE String)> */
+		/* 162 DirectiveInclude <- <(INCLUDE String)> */
 		nil,
-		/* 102 DirectiveDeclare <- <(DECLARE VariableSequence)> */
+		/* 163 DirectiveDeclare <- <(DECLARE VariableSequence)> */
 		nil,
-		/* 103 Command <- <(_ CommandName (__ ((CommandFirstArg __ CommandSecondArg) / CommandFirstArg / CommandSecondArg))? (_ CommandResultAssignment)?)> */
+		/* 164 FunctionDefinition <- <(DEF Identifier GROUPOPEN FunctionParameters? GROUPCLOSE OPEN Block* CLOSE)> */
+		nil,
+		/* 165 FunctionParameters <- <((FunctionArgument COMMA FunctionOptions) / FunctionArgument / FunctionOptions)> */
+		nil,
+		/* 166 FunctionArgument <- <Variable> */
 		func() bool {
-			position371, tokenIndex371 := position, tokenIndex
+			position784, tokenIndex784 := position, tokenIndex
 			{
-				position372 := position
+				position785 := position
+				if !_rules[ruleVariable]() {
+					goto l784
+				}
+				add(ruleFunctionArgument, position785)
+			}
+			return true
+		l784:
+			position, tokenIndex = position784, tokenIndex784
+			return false
+		},
+		/* 167 FunctionOptions <- <Object> */
+		func() bool {
+			position786, tokenIndex786 := position, tokenIndex
+			{
+				position787 := position
+				if !_rules[ruleObject]() {
+					goto l786
+				}
+				add(ruleFunctionOptions, position787)
+			}
+			return true
+		l786:
+			position, tokenIndex = position786, tokenIndex786
+			return false
+		},
+		/* 168 Command <- <(_ CommandName (__ ((CommandFirstArg __ CommandSecondArg) / CommandFirstArg / CommandSecondArg))? (_ CommandResultAssignment)?)> */
+		func() bool {
+			position788, tokenIndex788 := position, tokenIndex
+			{
+				position789 := position
 				if !_rules[rule_]() {
-					goto l371
+					goto l788
 				}
 				{
-					position373 := position
+					position790 := position
 					{
-						position374, tokenIndex374 := position, tokenIndex
+						position791, tokenIndex791 := position, tokenIndex
 						if !_rules[ruleIdentifier]() {
-							goto l374
+							goto l791
 						}
 						{
-							position376 := position
+							position793 := position
 							if buffer[position] != rune(':') {
-								goto l374
+								goto l791
 							}
 							position++
 							if buffer[position] != rune(':') {
-								goto l374
+								goto l791
 							}
 							position++
-							add(ruleSCOPE, position376)
+							add(ruleSCOPE, position793)
 						}
-						goto l375
-					l374:
-						position, tokenIndex = position374, tokenIndex374
+						goto l792
+					l791:
+						position, tokenIndex = position791, tokenIndex791
 					}
-				l375:
+				l792:
 					if !_rules[ruleIdentifier]() {
-						goto l371
+						goto l788
 					}
-					add(ruleCommandName, position373)
+					add(ruleCommandName, position790)
 				}
 				{
-					position377, tokenIndex377 := position, tokenIndex
+					position794, tokenIndex794 := position, tokenIndex
 					if !_rules[rule__]() {
-						goto l377
+						goto l794
 					}
 					{
-						position379, tokenIndex379 := position, tokenIndex
+						position796, tokenIndex796 := position, tokenIndex
 						if !_rules[ruleCommandFirstArg]() {
-							goto l380
+							goto l797
 						}
 						if !_rules[rule__]() {
-							goto l380
+							goto l797
 						}
 						if !_rules[ruleCommandSecondArg]() {
-							goto l380
+							goto l797
 						}
-						goto l379
-					l380:
-						position, tokenIndex = position379, tokenIndex379
+						goto l796
+					l797:
+						position, tokenIndex = position796, tokenIndex796
 						if !_rules[ruleCommandFirstArg]() {
-							goto l381
+							goto l798
 						}
-						goto l379
-					l381:
-						position, tokenIndex = position379, tokenIndex379
+						goto l796
+					l798:
+						position, tokenIndex = position796, tokenIndex796
 						if !_rules[ruleCommandSecondArg]() {
-							goto l377
+							goto l794
 						}
 					}
-				l379:
-					goto l378
-				l377:
-					position, tokenIndex = position377, tokenIndex377
+				l796:
+					goto l795
+				l794:
+					position, tokenIndex = position794, tokenIndex794
 				}
-			l378:
+			l795:
 				{
-					position382, tokenIndex382 := position, tokenIndex
+					position799, tokenIndex799 := position, tokenIndex
 					if !_rules[rule_]() {
-						goto l382
+						goto l799
 					}
 					{
-						position384 := position
-						{
-							position385 := position
-							if !_rules[rule_]() {
-								goto l382
-							}
-							if buffer[position] != rune('-') {
-								goto l382
-							}
-							position++
-							if buffer[position] != rune('>') {
-								goto l382
-							}
-							position++
-							if !_rules[rule_]() {
-								goto l382
-							}
-							add(ruleASSIGN, position385)
+						position801 := position
+						if !_rules[ruleASSIGN]() {
+							goto l799
 						}
-						if !_rules[ruleVariable]() {
-							goto l382
+						if !_rules[ruleAssignmentTarget]() {
+							goto l799
 						}
-						add(ruleCommandResultAssignment, position384)
+						add(ruleCommandResultAssignment, position801)
 					}
-					goto l383
-				l382:
-					position, tokenIndex = position382, tokenIndex382
+					goto l800
+				l799:
+					position, tokenIndex = position799, tokenIndex799
 				}
-			l383:
-				add(ruleCommand, position372)
+			l800:
+				add(ruleCommand, position789)
 			}
 			return true
-		l371:
-			position, tokenIndex = position371, tokenIndex371
+		l788:
+			position, tokenIndex = position788, tokenIndex788
 			return false
 		},
-		/* 104 CommandName <- <((Identifier SCOPE)? Identifier)> */
+		/* 169 CommandName <- <((Identifier SCOPE)? Identifier)> */
 		nil,
-		/* 105 CommandFirstArg <- <(Variable / Type)> */
+		/* 170 CommandFirstArg <- <(Variable / Type)> */
 		func() bool {
-			position387, tokenIndex387 := position, tokenIndex
+			position803, tokenIndex803 := position, tokenIndex
 			{
-				position388 := position
+				position804 := position
 				{
-					position389, tokenIndex389 := position, tokenIndex
+					position805, tokenIndex805 := position, tokenIndex
 					if !_rules[ruleVariable]() {
-						goto l390
+						goto l806
 					}
-					goto l389
-				l390:
-					position, tokenIndex = position389, tokenIndex389
+					goto l805
+				l806:
+					position, tokenIndex = position805, tokenIndex805
 					if !_rules[ruleType]() {
-						goto l387
+						goto l803
 					}
 				}
-			l389:
-				add(ruleCommandFirstArg, position388)
+			l805:
+				add(ruleCommandFirstArg, position804)
 			}
 			return true
-		l387:
-			position, tokenIndex = position387, tokenIndex387
+		l803:
+			position, tokenIndex = position803, tokenIndex803
 			return false
 		},
-		/* 106 CommandSecondArg <- <Object> */
+		/* 171 CommandSecondArg <- <Object> */
 		func() bool {
-			position391, tokenIndex391 := position, tokenIndex
+			position807, tokenIndex807 := position, tokenIndex
 			{
-				position392 := position
+				position808 := position
 				if !_rules[ruleObject]() {
-					goto l391
+					goto l807
+				}
+				add(ruleCommandSecondArg, position808)
+			}
+			return true
+		l807:
+			position, tokenIndex = position807, tokenIndex807
+			return false
+		},
+		/* 172 CommandResultAssignment <- <(ASSIGN AssignmentTarget)> */
+		nil,
+		/* 173 Conditional <- <(IfStanza ElseIfStanza* ElseStanza?)> */
+		nil,
+		/* 174 IfStanza <- <(IF ConditionalExpression OPEN Block* CLOSE)> */
+		func() bool {
+			position811, tokenIndex811 := position, tokenIndex
+			{
+				position812 := position
+				{
+					position813 := position
+					if !_rules[rule_]() {
+						goto l811
+					}
+					if buffer[position] != rune('i') {
+						goto l811
+					}
+					position++
+					if buffer[position] != rune('f') {
+						goto l811
+					}
+					position++
+					if !_rules[rule_]() {
+						goto l811
+					}
+					add(ruleIF, position813)
+				}
+				if !_rules[ruleConditionalExpression]() {
+					goto l811
+				}
+				if !_rules[ruleOPEN]() {
+					goto l811
+				}
+			l814:
+				{
+					position815, tokenIndex815 := position, tokenIndex
+					if !_rules[ruleBlock]() {
+						goto l815
+					}
+					goto l814
+				l815:
+					position, tokenIndex = position815, tokenIndex815
+				}
+				if !_rules[ruleCLOSE]() {
+					goto l811
+				}
+				add(ruleIfStanza, position812)
+			}
+			return true
+		l811:
+			position, tokenIndex = position811, tokenIndex811
+			return false
+		},
+		/* 175 ElseIfStanza <- <(ELSE IfStanza)> */
+		nil,
+		/* 176 ElseStanza <- <(ELSE OPEN Block* CLOSE)> */
+		nil,
+		/* 177 ParallelStatement <- <(PARALLEL OPEN Block* CLOSE)> */
+		nil,
+		/* 178 MatchStatement <- <(MATCH Expression OPEN MatchArm* CLOSE)> */
+		nil,
+		/* 179 MatchArm <- <(_ MatchPattern (COMMA MatchPattern)* ASSIGN OPEN Block* CLOSE)> */
+		nil,
+		/* 180 MatchPattern <- <(MatchWildcard / MatchType / Range / RegularExpression / Expression)> */
+		func() bool {
+			position821, tokenIndex821 := position, tokenIndex
+			{
+				position822 := position
+				{
+					position823, tokenIndex823 := position, tokenIndex
+					{
+						position825 := position
+						if buffer[position] != rune('_') {
+							goto l824
+						}
+						position++
+						{
+							position826, tokenIndex826 := position, tokenIndex
+							{
+								position827, tokenIndex827 := position, tokenIndex
+								if c := buffer[position]; c < rune('a') || c > rune('z') {
+									goto l828
+								}
+								position++
+								goto l827
+							l828:
+								position, tokenIndex = position827, tokenIndex827
+								if c := buffer[position]; c < rune('A') || c > rune('Z') {
+									goto l829
+								}
+								position++
+								goto l827
+							l829:
+								position, tokenIndex = position827, tokenIndex827
+								{
+									position831, tokenIndex831 := position, tokenIndex
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l832
+									}
+									position++
+									goto l831
+								l832:
+									position, tokenIndex = position831, tokenIndex831
+									if c := buffer[position]; c < rune('0') || c > rune('9') {
+										goto l830
+									}
+									position++
+								}
+							l831:
+								goto l827
+							l830:
+								position, tokenIndex = position827, tokenIndex827
+								if buffer[position] != rune('_') {
+									goto l826
+								}
+								position++
+							}
+						l827:
+							goto l824
+						l826:
+							position, tokenIndex = position826, tokenIndex826
+						}
+						add(ruleMatchWildcard, position825)
+					}
+					goto l823
+				l824:
+					position, tokenIndex = position823, tokenIndex823
+					{
+						position834 := position
+						{
+							position835 := position
+							if !_rules[rule_]() {
+								goto l833
+							}
+							if buffer[position] != rune('i') {
+								goto l833
+							}
+							position++
+							if buffer[position] != rune('s') {
+								goto l833
+							}
+							position++
+							if !_rules[rule__]() {
+								goto l833
+							}
+							add(ruleIS, position835)
+						}
+						if !_rules[ruleIdentifier]() {
+							goto l833
+						}
+						add(ruleMatchType, position834)
+					}
+					goto l823
+				l833:
+					position, tokenIndex = position823, tokenIndex823
+					if !_rules[ruleRange]() {
+						goto l836
+					}
+					goto l823
+				l836:
+					position, tokenIndex = position823, tokenIndex823
+					if !_rules[ruleRegularExpression]() {
+						goto l837
+					}
+					goto l823
+				l837:
+					position, tokenIndex = position823, tokenIndex823
+					if !_rules[ruleExpression]() {
+						goto l821
+					}
+				}
+			l823:
+				add(ruleMatchPattern, position822)
+			}
+			return true
+		l821:
+			position, tokenIndex = position821, tokenIndex821
+			return false
+		},
+		/* 181 MatchWildcard <- <('_' !([a-z] / [A-Z] / ([0-9] / [0-9]) / '_'))> */
+		nil,
+		/* 182 MatchType <- <(IS Identifier)> */
+		nil,
+		/* 183 Range <- <(RangeStart (RANGEEXCL / RANGE) RangeEnd (STEP RangeStep)?)> */
+		func() bool {
+			position840, tokenIndex840 := position, tokenIndex
+			{
+				position841 := position
+				{
+					position842 := position
+					if !_rules[ruleExpression]() {
+						goto l840
+					}
+					add(ruleRangeStart, position842)
+				}
+				{
+					position843, tokenIndex843 := position, tokenIndex
+					{
+						position845 := position
+						if !_rules[rule_]() {
+							goto l844
+						}
+						if buffer[position] != rune('.') {
+							goto l844
+						}
+						position++
+						if buffer[position] != rune('.') {
+							goto l844
+						}
+						position++
+						if buffer[position] != rune('<') {
+							goto l844
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l844
+						}
+						add(ruleRANGEEXCL, position845)
+					}
+					goto l843
+				l844:
+					position, tokenIndex = position843, tokenIndex843
+					{
+						position846 := position
+						if !_rules[rule_]() {
+							goto l840
+						}
+						if buffer[position] != rune('.') {
+							goto l840
+						}
+						position++
+						if buffer[position] != rune('.') {
+							goto l840
+						}
+						position++
+						if !_rules[rule_]() {
+							goto l840
+						}
+						add(ruleRANGE, position846)
+					}
+				}
+			l843:
+				{
+					position847 := position
+					if !_rules[ruleExpression]() {
+						goto l840
+					}
+					add(ruleRangeEnd, position847)
+				}
+				{
+					position848, tokenIndex848 := position, tokenIndex
+					{
+						position850 := position
+						if !_rules[rule_]() {
+							goto l848
+						}
+						if buffer[position] != rune('s') {
+							goto l848
+						}
+						position++
+						if buffer[position] != rune('t') {
+							goto l848
+						}
+						position++
+						if buffer[position] != rune('e') {
+							goto l848
+						}
+						position++
+						if buffer[position] != rune('p') {
+							goto l848
+						}
+						position++
+						if !_rules[rule__]() {
+							goto l848
+						}
+						add(ruleSTEP, position850)
+					}
+					{
+						position851 := position
+						if !_rules[ruleExpression]() {
+							goto l848
+						}
+						add(ruleRangeStep, position851)
+					}
+					goto l849
+				l848:
+					position, tokenIndex = position848, tokenIndex848
 				}
-				add(ruleCommandSecondArg, position392)
+			l849:
+				add(ruleRange, position841)
 			}
 			return true
-		l391:
-			position, tokenIndex = position391, tokenIndex391
+		l840:
+			position, tokenIndex = position840, tokenIndex840
 			return false
 		},
-		/* 107 CommandResultAssignment <- <(ASSIGN Variable)> */
+		/* 184 RangeStart <- <Expression> */
+		nil,
+		/* 185 RangeEnd <- <Expression> */
+		nil,
+		/* 186 RangeStep <- <Expression> */
+		nil,
+		/* 187 TimeoutStatement <- <(TIMEOUT Expression OPEN Block* CLOSE)> */
+		nil,
+		/* 188 RetryStatement <- <(RETRY RetryAttempts RetryBackoff? RetryJitter? RetryUntil? OPEN Block* CLOSE)> */
+		nil,
+		/* 189 RetryAttempts <- <(PositiveInteger / Variable)> */
+		nil,
+		/* 190 RetryBackoff <- <(BACKOFF RetryBackoffType? (Range / Expression))> */
+		nil,
+		/* 191 RetryBackoffType <- <((('e' 'x' 'p' 'o' 'n' 'e' 'n' 't' 'i' 'a' 'l') / ('l' 'i' 'n' 'e' 'a' 'r') / ('c' 'o' 'n' 's' 't' 'a' 'n' 't')) __)> */
+		nil,
+		/* 192 RetryJitter <- <(JITTER (Float / Variable)?)> */
+		nil,
+		/* 193 RetryUntil <- <(UNTIL ConditionalExpression)> */
+		nil,
+		/* 194 DeferStatement <- <(DEFER ((OPEN Block* CLOSE) / Command))> */
+		nil,
+		/* 195 TransactionStatement <- <(TransactionStanza RollbackStanza?)> */
+		nil,
+		/* 196 TransactionStanza <- <(TRANSACTION OPEN Block* CLOSE)> */
+		nil,
+		/* 197 RollbackStanza <- <(ROLLBACK Variable? OPEN Block* CLOSE)> */
+		nil,
+		/* 198 UndoableCommand <- <(Command UNDO ((OPEN Block* CLOSE) / Command))> */
+		nil,
+		/* 199 TryCatch <- <(TryStanza ((CatchStanza FinallyStanza?) / FinallyStanza))> */
 		nil,
-		/* 108 Conditional <- <(IfStanza ElseIfStanza* ElseStanza?)> */
+		/* 200 TryStanza <- <(TRY OPEN Block* CLOSE)> */
 		nil,
-		/* 109 IfStanza <- <(IF ConditionalExpression OPEN Block* CLOSE)> */
+		/* 201 CatchStanza <- <(CATCH Variable? OPEN Block* CLOSE)> */
+		nil,
+		/* 202 FinallyStanza <- <(FINALLY OPEN Block* CLOSE)> */
 		func() bool {
-			position395, tokenIndex395 := position, tokenIndex
+			position870, tokenIndex870 := position, tokenIndex
 			{
-				position396 := position
+				position871 := position
 				{
-					position397 := position
+					position872 := position
 					if !_rules[rule_]() {
-						goto l395
+						goto l870
+					}
+					if buffer[position] != rune('f') {
+						goto l870
 					}
+					position++
 					if buffer[position] != rune('i') {
-						goto l395
+						goto l870
 					}
 					position++
-					if buffer[position] != rune('f') {
-						goto l395
+					if buffer[position] != rune('n') {
+						goto l870
+					}
+					position++
+					if buffer[position] != rune('a') {
+						goto l870
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l870
+					}
+					position++
+					if buffer[position] != rune('l') {
+						goto l870
+					}
+					position++
+					if buffer[position] != rune('y') {
+						goto l870
 					}
 					position++
 					if !_rules[rule_]() {
-						goto l395
+						goto l870
 					}
-					add(ruleIF, position397)
-				}
-				if !_rules[ruleConditionalExpression]() {
-					goto l395
+					add(ruleFINALLY, position872)
 				}
 				if !_rules[ruleOPEN]() {
-					goto l395
+					goto l870
 				}
-			l398:
+			l873:
 				{
-					position399, tokenIndex399 := position, tokenIndex
+					position874, tokenIndex874 := position, tokenIndex
 					if !_rules[ruleBlock]() {
-						goto l399
+						goto l874
 					}
-					goto l398
-				l399:
-					position, tokenIndex = position399, tokenIndex399
+					goto l873
+				l874:
+					position, tokenIndex = position874, tokenIndex874
 				}
 				if !_rules[ruleCLOSE]() {
-					goto l395
+					goto l870
 				}
-				add(ruleIfStanza, position396)
+				add(ruleFinallyStanza, position871)
 			}
 			return true
-		l395:
-			position, tokenIndex = position395, tokenIndex395
+		l870:
+			position, tokenIndex = position870, tokenIndex870
 			return false
 		},
-		/* 110 ElseIfStanza <- <(ELSE IfStanza)> */
+		/* 203 Loop <- <(LOOP LoopLabel? ((OPEN Block* CLOSE) / (LoopConditionFixedLength OPEN Block* CLOSE) / (LoopParallel LoopConditionIterable LoopParallelResults? OPEN Block* CLOSE) / (LoopConditionIterable OPEN Block* CLOSE) / (LoopConditionBounded OPEN Block* CLOSE) / (LoopConditionTruthy OPEN Block* CLOSE)))> */
+		nil,
+		/* 204 LoopLabel <- <(':' Identifier _)> */
 		nil,
-		/* 111 ElseStanza <- <(ELSE OPEN Block* CLOSE)> */
+		/* 205 LoopParallel <- <(PARALLEL (LoopParallelLimit !IN __)?)> */
 		nil,
-		/* 112 Loop <- <(LOOP ((OPEN Block* CLOSE) / (LoopConditionFixedLength OPEN Block* CLOSE) / (LoopConditionIterable OPEN Block* CLOSE) / (LoopConditionBounded OPEN Block* CLOSE) / (LoopConditionTruthy OPEN Block* CLOSE)))> */
+		/* 206 LoopParallelLimit <- <(PositiveInteger / Variable)> */
 		nil,
-		/* 113 LoopConditionFixedLength <- <(COUNT (Integer / Variable))> */
+		/* 207 LoopParallelResults <- <(INTO Variable (COMMA Variable)?)> */
 		nil,
-		/* 114 LoopConditionIterable <- <(LoopIterableLHS IN LoopIterableRHS)> */
+		/* 208 LoopConditionFixedLength <- <(COUNT (Integer / Variable))> */
 		nil,
-		/* 115 LoopIterableLHS <- <VariableSequence> */
+		/* 209 LoopConditionIterable <- <(LoopIterableLHS IN LoopIterableRHS)> */
+		func() bool {
+			position881, tokenIndex881 := position, tokenIndex
+			{
+				position882 := position
+				{
+					position883 := position
+					if !_rules[ruleAssignmentTarget]() {
+						goto l881
+					}
+					add(ruleLoopIterableLHS, position883)
+				}
+				if !_rules[ruleIN]() {
+					goto l881
+				}
+				{
+					position884 := position
+					{
+						position885, tokenIndex885 := position, tokenIndex
+						if !_rules[ruleRange]() {
+							goto l886
+						}
+						goto l885
+					l886:
+						position, tokenIndex = position885, tokenIndex885
+						if !_rules[ruleCommand]() {
+							goto l887
+						}
+						goto l885
+					l887:
+						position, tokenIndex = position885, tokenIndex885
+						if !_rules[ruleVariable]() {
+							goto l881
+						}
+					}
+				l885:
+					add(ruleLoopIterableRHS, position884)
+				}
+				add(ruleLoopConditionIterable, position882)
+			}
+			return true
+		l881:
+			position, tokenIndex = position881, tokenIndex881
+			return false
+		},
+		/* 210 LoopIterableLHS <- <AssignmentTarget> */
 		nil,
-		/* 116 LoopIterableRHS <- <(Command / Variable)> */
+		/* 211 LoopIterableRHS <- <(Range / Command / Variable)> */
 		nil,
-		/* 117 LoopConditionBounded <- <(Command SEMI ConditionalExpression SEMI Command)> */
+		/* 212 LoopConditionBounded <- <(Command SEMI ConditionalExpression SEMI Command)> */
 		nil,
-		/* 118 LoopConditionTruthy <- <ConditionalExpression> */
+		/* 213 LoopConditionTruthy <- <ConditionalExpression> */
 		nil,
-		/* 119 ConditionalExpression <- <(NOT? (ConditionWithAssignment / ConditionWithCommand / ConditionWithRegex / ConditionWithComparator))> */
+		/* 214 ConditionalExpression <- <((NOT? (ConditionWithAssignment / ConditionWithCommand)) / ConditionDisjunction)> */
 		func() bool {
-			position409, tokenIndex409 := position, tokenIndex
+			position892, tokenIndex892 := position, tokenIndex
 			{
-				position410 := position
+				position893 := position
 				{
-					position411, tokenIndex411 := position, tokenIndex
+					position894, tokenIndex894 := position, tokenIndex
 					{
-						position413 := position
-						if !_rules[rule_]() {
-							goto l411
+						position896, tokenIndex896 := position, tokenIndex
+						if !_rules[ruleNOT]() {
+							goto l896
 						}
-						if buffer[position] != rune('n') {
-							goto l411
+						goto l897
+					l896:
+						position, tokenIndex = position896, tokenIndex896
+					}
+				l897:
+					{
+						position898, tokenIndex898 := position, tokenIndex
+						{
+							position900 := position
+							if !_rules[ruleAssignment]() {
+								goto l899
+							}
+							if !_rules[ruleSEMI]() {
+								goto l899
+							}
+							if !_rules[ruleConditionalExpression]() {
+								goto l899
+							}
+							add(ruleConditionWithAssignment, position900)
+						}
+						goto l898
+					l899:
+						position, tokenIndex = position898, tokenIndex898
+						{
+							position901 := position
+							if !_rules[ruleCommand]() {
+								goto l895
+							}
+							{
+								position902, tokenIndex902 := position, tokenIndex
+								if !_rules[ruleSEMI]() {
+									goto l902
+								}
+								if !_rules[ruleConditionalExpression]() {
+									goto l902
+								}
+								goto l903
+							l902:
+								position, tokenIndex = position902, tokenIndex902
+							}
+						l903:
+							add(ruleConditionWithCommand, position901)
+						}
+					}
+				l898:
+					goto l894
+				l895:
+					position, tokenIndex = position894, tokenIndex894
+					if !_rules[ruleConditionDisjunction]() {
+						goto l892
+					}
+				}
+			l894:
+				add(ruleConditionalExpression, position893)
+			}
+			return true
+		l892:
+			position, tokenIndex = position892, tokenIndex892
+			return false
+		},
+		/* 215 ConditionDisjunction <- <(ConditionConjunction (OR ConditionConjunction)*)> */
+		func() bool {
+			position904, tokenIndex904 := position, tokenIndex
+			{
+				position905 := position
+				if !_rules[ruleConditionConjunction]() {
+					goto l904
+				}
+			l906:
+				{
+					position907, tokenIndex907 := position, tokenIndex
+					{
+						position908 := position
+						if !_rules[rule_]() {
+							goto l907
 						}
-						position++
 						if buffer[position] != rune('o') {
-							goto l411
+							goto l907
 						}
 						position++
-						if buffer[position] != rune('t') {
-							goto l411
+						if buffer[position] != rune('r') {
+							goto l907
 						}
 						position++
 						if !_rules[rule__]() {
-							goto l411
+							goto l907
 						}
-						add(ruleNOT, position413)
+						add(ruleOR, position908)
+					}
+					if !_rules[ruleConditionConjunction]() {
+						goto l907
 					}
-					goto l412
-				l411:
-					position, tokenIndex = position411, tokenIndex411
+					goto l906
+				l907:
+					position, tokenIndex = position907, tokenIndex907
 				}
-			l412:
+				add(ruleConditionDisjunction, position905)
+			}
+			return true
+		l904:
+			position, tokenIndex = position904, tokenIndex904
+			return false
+		},
+		/* 216 ConditionConjunction <- <(ConditionTerm (AND ConditionTerm)*)> */
+		func() bool {
+			position909, tokenIndex909 := position, tokenIndex
+			{
+				position910 := position
+				if !_rules[ruleConditionTerm]() {
+					goto l909
+				}
+			l911:
 				{
-					position414, tokenIndex414 := position, tokenIndex
+					position912, tokenIndex912 := position, tokenIndex
 					{
-						position416 := position
-						if !_rules[ruleAssignment]() {
-							goto l415
+						position913 := position
+						if !_rules[rule_]() {
+							goto l912
 						}
-						if !_rules[ruleSEMI]() {
-							goto l415
+						if buffer[position] != rune('a') {
+							goto l912
 						}
-						if !_rules[ruleConditionalExpression]() {
-							goto l415
+						position++
+						if buffer[position] != rune('n') {
+							goto l912
 						}
-						add(ruleConditionWithAssignment, position416)
-					}
-					goto l414
-				l415:
-					position, tokenIndex = position414, tokenIndex414
-					{
-						position418 := position
-						if !_rules[ruleCommand]() {
-							goto l417
+						position++
+						if buffer[position] != rune('d') {
+							goto l912
 						}
-						{
-							position419, tokenIndex419 := position, tokenIndex
-							if !_rules[ruleSEMI]() {
-								goto l419
-							}
-							if !_rules[ruleConditionalExpression]() {
-								goto l419
-							}
-							goto l420
-						l419:
-							position, tokenIndex = position419, tokenIndex419
+						position++
+						if !_rules[rule__]() {
+							goto l912
 						}
-					l420:
-						add(ruleConditionWithCommand, position418)
+						add(ruleAND, position913)
+					}
+					if !_rules[ruleConditionTerm]() {
+						goto l912
+					}
+					goto l911
+				l912:
+					position, tokenIndex = position912, tokenIndex912
+				}
+				add(ruleConditionConjunction, position910)
+			}
+			return true
+		l909:
+			position, tokenIndex = position909, tokenIndex909
+			return false
+		},
+		/* 217 ConditionTerm <- <(NOT? (ConditionGroup / ConditionWithRegex / ConditionWithComparator))> */
+		func() bool {
+			position914, tokenIndex914 := position, tokenIndex
+			{
+				position915 := position
+				{
+					position916, tokenIndex916 := position, tokenIndex
+					if !_rules[ruleNOT]() {
+						goto l916
 					}
-					goto l414
-				l417:
-					position, tokenIndex = position414, tokenIndex414
+					goto l917
+				l916:
+					position, tokenIndex = position916, tokenIndex916
+				}
+			l917:
+				{
+					position918, tokenIndex918 := position, tokenIndex
 					{
-						position422 := position
-						if !_rules[ruleExpression]() {
-							goto l421
+						position920 := position
+						if !_rules[ruleGROUPOPEN]() {
+							goto l919
+						}
+						if !_rules[ruleConditionDisjunction]() {
+							goto l919
+						}
+						if !_rules[ruleGROUPCLOSE]() {
+							goto l919
 						}
 						{
-							position423 := position
+							position921, tokenIndex921 := position, tokenIndex
 							{
-								position424, tokenIndex424 := position, tokenIndex
-								{
-									position426 := position
-									if !_rules[rule_]() {
-										goto l425
-									}
-									if buffer[position] != rune('=') {
-										goto l425
-									}
-									position++
-									if buffer[position] != rune('~') {
-										goto l425
-									}
-									position++
-									if !_rules[rule_]() {
-										goto l425
-									}
-									add(ruleMatch, position426)
+								position922, tokenIndex922 := position, tokenIndex
+								if !_rules[ruleComparisonOperator]() {
+									goto l923
+								}
+								goto l922
+							l923:
+								position, tokenIndex = position922, tokenIndex922
+								if !_rules[ruleMatchOperator]() {
+									goto l924
 								}
-								goto l424
-							l425:
-								position, tokenIndex = position424, tokenIndex424
+								goto l922
+							l924:
+								position, tokenIndex = position922, tokenIndex922
 								{
-									position427 := position
+									position925 := position
 									if !_rules[rule_]() {
-										goto l421
-									}
-									if buffer[position] != rune('!') {
-										goto l421
+										goto l921
 									}
-									position++
-									if buffer[position] != rune('~') {
-										goto l421
+									{
+										position926, tokenIndex926 := position, tokenIndex
+										if !_rules[ruleExponentOperator]() {
+											goto l927
+										}
+										goto l926
+									l927:
+										position, tokenIndex = position926, tokenIndex926
+										if !_rules[ruleMultiplicativeOperator]() {
+											goto l928
+										}
+										goto l926
+									l928:
+										position, tokenIndex = position926, tokenIndex926
+										if !_rules[ruleAdditiveOperator]() {
+											goto l929
+										}
+										goto l926
+									l929:
+										position, tokenIndex = position926, tokenIndex926
+										if !_rules[ruleBitwiseOperator]() {
+											goto l921
+										}
 									}
-									position++
+								l926:
 									if !_rules[rule_]() {
-										goto l421
+										goto l921
 									}
-									add(ruleUnmatch, position427)
+									add(ruleOperator, position925)
 								}
 							}
-						l424:
-							add(ruleMatchOperator, position423)
+						l922:
+							goto l919
+						l921:
+							position, tokenIndex = position921, tokenIndex921
+						}
+						add(ruleConditionGroup, position920)
+					}
+					goto l918
+				l919:
+					position, tokenIndex = position918, tokenIndex918
+					{
+						position931 := position
+						if !_rules[ruleExpression]() {
+							goto l930
+						}
+						if !_rules[ruleMatchOperator]() {
+							goto l930
 						}
 						if !_rules[ruleRegularExpression]() {
-							goto l421
+							goto l930
 						}
-						add(ruleConditionWithRegex, position422)
+						add(ruleConditionWithRegex, position931)
 					}
-					goto l414
-				l421:
-					position, tokenIndex = position414, tokenIndex414
+					goto l918
+				l930:
+					position, tokenIndex = position918, tokenIndex918
 					{
-						position428 := position
+						position932 := position
 						{
-							position429 := position
+							position933 := position
 							if !_rules[ruleExpression]() {
-								goto l409
+								goto l914
 							}
-							add(ruleConditionWithComparatorLHS, position429)
+							add(ruleConditionWithComparatorLHS, position933)
 						}
 						{
-							position430, tokenIndex430 := position, tokenIndex
+							position934, tokenIndex934 := position, tokenIndex
 							{
-								position432 := position
-								{
-									position433 := position
-									if !_rules[rule_]() {
-										goto l430
-									}
-									{
-										position434, tokenIndex434 := position, tokenIndex
-										{
-											position436 := position
-											if !_rules[rule_]() {
-												goto l435
-											}
-											if buffer[position] != rune('=') {
-												goto l435
-											}
-											position++
-											if buffer[position] != rune('=') {
-												goto l435
-											}
-											position++
-											if !_rules[rule_]() {
-												goto l435
-											}
-											add(ruleEquality, position436)
-										}
-										goto l434
-									l435:
-										position, tokenIndex = position434, tokenIndex434
-										{
-											position438 := position
-											if !_rules[rule_]() {
-												goto l437
-											}
-											if buffer[position] != rune('!') {
-												goto l437
-											}
-											position++
-											if buffer[position] != rune('=') {
-												goto l437
-											}
-											position++
-											if !_rules[rule_]() {
-												goto l437
-											}
-											add(ruleNonEquality, position438)
-										}
-										goto l434
-									l437:
-										position, tokenIndex = position434, tokenIndex434
-										{
-											position440 := position
-											if !_rules[rule_]() {
-												goto l439
-											}
-											if buffer[position] != rune('>') {
-												goto l439
-											}
-											position++
-											if buffer[position] != rune('=') {
-												goto l439
-											}
-											position++
-											if !_rules[rule_]() {
-												goto l439
-											}
-											add(ruleGreaterEqual, position440)
-										}
-										goto l434
-									l439:
-										position, tokenIndex = position434, tokenIndex434
-										{
-											position442 := position
-											if !_rules[rule_]() {
-												goto l441
-											}
-											if buffer[position] != rune('<') {
-												goto l441
-											}
-											position++
-											if buffer[position] != rune('=') {
-												goto l441
-											}
-											position++
-											if !_rules[rule_]() {
-												goto l441
-											}
-											add(ruleLessEqual, position442)
-										}
-										goto l434
-									l441:
-										position, tokenIndex = position434, tokenIndex434
-										{
-											position444 := position
-											if !_rules[rule_]() {
-												goto l443
-											}
-											if buffer[position] != rune('>') {
-												goto l443
-											}
-											position++
-											if !_rules[rule_]() {
-												goto l443
-											}
-											add(ruleGreaterThan, position444)
-										}
-										goto l434
-									l443:
-										position, tokenIndex = position434, tokenIndex434
-										{
-											position446 := position
-											if !_rules[rule_]() {
-												goto l445
-											}
-											if buffer[position] != rune('<') {
-												goto l445
-											}
-											position++
-											if !_rules[rule_]() {
-												goto l445
-											}
-											add(ruleLessThan, position446)
-										}
-										goto l434
-									l445:
-										position, tokenIndex = position434, tokenIndex434
-										{
-											position448 := position
-											if !_rules[rule_]() {
-												goto l447
-											}
-											if buffer[position] != rune('i') {
-												goto l447
-											}
-											position++
-											if buffer[position] != rune('n') {
-												goto l447
-											}
-											position++
-											if !_rules[rule_]() {
-												goto l447
-											}
-											add(ruleMembership, position448)
-										}
-										goto l434
-									l447:
-										position, tokenIndex = position434, tokenIndex434
-										{
-											position449 := position
-											if !_rules[rule_]() {
-												goto l430
-											}
-											if buffer[position] != rune('n') {
-												goto l430
-											}
-											position++
-											if buffer[position] != rune('o') {
-												goto l430
-											}
-											position++
-											if buffer[position] != rune('t') {
-												goto l430
-											}
-											position++
-											if !_rules[rule__]() {
-												goto l430
-											}
-											if buffer[position] != rune('i') {
-												goto l430
-											}
-											position++
-											if buffer[position] != rune('n') {
-												goto l430
-											}
-											position++
-											if !_rules[rule_]() {
-												goto l430
-											}
-											add(ruleNonMembership, position449)
-										}
-									}
-								l434:
-									if !_rules[rule_]() {
-										goto l430
-									}
-									add(ruleComparisonOperator, position433)
+								position936 := position
+								if !_rules[ruleComparisonOperator]() {
+									goto l934
 								}
 								if !_rules[ruleExpression]() {
-									goto l430
+									goto l934
 								}
-								add(ruleConditionWithComparatorRHS, position432)
+								add(ruleConditionWithComparatorRHS, position936)
 							}
-							goto l431
-						l430:
-							position, tokenIndex = position430, tokenIndex430
+							goto l935
+						l934:
+							position, tokenIndex = position934, tokenIndex934
 						}
-					l431:
-						add(ruleConditionWithComparator, position428)
+					l935:
+						add(ruleConditionWithComparator, position932)
 					}
 				}
-			l414:
-				add(ruleConditionalExpression, position410)
+			l918:
+				add(ruleConditionTerm, position915)
 			}
 			return true
-		l409:
-			position, tokenIndex = position409, tokenIndex409
+		l914:
+			position, tokenIndex = position914, tokenIndex914
 			return false
 		},
-		/* 120 ConditionWithAssignment <- <(Assignment SEMI ConditionalExpression)> */
+		/* 218 ConditionGroup <- <(GROUPOPEN ConditionDisjunction GROUPCLOSE !(ComparisonOperator / MatchOperator / Operator))> */
+		nil,
+		/* 219 ConditionWithAssignment <- <(Assignment SEMI ConditionalExpression)> */
 		nil,
-		/* 121 ConditionWithCommand <- <(Command (SEMI ConditionalExpression)?)> */
+		/* 220 ConditionWithCommand <- <(Command (SEMI ConditionalExpression)?)> */
 		nil,
-		/* 122 ConditionWithRegex <- <(Expression MatchOperator RegularExpression)> */
+		/* 221 ConditionWithRegex <- <(Expression MatchOperator RegularExpression)> */
 		nil,
-		/* 123 ConditionWithComparator <- <(ConditionWithComparatorLHS ConditionWithComparatorRHS?)> */
+		/* 222 ConditionWithComparator <- <(ConditionWithComparatorLHS ConditionWithComparatorRHS?)> */
 		nil,
-		/* 124 ConditionWithComparatorLHS <- <Expression> */
+		/* 223 ConditionWithComparatorLHS <- <Expression> */
 		nil,
-		/* 125 ConditionWithComparatorRHS <- <(ComparisonOperator Expression)> */
+		/* 224 ConditionWithComparatorRHS <- <(ComparisonOperator Expression)> */
 		nil,
 	}
 	p.rules = _rules
@@ -0,0 +1,370 @@
+package scripting
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ghetzel/go-stockutil/maputil"
+	"github.com/ghetzel/go-stockutil/sliceutil"
+	"github.com/ghetzel/go-stockutil/stringutil"
+	"github.com/ghetzel/go-stockutil/typeutil"
+)
+
+var rxInterpolationNumber = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// A filter transforms a value in an interpolation sequence (e.g.: the "upper" in "{name|upper}").  The
+// value being filtered is passed in along with any arguments given to the filter, which will already
+// have been resolved to their values.
+type FilterFunc func(value any, args ...any) (any, error)
+
+var filters = make(map[string]FilterFunc)
+var filterlock sync.RWMutex
+
+func init() {
+	RegisterFilter(`upper`, func(value any, _ ...any) (any, error) {
+		return strings.ToUpper(typeutil.String(value)), nil
+	})
+
+	RegisterFilter(`lower`, func(value any, _ ...any) (any, error) {
+		return strings.ToLower(typeutil.String(value)), nil
+	})
+
+	RegisterFilter(`trim`, func(value any, _ ...any) (any, error) {
+		return strings.TrimSpace(typeutil.String(value)), nil
+	})
+
+	RegisterFilter(`camelize`, func(value any, _ ...any) (any, error) {
+		return stringutil.Camelize(typeutil.String(value)), nil
+	})
+
+	RegisterFilter(`underscore`, func(value any, _ ...any) (any, error) {
+		return stringutil.Underscore(typeutil.String(value)), nil
+	})
+
+	RegisterFilter(`length`, func(value any, _ ...any) (any, error) {
+		if IsEmpty(value) {
+			return 0, nil
+		} else if typeutil.IsArray(value) || typeutil.IsMap(value) {
+			return typeutil.Len(value), nil
+		} else {
+			return len([]rune(typeutil.String(value))), nil
+		}
+	})
+
+	RegisterFilter(`join`, func(value any, args ...any) (any, error) {
+		var values = sliceutil.Stringify(sliceutil.Sliceify(value))
+		return strings.Join(values, filterArg(args, 0, `,`)), nil
+	})
+
+	RegisterFilter(`split`, func(value any, args ...any) (any, error) {
+		return sliceutil.Sliceify(strings.Split(typeutil.String(value), filterArg(args, 0, `,`))), nil
+	})
+
+	RegisterFilter(`replace`, func(value any, args ...any) (any, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("expected 2 arguments, got %d", len(args))
+		}
+
+		return strings.ReplaceAll(typeutil.String(value), filterArg(args, 0, ``), filterArg(args, 1, ``)), nil
+	})
+
+	RegisterFilter(`printf`, func(value any, args ...any) (any, error) {
+		if len(args) == 0 {
+			return nil, fmt.Errorf("a format string is required")
+		}
+
+		return fmt.Sprintf(typeutil.String(args[0]), append([]any{value}, args[1:]...)...), nil
+	})
+
+	RegisterFilter(`default`, func(value any, args ...any) (any, error) {
+		if IsEmpty(value) || typeutil.IsEmpty(value) {
+			if len(args) > 0 {
+				return args[0], nil
+			}
+
+			return nil, nil
+		}
+
+		return value, nil
+	})
+
+	RegisterFilter(`json`, func(value any, _ ...any) (any, error) {
+		if IsEmpty(value) {
+			value = nil
+		}
+
+		if data, err := json.Marshal(value); err == nil {
+			return string(data), nil
+		} else {
+			return nil, err
+		}
+	})
+
+	RegisterFilter(`first`, func(value any, _ ...any) (any, error) {
+		return sliceutil.First(value), nil
+	})
+
+	RegisterFilter(`last`, func(value any, _ ...any) (any, error) {
+		return sliceutil.Last(value), nil
+	})
+
+	RegisterFilter(`keys`, func(value any, _ ...any) (any, error) {
+		var keys = maputil.StringKeys(value)
+		sort.Strings(keys)
+
+		return sliceutil.Sliceify(keys), nil
+	})
+}
+
+// Register a filter that can be used in interpolation sequences.  Filters are shared by all scopes,
+// and registering a filter with the name of an existing one replaces it.  Registering a nil filter
+// removes it.
+func RegisterFilter(name string, filter FilterFunc) {
+	filterlock.Lock()
+	defer filterlock.Unlock()
+
+	if filter == nil {
+		delete(filters, name)
+	} else {
+		filters[name] = filter
+	}
+}
+
+// Return the names of all registered filters.
+func FilterNames() []string {
+	filterlock.RLock()
+	defer filterlock.RUnlock()
+
+	return maputil.StringKeys(filters)
+}
+
+func getFilter(name string) (FilterFunc, bool) {
+	filterlock.RLock()
+	defer filterlock.RUnlock()
+
+	filter, ok := filters[name]
+	return filter, ok
+}
+
+func filterArg(args []any, i int, fallback string) string {
+	if i < len(args) && !IsEmpty(args[i]) {
+		return typeutil.String(args[i])
+	}
+
+	return fallback
+}
+
+// Same as Interpolate, but returns an error if a sequence uses an unknown filter, a filter fails, or
+// (in strict mode) if a sequence refers to a variable that is not defined.  If an error occurs, the
+// rest of the string is still interpolated and returned along with the first error encountered.
+func (self *Scope) Expand(in string) (string, error) {
+	var out strings.Builder
+	var firstErr error
+
+	for {
+		start, end := nextInterpolationSequence(in)
+
+		if start < 0 {
+			out.WriteString(in)
+			break
+		}
+
+		out.WriteString(in[:start])
+
+		if value, err := self.evaluateSequence(in[start+1 : end]); err == nil {
+			if !IsEmpty(value) {
+				out.WriteString(typeutil.String(value))
+			}
+		} else if firstErr == nil {
+			firstErr = err
+		}
+
+		in = in[end+1:]
+	}
+
+	return out.String(), firstErr
+}
+
+// evaluates the contents of a single interpolation sequence, which takes the form:
+//
+//	reference ( ?? fallback )* ( | filter ( : argument ( , argument )* )? )*
+func (self *Scope) evaluateSequence(seq string) (any, error) {
+	var stages = splitUnquoted(seq, `|`)
+	var alternatives = splitUnquoted(stages[0], `??`)
+
+	// sequences without any defaults or filters are treated as a variable name, exactly as written
+	if len(stages) == 1 && len(alternatives) == 1 {
+		return self.resolveReference(strings.TrimSpace(seq), true)
+	}
+
+	var value any
+
+	for i, alternative := range alternatives {
+		var last = (i == len(alternatives)-1)
+
+		if v, err := self.resolveOperand(alternative, last); err == nil {
+			value = v
+		} else {
+			return nil, err
+		}
+
+		if !IsEmpty(value) {
+			break
+		}
+	}
+
+	for _, stage := range stages[1:] {
+		var name, argstr = stringutil.SplitPairTrimSpace(stage, `:`)
+		var args []any
+
+		if filter, ok := getFilter(name); ok {
+			if argstr != `` {
+				for _, arg := range splitUnquoted(argstr, `,`) {
+					if v, err := self.resolveOperand(arg, true); err == nil {
+						args = append(args, v)
+					} else {
+						return nil, err
+					}
+				}
+			}
+
+			if v, err := filter(value, args...); err == nil {
+				value = v
+			} else {
+				return nil, fmt.Errorf("filter %q: %v", name, err)
+			}
+		} else {
+			return nil, fmt.Errorf("unknown filter %q", name)
+		}
+	}
+
+	return value, nil
+}
+
+// resolves a quoted string, number, boolean, null, or variable reference in an interpolation sequence
+func (self *Scope) resolveOperand(operand string, strict bool) (any, error) {
+	operand = strings.TrimSpace(operand)
+
+	switch {
+	case operand == ``:
+		return nil, fmt.Errorf("missing value")
+	case len(operand) >= 2 && (operand[0] == '"' || operand[0] == '\'') && operand[len(operand)-1] == operand[0]:
+		return unescapeString(operand[1:len(operand)-1], false), nil
+	case operand == `true`:
+		return true, nil
+	case operand == `false`:
+		return false, nil
+	case operand == `null`:
+		return nil, nil
+	case rxInterpolationNumber.MatchString(operand):
+		if strings.Contains(operand, `.`) {
+			return strconv.ParseFloat(operand, 64)
+		} else {
+			return parseIntegerLiteral(operand)
+		}
+	default:
+		return self.resolveReference(operand, strict)
+	}
+}
+
+// Retrieve the value of the given variable reference.  If strict is true and this scope is in strict
+// mode, referring to a variable or key that does not exist is an error, unless the reference uses
+// safe navigation ("a?.b") to mark the missing key as optional.
+func (self *Scope) resolveReference(key string, strict bool) (any, error) {
+	if strict && self.IsStrict() {
+		var path []string
+		var optional bool
+
+		for _, part := range strings.Split(normalizeReference(strings.TrimPrefix(key, `$`)), `.`) {
+			path = append(path, strings.TrimSuffix(part, `?`))
+
+			if name := strings.Join(path, `.`); !self.Has(name) {
+				if optional {
+					return nil, nil
+				}
+
+				return nil, fmt.Errorf("%w $%s", ErrUndefinedVariable, name)
+			}
+
+			optional = strings.HasSuffix(part, `?`)
+		}
+	}
+
+	return self.Get(key), nil
+}
+
+// Return the start and end offsets of the first "{...}" sequence in the given string, or -1 if there
+// are none.  Braces that appear inside of quoted strings within the sequence do not end it.
+func nextInterpolationSequence(in string) (int, int) {
+	for start := strings.IndexByte(in, '{'); start >= 0; {
+		var quote byte
+		var end = -1
+
+		for i := start + 1; i < len(in) && end < 0; i++ {
+			switch c := in[i]; {
+			case quote != 0 && c == '\\':
+				i += 1
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '}':
+				end = i
+			}
+		}
+
+		// unbalanced quotes are not treated as quotes at all
+		if end < 0 {
+			end = strings.IndexByte(in[start:], '}')
+
+			if end >= 0 {
+				end += start
+			}
+		}
+
+		if end < 0 {
+			return -1, -1
+		} else if end > start+1 {
+			return start, end
+		} else if next := strings.IndexByte(in[end+1:], '{'); next >= 0 {
+			start = end + 1 + next
+		} else {
+			break
+		}
+	}
+
+	return -1, -1
+}
+
+// splits the given string on a separator, except for where that separator appears in a quoted string
+func splitUnquoted(in string, sep string) []string {
+	var parts []string
+	var quote byte
+	var last int
+
+	for i := 0; i < len(in); i++ {
+		switch c := in[i]; {
+		case quote != 0 && c == '\\':
+			i += 1
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(in[i:], sep):
+			parts = append(parts, in[last:i])
+			last = i + len(sep)
+			i += len(sep) - 1
+		}
+	}
+
+	return append(parts, in[last:])
+}
@@ -3,9 +3,7 @@ package scripting
 import (
 	"fmt"
 
-	"github.com/ghetzel/go-stockutil/log"
 	"github.com/ghetzel/go-stockutil/sliceutil"
-	"github.com/ghetzel/go-stockutil/stringutil"
 )
 
 type AssignmentOperator int
@@ -86,64 +84,43 @@ func (self AssignmentOperator) Evaluate(lhs any, rhs any) (any, error) {
 	if v, err := exprToValue(lhs); err == nil {
 		lhs = v
 	} else {
-		log.Panicf("malformed left-hand expression: %v", err)
+		return nil, err
 	}
 
 	if v, err := exprToValue(rhs); err == nil {
 		rhs = v
 	} else {
-		log.Panicf("malformed right-hand expression (%T): %v", rhs, err)
+		return nil, err
 	}
 
-	var lv float64
-	var rv float64
-	var lverr error
-	var rverr error
-
-	// log.Debugf("lhs=%T(%v) %v rhs=%T(%v)", lhs, lhs, self, rhs, rhs)
-
-	lv, lverr = stringutil.ConvertToFloat(lhs)
-	rv, rverr = stringutil.ConvertToFloat(rhs)
-
-	switch self {
-	case assnAssignEq, assnAppend:
-		break
-	default:
-		if lverr != nil {
-			lv = 0
-		}
-
-		if rverr != nil {
-			return nil, rverr
-		}
-	}
+	var op operator
 
 	switch self {
 	case assnAssignEq:
 		return rhs, nil
-
+	case assnAppend:
+		return append(sliceutil.Sliceify(lhs), rhs), nil
 	case assnStarEq:
-		return (lv * rv), nil
-
+		op = opMultiply
 	case assnDivEq:
-		if rv == 0 {
-			return 0, fmt.Errorf("divide by zero")
-		}
-
-		return (lv / rv), nil
-
+		op = opDivide
 	case assnPlusEq:
-		return (lv + rv), nil
-
+		op = opAdd
 	case assnMinusEq:
-		return (lv - rv), nil
+		op = opSubtract
 	case assnAndEq:
-		return int64(lv) & int64(rv), nil
-
+		op = opBitwiseAnd
 	case assnOrEq:
-		return int64(lv) | int64(rv), nil
-	case assnAppend:
-		return append(sliceutil.Sliceify(lhs), rhs), nil
+		op = opBitwiseOr
+	}
+
+	if op != opNull {
+		// mutating a variable that isn't set treats it as zero
+		if IsEmpty(lhs) {
+			lhs = 0
+		}
+
+		return op.evaluate(lhs, rhs)
 	}
 
 	return 0, fmt.Errorf("unsupported assignment operator %v", self)
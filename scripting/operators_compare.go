@@ -57,9 +57,6 @@ func parseComparator(node *node32) (Comparator, error) {
 
 func (self Comparator) Evaluate(lhs *Expression, rhs *Expression) bool {
 	var lvv, rvv any
-	var lv, rv float64
-	var lverr error
-	var rverr error
 
 	if lhs == nil {
 		log.Fatal("malformed expression: missing left-hand side")
@@ -77,6 +74,24 @@ func (self Comparator) Evaluate(lhs *Expression, rhs *Expression) bool {
 		log.Panicf("invalid expression result: %v", err)
 	}
 
+	return self.compare(lvv, rvv)
+}
+
+func (self Comparator) compare(lvv any, rvv any) bool {
+	var lv, rv float64
+	var lverr error
+	var rverr error
+
+	// integers, decimals, times, and durations are compared exactly
+	switch self {
+	case cmpEquality, cmpNonEquality, cmpGreaterThan, cmpGreaterEqual, cmpLessEqual, cmpLessThan:
+		if c, ok := compareTime(lvv, rvv); ok {
+			return self.fromComparison(c)
+		} else if c, ok := compareExact(lvv, rvv); ok {
+			return self.fromComparison(c)
+		}
+	}
+
 	lv, lverr = stringutil.ConvertToFloat(lvv)
 	rv, rverr = stringutil.ConvertToFloat(rvv)
 
@@ -143,6 +158,69 @@ func (self Comparator) Evaluate(lhs *Expression, rhs *Expression) bool {
 	}
 }
 
+// converts the result of a three-way comparison (-1, 0, +1) into the result of this comparator
+func (self Comparator) fromComparison(c int) bool {
+	switch self {
+	case cmpEquality:
+		return (c == 0)
+	case cmpNonEquality:
+		return (c != 0)
+	case cmpGreaterThan:
+		return (c > 0)
+	case cmpGreaterEqual:
+		return (c >= 0)
+	case cmpLessEqual:
+		return (c <= 0)
+	case cmpLessThan:
+		return (c < 0)
+	default:
+		return false
+	}
+}
+
+// Compares two times, durations, or numbers, returning -1, 0, or +1 (and true) if the values could be
+// compared.  Unlike Comparator.compare, values that cannot be ordered are not an error.
+func compareOrdered(lhs any, rhs any) (int, bool) {
+	if IsEmpty(lhs) || IsEmpty(rhs) {
+		return 0, false
+	} else if c, ok := compareTime(lhs, rhs); ok {
+		return c, true
+	} else if c, ok := compareExact(lhs, rhs); ok {
+		return c, true
+	} else if lv, err := stringutil.ConvertToFloat(lhs); err != nil {
+		return 0, false
+	} else if rv, err := stringutil.ConvertToFloat(rhs); err != nil {
+		return 0, false
+	} else if lv < rv {
+		return -1, true
+	} else if lv > rv {
+		return 1, true
+	} else {
+		return 0, true
+	}
+}
+
+// Compares two values exactly if both are integers, or if either is a decimal (and the other can be
+// converted to one.)  Returns -1, 0, or +1 (and true) if the values could be compared this way.
+func compareExact(lhs any, rhs any) (int, bool) {
+	_, ldec := lhs.(*Decimal)
+	_, rdec := rhs.(*Decimal)
+
+	if ldec || rdec {
+		if l, err := NewDecimal(lhs); err == nil {
+			if r, err := NewDecimal(rhs); err == nil {
+				return l.Cmp(r), true
+			}
+		}
+	} else if l, ok := toBigInt(lhs); ok {
+		if r, ok := toBigInt(rhs); ok {
+			return l.Cmp(r), true
+		}
+	}
+
+	return 0, false
+}
+
 func membershipTest(i int, first any, second any) bool {
 	return fmt.Sprintf("%v", first) == fmt.Sprintf("%v", second)
 }
@@ -3,10 +3,16 @@ package scripting
 import (
 	"fmt"
 	"math"
+	"math/big"
+	"time"
 
 	"github.com/ghetzel/go-stockutil/stringutil"
 )
 
+// The largest number of bits that the result of raising an integer to an integer power may have
+// before the result is calculated as a float instead.
+var MaxIntegerBits int64 = 1 << 16
+
 type operator int
 
 const (
@@ -21,6 +27,8 @@ const (
 	opBitwiseOr
 	opBitwiseNot
 	opBitwiseXor
+	opNegate
+	opNot
 )
 
 func parseOperator(node *node32) (operator, error) {
@@ -49,6 +57,10 @@ func parseOperator(node *node32) (operator, error) {
 		return opBitwiseNot, nil
 	case ruleBitwiseXor:
 		return opBitwiseXor, nil
+	case ruleNegate:
+		return opNegate, nil
+	case ruleLogicalNot:
+		return opNot, nil
 	default:
 		return -1, fmt.Errorf("invalid operator %q", node)
 	}
@@ -72,6 +84,45 @@ func (self operator) evaluate(lhs any, rhs any) (any, error) {
 		return nil, err
 	}
 
+	// strings are concatenated
+	if self == opAdd {
+		_, lstr := lhs.(string)
+		_, rstr := rhs.(string)
+
+		if lstr || rstr {
+			return fmt.Sprintf("%v%v", lhs, rhs), nil
+		}
+	}
+
+	// times and durations
+	if output, ok, err := self.evaluateTime(lhs, rhs); ok {
+		return output, err
+	}
+
+	// decimals are contagious: if either side is a decimal, the other side is converted to one
+	// and the result is a decimal.
+	_, ldec := lhs.(*Decimal)
+	_, rdec := rhs.(*Decimal)
+
+	if ldec || rdec {
+		if l, err := NewDecimal(lhs); err != nil {
+			return nil, err
+		} else if r, err := NewDecimal(rhs); err != nil {
+			return nil, err
+		} else {
+			return self.evaluateDecimal(l, r)
+		}
+	}
+
+	// integer arithmetic stays integer arithmetic (where possible)
+	if l, ok := toBigInt(lhs); ok {
+		if r, ok := toBigInt(rhs); ok {
+			if output, ok, err := self.evaluateInteger(l, r); ok {
+				return output, err
+			}
+		}
+	}
+
 	lv, lverr = stringutil.ConvertToFloat(lhs)
 	rv, rverr = stringutil.ConvertToFloat(rhs)
 
@@ -106,14 +157,7 @@ func (self operator) evaluate(lhs any, rhs any) (any, error) {
 		output = math.Mod(lv, rv)
 
 	case opAdd:
-		_, lstr := lhs.(string)
-		_, rstr := rhs.(string)
-
-		if lstr || rstr {
-			output = fmt.Sprintf("%v%v", lhs, rhs)
-		} else {
-			output = (lv + rv)
-		}
+		output = (lv + rv)
 
 	case opSubtract:
 		output = (lv - rv)
@@ -144,6 +188,113 @@ func (self operator) evaluate(lhs any, rhs any) (any, error) {
 	}
 }
 
+// Evaluates a unary operator.  Negation preserves the numeric type of its operand, bitwise NOT
+// requires an integer (or a float with no fractional part), and logical NOT always yields a
+// boolean based on the truthiness of its operand.
+func (self operator) evaluateUnary(value any) (any, error) {
+	if v, err := exprToValue(value); err == nil {
+		value = v
+	} else {
+		return nil, err
+	}
+
+	switch self {
+	case opNot:
+		return !isTruthy(value), nil
+
+	case opNegate:
+		if d, ok := value.(time.Duration); ok {
+			return -d, nil
+		} else if d, ok := value.(*Decimal); ok {
+			return &Decimal{new(big.Rat).Neg(d.value)}, nil
+		} else if i, ok := toBigInt(value); ok {
+			return normalizeInteger(i.Neg(i)), nil
+		} else if f, err := stringutil.ConvertToFloat(value); err == nil && !IsEmpty(value) {
+			return -f, nil
+		} else {
+			return nil, fmt.Errorf("cannot negate %T", value)
+		}
+
+	case opBitwiseNot:
+		if i, ok := toBigInt(value); ok {
+			return normalizeInteger(i.Not(i)), nil
+		} else if f, err := stringutil.ConvertToFloat(value); err == nil && !IsEmpty(value) && f == math.Trunc(f) {
+			return normalizeInteger(new(big.Int).Not(big.NewInt(int64(f)))), nil
+		} else {
+			return nil, fmt.Errorf("bitwise NOT requires an integer, got %T", value)
+		}
+
+	default:
+		return nil, fmt.Errorf("operator '%v' is not a unary operator", self)
+	}
+}
+
+// Performs integer arithmetic, promoting the result to a *big.Int if it would overflow.  Division
+// only yields an integer if the result is exact, and negative (or very large) exponents are
+// evaluated as floats; in these cases ok is false and the caller should fall back to float math.
+func (self operator) evaluateInteger(lhs *big.Int, rhs *big.Int) (output any, ok bool, err error) {
+	var result = new(big.Int)
+
+	switch self {
+	case opAdd:
+		result.Add(lhs, rhs)
+	case opSubtract:
+		result.Sub(lhs, rhs)
+	case opMultiply:
+		result.Mul(lhs, rhs)
+	case opDivide, opModulus:
+		if rhs.Sign() == 0 {
+			return nil, true, fmt.Errorf("cannot divide by zero")
+		}
+
+		var remainder = new(big.Int)
+		result.QuoRem(lhs, rhs, remainder)
+
+		if self == opModulus {
+			result = remainder
+		} else if remainder.Sign() != 0 {
+			return nil, false, nil
+		}
+
+	case opExponentiate:
+		if rhs.Sign() < 0 || !rhs.IsInt64() || int64(lhs.BitLen())*rhs.Int64() > MaxIntegerBits {
+			return nil, false, nil
+		}
+
+		result.Exp(lhs, rhs, nil)
+
+	case opBitwiseAnd:
+		result.And(lhs, rhs)
+	case opBitwiseOr:
+		result.Or(lhs, rhs)
+	case opBitwiseXor:
+		result.Xor(lhs, rhs)
+	default:
+		return nil, true, fmt.Errorf("operator '%v' not implemented", self)
+	}
+
+	return normalizeInteger(result), true, nil
+}
+
+func (self operator) evaluateDecimal(lhs *Decimal, rhs *Decimal) (any, error) {
+	switch self {
+	case opAdd:
+		return lhs.add(rhs), nil
+	case opSubtract:
+		return lhs.sub(rhs), nil
+	case opMultiply:
+		return lhs.mul(rhs), nil
+	case opDivide:
+		return lhs.quo(rhs)
+	case opModulus:
+		return lhs.rem(rhs)
+	case opExponentiate:
+		return lhs.pow(rhs)
+	default:
+		return nil, fmt.Errorf("operator '%v' is not supported on decimals", self)
+	}
+}
+
 func (self operator) String() string {
 	switch self {
 	case opExponentiate:
@@ -166,7 +317,53 @@ func (self operator) String() string {
 		return `~`
 	case opBitwiseXor:
 		return `^`
+	case opNegate:
+		return `-`
+	case opNot:
+		return `not`
 	default:
 		return `INVALID`
 	}
 }
+
+// Converts any Go integer type (or *big.Int) into a *big.Int.  Other types (including floats and
+// numeric strings) are not converted.
+func toBigInt(in any) (*big.Int, bool) {
+	switch v := in.(type) {
+	case int:
+		return big.NewInt(int64(v)), true
+	case int8:
+		return big.NewInt(int64(v)), true
+	case int16:
+		return big.NewInt(int64(v)), true
+	case int32:
+		return big.NewInt(int64(v)), true
+	case int64:
+		return big.NewInt(v), true
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint16:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case uint64:
+		return new(big.Int).SetUint64(v), true
+	case *big.Int:
+		if v != nil {
+			return new(big.Int).Set(v), true
+		}
+	}
+
+	return nil, false
+}
+
+// Returns the given integer as an int if it fits in one, or as a *big.Int if it doesn't.
+func normalizeInteger(in *big.Int) any {
+	if in.IsInt64() && int64(int(in.Int64())) == in.Int64() {
+		return int(in.Int64())
+	}
+
+	return in
+}
@@ -0,0 +1,125 @@
+package scripting
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ghetzel/go-stockutil/stringutil"
+)
+
+// The layouts (in order of preference) used to parse timestamp literals.  Timestamps without a
+// timezone are interpreted as UTC.
+var TimestampLayouts = []string{
+	time.RFC3339Nano,
+	`2006-01-02T15:04:05`,
+	`2006-01-02T15:04`,
+	time.DateOnly,
+}
+
+// Performs arithmetic involving times and durations.  Supported operations are:
+//
+//	time ± duration      -> time
+//	duration + time      -> time
+//	time - time          -> duration
+//	duration ± duration  -> duration
+//	duration % duration  -> duration
+//	duration * number    -> duration
+//	number * duration    -> duration
+//	duration / number    -> duration
+//	duration / duration  -> float (the ratio of the two)
+//
+// If neither side is a time or duration, ok is false.
+func (self operator) evaluateTime(lhs any, rhs any) (output any, ok bool, err error) {
+	var lt, ltime = lhs.(time.Time)
+	var rt, rtime = rhs.(time.Time)
+	var ld, ldur = lhs.(time.Duration)
+	var rd, rdur = rhs.(time.Duration)
+
+	if !ltime && !rtime && !ldur && !rdur {
+		return nil, false, nil
+	}
+
+	switch {
+	case ltime && rdur:
+		switch self {
+		case opAdd:
+			return lt.Add(rd), true, nil
+		case opSubtract:
+			return lt.Add(-rd), true, nil
+		}
+
+	case ldur && rtime:
+		if self == opAdd {
+			return rt.Add(ld), true, nil
+		}
+
+	case ltime && rtime:
+		if self == opSubtract {
+			return lt.Sub(rt), true, nil
+		}
+
+	case ldur && rdur:
+		switch self {
+		case opAdd:
+			return ld + rd, true, nil
+		case opSubtract:
+			return ld - rd, true, nil
+		case opModulus:
+			if rd == 0 {
+				return nil, true, fmt.Errorf("cannot divide by zero")
+			}
+
+			return ld % rd, true, nil
+		case opDivide:
+			if rd == 0 {
+				return nil, true, fmt.Errorf("cannot divide by zero")
+			}
+
+			return float64(ld) / float64(rd), true, nil
+		}
+
+	case ldur && !rtime:
+		if n, err := stringutil.ConvertToFloat(rhs); err == nil && !IsEmpty(rhs) {
+			switch self {
+			case opMultiply:
+				return time.Duration(float64(ld) * n), true, nil
+			case opDivide:
+				if n == 0 {
+					return nil, true, fmt.Errorf("cannot divide by zero")
+				}
+
+				return time.Duration(float64(ld) / n), true, nil
+			}
+		}
+
+	case rdur && !ltime:
+		if n, err := stringutil.ConvertToFloat(lhs); err == nil && !IsEmpty(lhs) && self == opMultiply {
+			return time.Duration(n * float64(rd)), true, nil
+		}
+	}
+
+	return nil, true, fmt.Errorf("unsupported operation: %T %v %T", lhs, self, rhs)
+}
+
+// Compares two times or two durations, returning -1, 0, or +1 (and true) if the values could be
+// compared this way.
+func compareTime(lhs any, rhs any) (int, bool) {
+	if lt, ok := lhs.(time.Time); ok {
+		if rt, ok := rhs.(time.Time); ok {
+			return lt.Compare(rt), true
+		}
+	} else if ld, ok := lhs.(time.Duration); ok {
+		if rd, ok := rhs.(time.Duration); ok {
+			switch {
+			case ld < rd:
+				return -1, true
+			case ld > rd:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+
+	return 0, false
+}
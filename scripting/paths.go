@@ -0,0 +1,188 @@
+package scripting
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ghetzel/go-stockutil/maputil"
+	"github.com/ghetzel/go-stockutil/sliceutil"
+	"github.com/ghetzel/go-stockutil/typeutil"
+)
+
+var rxBracketIndex = regexp.MustCompile(`\[\s*['"]?([^\]'"]*)['"]?\s*\]`)
+
+// The most nulls that assigning past the end of an array will pad it with (e.g.: assigning to index 5 of
+// an empty array pads it with 5.)  Assigning to indices further past the end than this is an error.
+var MaxArrayPadding = 1024
+
+// Retrieve the value at the given path within the given object or array.  Integer path segments
+// index into arrays and strings, with negative indices counting backwards from the end.  Segments of
+// the form "start:end" (either of which may be omitted or negative) return a slice of an array or
+// string.  The second return value is false if nothing exists at the given path.
+func deepGet(container any, path []string) (any, bool) {
+	for _, key := range path {
+		switch {
+		case typeutil.IsArray(container):
+			var items = sliceutil.Sliceify(container)
+
+			if start, end, ok := parseSlice(key, len(items)); ok {
+				container = append([]any{}, items[start:end]...)
+			} else if i, ok := parseIndex(key, len(items)); ok {
+				container = items[i]
+			} else {
+				return nil, false
+			}
+
+		case typeutil.IsMap(container):
+			var mv = reflect.Indirect(reflect.ValueOf(container))
+
+			if mv.Type().Key().Kind() != reflect.String {
+				return nil, false
+			} else if value := mv.MapIndex(reflect.ValueOf(key).Convert(mv.Type().Key())); value.IsValid() {
+				container = value.Interface()
+			} else {
+				return nil, false
+			}
+
+		default:
+			if str, ok := container.(string); ok {
+				var runes = []rune(str)
+
+				if start, end, ok := parseSlice(key, len(runes)); ok {
+					container = string(runes[start:end])
+				} else if i, ok := parseIndex(key, len(runes)); ok {
+					container = string(runes[i])
+				} else {
+					return nil, false
+				}
+			} else {
+				return nil, false
+			}
+		}
+	}
+
+	return container, true
+}
+
+// Set the value at the given path within the given object or array, creating any intermediate objects
+// and arrays that do not exist yet.  Integer path segments index into (or create) arrays, which are
+// padded with nulls as needed (up to MaxArrayPadding of them), and negative indices count backwards from
+// the end of existing arrays.  All other segments are object keys.  The (possibly new) container is
+// returned.
+func vivify(container any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	var key = path[0]
+
+	if IsEmpty(container) || typeutil.IsArray(container) {
+		if i, err := strconv.Atoi(key); err == nil {
+			var items []any
+
+			if !IsEmpty(container) {
+				items = sliceutil.Sliceify(container)
+			}
+
+			if i < 0 {
+				if i += len(items); i < 0 {
+					return nil, fmt.Errorf("index %v is out of range (length %d)", key, len(items))
+				}
+			}
+
+			if i-len(items) > MaxArrayPadding {
+				return nil, fmt.Errorf("index %v is out of range (length %d)", key, len(items))
+			}
+
+			for len(items) <= i {
+				items = append(items, nil)
+			}
+
+			if v, err := vivify(items[i], path[1:], value); err == nil {
+				items[i] = v
+			} else {
+				return nil, err
+			}
+
+			return items, nil
+		} else if strings.Contains(key, `:`) && typeutil.IsArray(container) {
+			return nil, fmt.Errorf("cannot assign to a slice")
+		}
+	}
+
+	var object map[string]any
+
+	if m, ok := container.(map[string]any); ok {
+		object = m
+	} else {
+		object = make(map[string]any)
+
+		// objects of other types are converted; anything else is replaced
+		if typeutil.IsMap(container) {
+			for _, k := range maputil.StringKeys(container) {
+				object[k] = maputil.Get(container, k)
+			}
+		}
+	}
+
+	if v, err := vivify(object[key], path[1:], value); err == nil {
+		object[key] = v
+	} else {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// Convert the brackets in a variable reference to dot-separated keys (e.g.: "a[0]['b']" becomes
+// "a.0.b").
+func normalizeReference(key string) string {
+	return rxBracketIndex.ReplaceAllString(key, `.$1`)
+}
+
+// parses an index into a sequence of the given length, counting back from the end if negative
+func parseIndex(key string, length int) (int, bool) {
+	if i, err := strconv.Atoi(key); err == nil {
+		if i < 0 {
+			i += length
+		}
+
+		if i >= 0 && i < length {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// parses a "start:end" slice of a sequence of the given length, clamping the bounds to the sequence
+func parseSlice(key string, length int) (int, int, bool) {
+	if lo, hi, ok := strings.Cut(key, `:`); ok {
+		var bounds = []int{0, length}
+
+		for b, bound := range []string{lo, hi} {
+			if bound = strings.TrimSpace(bound); bound == `` {
+				continue
+			} else if i, err := strconv.Atoi(bound); err == nil {
+				if i < 0 {
+					i += length
+				}
+
+				bounds[b] = min(max(i, 0), length)
+			} else {
+				return 0, 0, false
+			}
+		}
+
+		if bounds[1] < bounds[0] {
+			bounds[1] = bounds[0]
+		}
+
+		return bounds[0], bounds[1], true
+	}
+
+	return 0, 0, false
+}
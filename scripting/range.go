@@ -0,0 +1,158 @@
+package scripting
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ghetzel/go-stockutil/stringutil"
+)
+
+// A range of values from Start to End (e.g.: "1..10"), which includes End unless Exclusive is true.
+// Ranges whose End is less than their Start count down instead of up.  Ranges of numbers can be
+// iterated over, yielding every Step-th number in the range; their values are computed as they are
+// needed, so ranges of any size can be used without allocating them.
+type Range struct {
+	Start     any
+	End       any
+	Step      any
+	Exclusive bool
+	integer   bool
+	first     float64
+	step      float64
+	count     int
+}
+
+// Create a new range from start to end.  A nil step counts by one.  The start and end must be values
+// that can be ordered (numbers, times, or durations), and the step (if given) must be a non-zero number.
+func NewRange(start any, end any, step any, exclusive bool) (*Range, error) {
+	var rng = &Range{
+		Start:     start,
+		End:       end,
+		Step:      step,
+		Exclusive: exclusive,
+		count:     -1,
+	}
+
+	if _, ok := compareOrdered(start, end); !ok {
+		return nil, fmt.Errorf("invalid range: cannot compare %T and %T", start, end)
+	}
+
+	if IsEmpty(step) {
+		rng.Step = nil
+		rng.step = 1
+	} else if s, err := stringutil.ConvertToFloat(step); err == nil && s != 0 {
+		rng.step = math.Abs(s)
+	} else {
+		return nil, fmt.Errorf("invalid range: step must be a non-zero number")
+	}
+
+	// only ranges of numbers have a length
+	if lo, err := stringutil.ConvertToFloat(start); err == nil && isNumber(start) {
+		if hi, err := stringutil.ConvertToFloat(end); err == nil && isNumber(end) {
+			var span = math.Abs(hi - lo)
+			var steps = span / rng.step
+
+			rng.first = lo
+			rng.integer = isInteger(start) && isInteger(end) && (rng.Step == nil || isInteger(rng.Step))
+
+			if hi < lo {
+				rng.step = -rng.step
+			}
+
+			// allow for floating point error when deciding whether the end falls on a step
+			switch {
+			case span == 0 && exclusive:
+				rng.count = 0
+			case exclusive:
+				rng.count = int(math.Ceil(steps - 1e-9))
+			default:
+				rng.count = int(math.Floor(steps+1e-9)) + 1
+			}
+		}
+	}
+
+	return rng, nil
+}
+
+// Return whether the values in this range can be iterated over (i.e.: it is a range of numbers.)
+func (self *Range) IsIterable() bool {
+	return self.count >= 0
+}
+
+// Return the number of values in the range, or -1 if the range cannot be iterated over.
+func (self *Range) Len() int {
+	return self.count
+}
+
+// Return the value at the given position in the range, or nil if the position is out of bounds.
+func (self *Range) At(i int) any {
+	if i < 0 || i >= self.count {
+		return nil
+	}
+
+	var value = self.first + float64(i)*self.step
+
+	if self.integer {
+		return int(math.Round(value))
+	}
+
+	return value
+}
+
+// Return whether the given value falls within the range.  If the range has a step, the value must also
+// be one of the values that iterating over the range would yield.
+func (self *Range) Contains(value any) bool {
+	var lo, hi = self.Start, self.End
+	var loExclusive, hiExclusive = false, self.Exclusive
+
+	// ranges that count down (e.g.: 10..1) contain the same values as the equivalent range counting up
+	if c, _ := compareOrdered(lo, hi); c > 0 {
+		lo, hi = hi, lo
+		loExclusive, hiExclusive = hiExclusive, loExclusive
+	}
+
+	if c, ok := compareOrdered(value, lo); !ok || c < 0 || (c == 0 && loExclusive) {
+		return false
+	} else if c, ok := compareOrdered(value, hi); !ok || c > 0 || (c == 0 && hiExclusive) {
+		return false
+	}
+
+	if self.Step != nil && self.IsIterable() {
+		if v, err := stringutil.ConvertToFloat(value); err == nil {
+			var steps = (v - self.first) / self.step
+			return math.Abs(steps-math.Round(steps)) < 1e-9
+		} else {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (self *Range) String() string {
+	var op = `..`
+
+	if self.Exclusive {
+		op = `..<`
+	}
+
+	if self.Step != nil {
+		return fmt.Sprintf("%v%s%v step %v", self.Start, op, self.End, self.Step)
+	}
+
+	return fmt.Sprintf("%v%s%v", self.Start, op, self.End)
+}
+
+func isInteger(value any) bool {
+	_, ok := toBigInt(value)
+	return ok
+}
+
+func isNumber(value any) bool {
+	switch value.(type) {
+	case float32, float64, *Decimal:
+		return true
+	default:
+		return isInteger(value)
+	}
+}
@@ -2,39 +2,43 @@ package scripting
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"regexp"
 	"strings"
 	"sync"
 
 	"github.com/ghetzel/go-stockutil/log"
 	"github.com/ghetzel/go-stockutil/maputil"
-	"github.com/ghetzel/go-stockutil/rxutil"
 	"github.com/ghetzel/go-stockutil/sliceutil"
 	"github.com/ghetzel/go-stockutil/stringutil"
 	"github.com/ghetzel/go-stockutil/typeutil"
 )
 
-var rxInterpolate = regexp.MustCompile(`({[^}]+})`)
 var placeholderVarName = `_`
-var maxInterpolateSequences = 64
+
+// The error returned when a variable that is not defined is referenced in strict mode.
+var ErrUndefinedVariable = errors.New(`undefined variable`)
+
+// The error returned when assigning to (or unsetting) a variable that was declared as a constant.
+var ErrConstant = errors.New(`cannot reassign constant`)
 
 // This represents the name of a module whose commands do not need to be qualified with
 // a "name::" prefix.
 var UnqualifiedModuleName = `core`
 
-type tracer int
-
 type Scope struct {
 	Environment    Commandable
 	SkipPreclear   bool
 	parent         *Scope
 	data           map[string]any
+	constants      map[string]bool
 	isolatedReads  bool
 	isolatedWrites bool
 	mostRecentKey  string
 	evallock       sync.Mutex
+	datalock       sync.RWMutex
 	ctx            *Context
+	strict         bool
 }
 
 func NewScope(parent *Scope) *Scope {
@@ -48,6 +52,16 @@ func NewEphemeralScope(parent *Scope) *Scope {
 	scope := NewScope(nil)
 	scope.isolatedReads = false
 	scope.isolatedWrites = true
+	scope.strict = (parent != nil && parent.IsStrict())
+	return scope
+}
+
+// Create a scope that can read values from its parent, but whose writes always remain
+// local to itself.
+func NewLocalScope(parent *Scope) *Scope {
+	scope := NewScope(parent)
+	scope.isolatedReads = false
+	scope.isolatedWrites = true
 	return scope
 }
 
@@ -68,6 +82,24 @@ func (self *Scope) Env() Commandable {
 	}
 }
 
+// Enable or disable strict mode.  In strict mode, referring to a variable (or a key within one) that
+// is not defined is an error, rather than yielding null.  Strict mode applies to this scope and all of
+// its descendants.
+func (self *Scope) SetStrict(strict bool) {
+	self.strict = strict
+}
+
+// Return whether this scope (or any of its ancestors) is in strict mode.
+func (self *Scope) IsStrict() bool {
+	if self.strict {
+		return true
+	} else if self.parent != nil {
+		return self.parent.IsStrict()
+	} else {
+		return false
+	}
+}
+
 func (self *Scope) Level() int {
 	if self.parent == nil {
 		return 0
@@ -77,11 +109,15 @@ func (self *Scope) Level() int {
 }
 
 func (self *Scope) MostRecentValue() any {
-	if self.mostRecentKey == `` {
+	self.datalock.RLock()
+	var key = self.mostRecentKey
+	self.datalock.RUnlock()
+
+	if key == `` {
 		return nil
 	}
 
-	return self.Get(self.mostRecentKey)
+	return self.Get(key)
 }
 
 // Sets the scope evaluation lock and store the given context.
@@ -118,9 +154,14 @@ func (self *Scope) String() string {
 func (self *Scope) Data() map[string]any {
 	var output = make(map[string]any)
 
+	self.datalock.RLock()
+	defer self.datalock.RUnlock()
+
 	maputil.Walk(self.data, func(value any, path []string, isLeaf bool) error {
 		if resolvable, ok := value.(Resolvable); ok {
 			maputil.DeepSet(output, path, resolvable.Resolve())
+		} else if numeric, ok := asNumericType(value); ok {
+			maputil.DeepSet(output, path, numeric)
 		} else if typeutil.IsArray(value) {
 			maputil.DeepSet(output, path, value)
 			return maputil.SkipDescendants
@@ -143,14 +184,140 @@ func (self *Scope) Declare(key string) {
 	key = self.prepVariableName(key)
 
 	// log.Infof("DECL scope(%d)[%v]", self.Level(), key)
-	maputil.DeepSet(self.data, strings.Split(key, `.`), e)
+	self.datalock.Lock()
+	defer self.datalock.Unlock()
+
+	// redeclaring a constant in the scope that owns it leaves it as-is
+	if self.constants[rootVariableName(key)] {
+		return
+	}
+
+	vivify(self.data, strings.Split(key, `.`), e)
 }
 
+// Set the given key to the given value in the scope that owns it.  Values that cannot be set (see
+// Assign) are logged and otherwise ignored.
 func (self *Scope) Set(key string, value any) {
+	if err := self.Assign(key, value); err != nil {
+		log.Warningf("friendscript: %v", err)
+	}
+}
+
+// Set the given key to the given value in the scope that owns it, returning an error if it cannot be
+// set.  Nested keys (e.g.: "a.b.0.c") will create any intermediate objects and arrays that do not
+// already exist.
+func (self *Scope) Assign(key string, value any) error {
+	key = self.prepVariableName(key)
+
+	if self.IsConstant(key) {
+		return fmt.Errorf("%w $%v", ErrConstant, rootVariableName(key))
+	}
+
+	scope := self.OwnerOf(key)
+
+	// scopes that keep their writes to themselves change their own copy of a variable from a parent
+	// scope when setting a key within it, rather than hiding the rest of it
+	if err := scope.copyOnWrite(key); err != nil {
+		return err
+	}
+
+	if err := scope.set(key, value); err != nil {
+		return err
+	}
+
+	self.datalock.Lock()
+	self.mostRecentKey = key
+	self.datalock.Unlock()
+
+	return nil
+}
+
+// Removes the given key from the scope that owns it.  Nested keys are removed from their
+// containing object, and array indices are removed from their containing array (shifting
+// subsequent elements down.)  Scopes that isolate their writes will only ever remove keys
+// that are local to themselves.
+func (self *Scope) Unset(key string) error {
 	key = self.prepVariableName(key)
+
+	if key == `` || key == placeholderVarName {
+		return nil
+	} else if self.IsConstant(key) {
+		return fmt.Errorf("%w $%v", ErrConstant, rootVariableName(key))
+	}
+
 	scope := self.OwnerOf(key)
-	scope.set(key, value)
+
+	if err := scope.unset(key); err != nil {
+		return err
+	}
+
+	for _, s := range []*Scope{scope, self} {
+		s.datalock.Lock()
+
+		if s.mostRecentKey == key {
+			s.mostRecentKey = ``
+		}
+
+		s.datalock.Unlock()
+	}
+
+	return nil
+}
+
+// Set the given variable to the given value in this scope, and prevent it (and any keys within it) from
+// being assigned to or unset from then on.  Descendant scopes can still declare a variable of their own
+// with the same name (e.g.: a function parameter or loop variable), which hides the constant from them.
+func (self *Scope) SetConstant(key string, value any) error {
+	key = self.prepVariableName(key)
+
+	if key == placeholderVarName {
+		return nil
+	} else if key == `` {
+		return fmt.Errorf("invalid constant name %q", key)
+	} else if strings.Contains(key, `.`) {
+		return fmt.Errorf("cannot declare $%v as a constant: constants must be top-level variables", key)
+	}
+
+	self.datalock.RLock()
+	var exists = self.constants[key]
+	self.datalock.RUnlock()
+
+	if exists {
+		return fmt.Errorf("%w $%v", ErrConstant, key)
+	} else if err := self.set(key, value); err != nil {
+		return err
+	}
+
+	self.datalock.Lock()
+	defer self.datalock.Unlock()
+
+	if self.constants == nil {
+		self.constants = make(map[string]bool)
+	}
+
+	self.constants[key] = true
 	self.mostRecentKey = key
+
+	return nil
+}
+
+// Return whether the given key refers to a constant (or a key within one.)  The nearest scope that
+// has the variable decides; a local variable hides any constant of the same name in its ancestors.
+func (self *Scope) IsConstant(key string) bool {
+	var root = rootVariableName(self.prepVariableName(key))
+
+	for scope := self; scope != nil; scope = scope.parent {
+		if scope.IsLocal(root) {
+			scope.datalock.RLock()
+			defer scope.datalock.RUnlock()
+
+			return scope.constants[root]
+		} else if scope.isolatedReads {
+			break
+		}
+	}
+
+	return false
 }
 
 func (self *Scope) Get(key string, fallback ...any) any {
@@ -171,27 +338,64 @@ func (self *Scope) Get(key string, fallback ...any) any {
 // is consulted for non-nil values (and so on, all the way up the scope chain).
 //
 // If none of the ancestor scopes have a non-nil value at the given key, the current
-// scope becomes the owner of the key and will be returned.
+// scope becomes the owner of the key and will be returned.  Scopes that keep their writes
+// to themselves (see NewLocalScope) own every key set from within them.
 func (self *Scope) OwnerOf(key string) *Scope {
 	if self.isolatedWrites || self.IsLocal(key) {
 		return self
-	} else {
-		_, scope := self.get(key)
-		return scope
 	}
+
+	_, owner := self.get(key)
+
+	// writes never go past a scope that keeps them to itself
+	for scope := self; scope != nil && scope != owner; scope = scope.parent {
+		if scope.isolatedWrites {
+			return scope
+		}
+	}
+
+	return owner
 }
 
-func (self *Scope) IsLocal(key string) bool {
-	if _, ok := maputil.DeepGet(self.data, strings.Split(key, `.`), tracer(0)).(tracer); ok {
+// Return whether the given key has been set or declared in this scope or any scope it can read from,
+// even if its value is null.
+func (self *Scope) Has(key string) bool {
+	key = self.prepVariableName(key)
+
+	if self.IsLocal(key) {
+		return true
+	} else if self.parent != nil && !self.isolatedReads {
+		return self.parent.Has(key)
+	} else {
 		return false
 	}
+}
+
+// Copy the variable that the given nested key is within from a parent scope into this one, if this
+// scope keeps its writes to itself and does not have a variable of that name already.
+func (self *Scope) copyOnWrite(key string) error {
+	var root = rootVariableName(key)
+
+	if !self.isolatedWrites || self.isolatedReads || self.parent == nil || root == key || self.IsLocal(root) {
+		return nil
+	} else if value, _ := self.parent.get(root); !IsEmpty(value) {
+		return self.set(root, value)
+	}
+
+	return nil
+}
+
+func (self *Scope) IsLocal(key string) bool {
+	self.datalock.RLock()
+	defer self.datalock.RUnlock()
 
-	return true
+	_, ok := deepGet(self.data, strings.Split(key, `.`))
+	return ok
 }
 
-func (self *Scope) set(key string, value any) {
+func (self *Scope) set(key string, value any) error {
 	if key == `` || key == placeholderVarName {
-		return
+		return nil
 	}
 
 	if IsEmpty(value) {
@@ -199,7 +403,7 @@ func (self *Scope) set(key string, value any) {
 	} else if v, err := exprToValue(value); err == nil {
 		value = v
 	} else {
-		log.Panicf("Cannot set %v: %v", key, err)
+		return fmt.Errorf("cannot set %v: %v", key, err)
 	}
 
 	value = intIfYouCan(value)
@@ -213,21 +417,73 @@ func (self *Scope) set(key string, value any) {
 	// 	}
 	// }
 
-	maputil.DeepSet(self.data, strings.Split(key, `.`), value)
+	self.datalock.Lock()
+	defer self.datalock.Unlock()
+
+	if _, err := vivify(self.data, strings.Split(key, `.`), value); err != nil {
+		return fmt.Errorf("cannot set %v: %v", key, err)
+	}
+
+	return nil
+}
+
+func (self *Scope) unset(key string) error {
+	var parts = strings.Split(key, `.`)
+	var last = parts[len(parts)-1]
+
+	self.datalock.Lock()
+	defer self.datalock.Unlock()
+
+	if len(parts) == 1 {
+		delete(self.data, key)
+		return nil
+	}
+
+	var parentPath = parts[:len(parts)-1]
+	var container, _ = deepGet(self.data, parentPath)
+
+	if IsEmpty(container) {
+		return nil
+	} else if typeutil.IsMap(container) {
+		return maputil.Delete(container, last)
+	} else if typeutil.IsArray(container) {
+		var items = sliceutil.Sliceify(container)
+
+		if i, err := stringutil.ConvertToInteger(last); err == nil {
+			// negative indices count backwards from the end, same as when reading them
+			if i < 0 {
+				i += int64(len(items))
+			}
+
+			if i >= 0 && int(i) < len(items) {
+				items = append(items[:i], items[i+1:]...)
+				vivify(self.data, parentPath, items)
+			}
+
+			return nil
+		} else {
+			return fmt.Errorf("cannot unset %v: array index %q is not an integer", key, last)
+		}
+	} else {
+		return fmt.Errorf("cannot unset %v: %v is not an object or array", key, strings.Join(parentPath, `.`))
+	}
 }
 
 func (self *Scope) get(key string, fallback ...any) (any, *Scope) {
 	key = self.prepVariableName(key)
 
-	v := maputil.DeepGet(self.data, strings.Split(key, `.`))
+	self.datalock.RLock()
+	v, _ := deepGet(self.data, strings.Split(key, `.`))
+
+	// return *copies* of compound types
+	if typeutil.IsMap(v) {
+		v = maputil.DeepCopyStruct(v)
+	} else if typeutil.IsArray(v) {
+		v = sliceutil.Sliceify(v)
+	}
+	self.datalock.RUnlock()
 
 	if !IsEmpty(v) {
-		// return *copies* of compound types
-		if typeutil.IsMap(v) {
-			v = maputil.DeepCopyStruct(v)
-		} else if typeutil.IsArray(v) {
-			v = sliceutil.Sliceify(v)
-		}
 
 		// fmt.Printf("SGET scope(%d)[%v] -> %T(%v)\n", self.Level(), key, v, v)
 		return v, self
@@ -247,29 +503,24 @@ func (self *Scope) get(key string, fallback ...any) (any, *Scope) {
 	}
 }
 
+// Replace all interpolation sequences (e.g.: "{name}", "{name|upper}", "{name ?? 'friend'}") in the
+// given string with the values they refer to.  Sequences that cannot be evaluated are replaced with
+// an empty string; use Expand to find out why.
 func (self *Scope) Interpolate(in string) string {
-	for range maxInterpolateSequences {
-		if match := rxutil.Match(rxInterpolate, in); match != nil {
-			seq := match.Group(1)
-			seq = stringutil.Unwrap(seq, `{`, `}`)
-
-			value := self.Get(seq)
-
-			if IsEmpty(value) {
-				value = ``
-			}
-
-			in = match.ReplaceGroup(1, typeutil.String(value))
-		} else {
-			break
-		}
-	}
+	out, _ := self.Expand(in)
+	return out
+}
 
-	return in
+// Return the top-level variable that the given (prepared) key is within (e.g.: "a" for "a.b.0").
+func rootVariableName(key string) string {
+	root, _, _ := strings.Cut(key, `.`)
+	return root
 }
 
 func (self *Scope) prepVariableName(key string) string {
 	key = strings.TrimPrefix(key, `$`)
+	key = normalizeReference(key)
+	key = strings.ReplaceAll(key, `?.`, `.`)
 
 	return key
 }
@@ -1,6 +1,8 @@
 package scripting
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/ghetzel/testify/require"
@@ -21,6 +23,70 @@ func TestInterpolate(t *testing.T) {
 	assert.Equal(`test test 1 2 3 15155870`, scope.Interpolate(`test test {x} {y} {z} {a}`))
 }
 
+func TestInterpolateFilters(t *testing.T) {
+	assert := require.New(t)
+	scope := NewScope(nil)
+	scope.Set(`name`, `friend`)
+	scope.Set(`items`, []any{`a`, `b`, `c`})
+	scope.Set(`price`, 4.5)
+	scope.Set(`cost`, MustDecimal(`19.995`))
+	scope.Set(`user`, map[string]any{`name`: `tester`})
+	scope.Declare(`nothing`)
+
+	for in, out := range map[string]string{
+		`{name|upper}`:                    `FRIEND`,
+		`{name | upper | lower}`:          `friend`,
+		`{items|join:", "}`:               `a, b, c`,
+		`{items|join}`:                    `a,b,c`,
+		`{items|join:'|'}`:                `a|b|c`,
+		`{items|length}`:                  `3`,
+		`{items|last}`:                    `c`,
+		`{price|printf:"%.2f"}`:           `4.50`,
+		`{cost|printf:"%.2f"}`:            `20.00`,
+		`{name|printf:"%s and %s",$name}`: `friend and friend`,
+		`{user.name ?? "anonymous"}`:      `tester`,
+		`{user.email ?? "anonymous"}`:     `anonymous`,
+		`{user.email ?? user.name}`:       `tester`,
+		`{nope ?? nada ?? 'x' | upper}`:   `X`,
+		`{nothing|default:'empty'}`:       `empty`,
+		`{name|replace:"f","F"}`:          `Friend`,
+		`{user|keys|json}`:                `["name"]`,
+		`{"}" ?? 1}`:                      `}`,
+		`{nope}`:                          ``,
+		`{}`:                              `{}`,
+	} {
+		actual, err := scope.Expand(in)
+		assert.NoError(err, in)
+		assert.Equal(out, actual, in)
+	}
+
+	_, err := scope.Expand(`{name|nope}`)
+	assert.Error(err)
+
+	_, err = scope.Expand(`{name|replace:"f"}`)
+	assert.Error(err)
+
+	RegisterFilter(`shout`, func(value any, args ...any) (any, error) {
+		return fmt.Sprintf("%v!", value), nil
+	})
+
+	defer RegisterFilter(`shout`, nil)
+
+	assert.Equal(`friend!`, scope.Interpolate(`{name|shout}`))
+
+	// strict mode: undefined variables are errors, but defaults and declared variables are allowed
+	child := NewScope(scope)
+	scope.SetStrict(true)
+	assert.True(child.IsStrict())
+
+	_, err = child.Expand(`hello {nope}`)
+	assert.Error(err)
+
+	actual, err := child.Expand(`hello {nope ?? name} {nothing}`)
+	assert.NoError(err)
+	assert.Equal(`hello friend `, actual)
+}
+
 func TestIsEmpty(t *testing.T) {
 	assert := require.New(t)
 
@@ -33,3 +99,145 @@ func TestIsEmpty(t *testing.T) {
 		`a`: `thing`,
 	}))
 }
+
+func TestUnset(t *testing.T) {
+	assert := require.New(t)
+	parent := NewScope(nil)
+	parent.Set(`a`, 1)
+	parent.Set(`b`, map[string]any{`c`: 2, `d`: 3})
+
+	child := NewScope(parent)
+	assert.NoError(child.Unset(`$b.c`))
+	assert.Equal(map[string]any{`b`: map[string]any{`d`: 3}, `a`: 1}, parent.Data())
+
+	// writes to isolated scopes never affect the parent
+	isolated := NewIsolatedScope(parent)
+	isolated.Set(`a`, 5)
+	assert.NoError(isolated.Unset(`a`))
+	assert.Nil(isolated.Get(`a`))
+	assert.Equal(1, parent.Get(`a`))
+
+	assert.NoError(child.Unset(`b`))
+	assert.Nil(parent.Get(`b`))
+	assert.Nil(parent.MostRecentValue())
+	assert.Equal(map[string]any{`a`: 1}, parent.Data())
+
+	// negative indices count backwards from the end of arrays, and out-of-range ones are ignored
+	parent.Set(`list`, []any{1, 2, 3, 4})
+	assert.NoError(child.Unset(`list.-1`))
+	assert.Equal([]any{1, 2, 3}, parent.Get(`list`))
+	assert.NoError(child.Unset(`list[-3]`))
+	assert.Equal([]any{2, 3}, parent.Get(`list`))
+	assert.NoError(child.Unset(`list.-3`))
+	assert.Equal([]any{2, 3}, parent.Get(`list`))
+}
+
+func TestNestedPaths(t *testing.T) {
+	assert := require.New(t)
+	scope := NewScope(nil)
+
+	assert.NoError(scope.Assign(`a.list.1.name`, `x`))
+	assert.Equal([]any{nil, map[string]any{`name`: `x`}}, scope.Get(`a.list`))
+	assert.Equal(`x`, scope.Get(`a.list.-1.name`))
+	assert.Equal(`x`, scope.Get(`a[list][-1]['name']`))
+	assert.Equal([]any{nil}, scope.Get(`a.list.:1`))
+	assert.True(scope.Has(`a.list.0`))
+	assert.False(scope.Has(`a.list.2`))
+
+	assert.NoError(scope.Assign(`a.list.-2`, 1))
+	assert.Equal(1, scope.Get(`a.list.0`))
+	assert.Error(scope.Assign(`a.list.-3`, 1))
+	assert.Error(scope.Assign(`a.list.0:1`, 1))
+
+	// arrays are only padded so far past their end
+	assert.NoError(scope.Assign(`a.list.1026`, 1))
+	assert.Len(scope.Get(`a.list`), 1027)
+	assert.Error(scope.Assign(`a.list.100000000`, 1))
+	assert.Len(scope.Get(`a.list`), 1027)
+}
+
+func TestScopeConcurrentAccess(t *testing.T) {
+	assert := require.New(t)
+	parent := NewScope(nil)
+	parent.Set(`shared.count`, 0)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			child := NewScope(parent)
+			child.Declare(`local`)
+
+			for j := 0; j < 100; j++ {
+				child.Set(`local`, j)
+				child.Set(fmt.Sprintf("shared.worker%d", i), j)
+				child.Get(`shared`)
+				parent.Data()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i := 0; i < 16; i++ {
+		assert.Equal(99, parent.Get(fmt.Sprintf("shared.worker%d", i)))
+	}
+
+	assert.Nil(parent.Get(`local`))
+}
+
+func TestConstants(t *testing.T) {
+	assert := require.New(t)
+	parent := NewScope(nil)
+	assert.NoError(parent.SetConstant(`$a`, map[string]any{`b`: 1}))
+	assert.Error(parent.SetConstant(`a`, 2))
+	assert.Error(parent.SetConstant(`c.d`, 2))
+
+	// constants (and the keys within them) cannot be changed from any scope that can read them
+	child := NewScope(parent)
+	local := NewLocalScope(parent)
+
+	for _, scope := range []*Scope{parent, child, local} {
+		assert.True(scope.IsConstant(`a.b`))
+		assert.EqualError(scope.Assign(`a`, 2), `cannot reassign constant $a`)
+		assert.Error(scope.Assign(`a.b`, 2))
+		assert.Error(scope.Unset(`a.b`))
+	}
+
+	parent.Declare(`a`)
+	assert.Equal(map[string]any{`b`: 1}, parent.Get(`a`))
+
+	// variables declared by descendant scopes hide constants of the same name
+	child.Declare(`a`)
+	assert.False(child.IsConstant(`a`))
+	assert.NoError(child.Assign(`a`, 3))
+	assert.Equal(3, child.Get(`a`))
+	assert.Equal(map[string]any{`b`: 1}, parent.Get(`a`))
+
+	isolated := NewIsolatedScope(parent)
+	assert.False(isolated.IsConstant(`a`))
+	assert.NoError(isolated.Assign(`a`, 4))
+}
+
+func TestLocalScopeWrites(t *testing.T) {
+	assert := require.New(t)
+	parent := NewScope(nil)
+	parent.Set(`config`, map[string]any{`name`: `friend`, `retries`: 1})
+	parent.Set(`count`, 1)
+
+	// writes from anywhere within a local scope stay in it, and keys set within a parent's variable
+	// change a copy of the whole thing
+	local := NewLocalScope(parent)
+	child := NewScope(local)
+
+	assert.NoError(child.Assign(`count`, 2))
+	assert.NoError(child.Assign(`config.retries`, 3))
+	assert.Equal(map[string]any{`name`: `friend`, `retries`: 3}, local.Get(`config`))
+	assert.Equal(2, local.Get(`count`))
+	assert.Equal(map[string]any{`name`: `friend`, `retries`: 1}, parent.Get(`config`))
+	assert.Equal(1, parent.Get(`count`))
+}
@@ -3,10 +3,12 @@ package scripting
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/fatih/structs"
 	"github.com/ghetzel/go-stockutil/maputil"
@@ -32,10 +34,18 @@ type mappable interface {
 type runtime struct {
 	scope    *Scope
 	filename string
+	labels   map[uint32]int
+	origin   *Friendscript
 }
 
 type nodeFunc func(node *node32, depth int)
 
+// values are converted using their JSON tags; this is set once (rather than as values are converted)
+// since scripts may set variables from several goroutines at once
+func init() {
+	maputil.UnmarshalStructTag = `json`
+}
+
 func Parse(input string) (*Friendscript, error) {
 	structs.DefaultTagName = `json`
 
@@ -50,6 +60,10 @@ func Parse(input string) (*Friendscript, error) {
 	fs.Init()
 
 	if err := fs.Parse(); err == nil {
+		if err := fs.resolveLabels(fs.AST(), nil); err != nil {
+			return nil, fs.errorWithContext(err)
+		}
+
 		return fs, nil
 	} else if strings.HasPrefix(strings.TrimSpace(err.Error()), `parse error near`) {
 		return nil, fs.errorWithContext(err)
@@ -62,14 +76,19 @@ func LoadFromFile(filename string) (*Friendscript, error) {
 	if file, err := os.Open(filename); err == nil {
 		defer file.Close()
 
-		if data, err := ioutil.ReadAll(file); err == nil {
-			if fs, err := Parse(string(data)); err == nil {
-				fs.filename = filename
+		return LoadFromReader(filename, file)
+	} else {
+		return nil, err
+	}
+}
 
-				return fs, nil
-			} else {
-				return nil, err
-			}
+// Parse a script from the given reader, associating it with the given filename.
+func LoadFromReader(filename string, reader io.Reader) (*Friendscript, error) {
+	if data, err := ioutil.ReadAll(reader); err == nil {
+		if fs, err := Parse(string(data)); err == nil {
+			fs.filename = filename
+
+			return fs, nil
 		} else {
 			return nil, err
 		}
@@ -82,6 +101,27 @@ func (self *Friendscript) SetScope(scope *Scope) {
 	self.runtime.scope = scope
 }
 
+// Return a copy of this script that shares its parsed syntax, but which is evaluated in the given scope.
+// Blocks retrieved from the copy (or moved to it with Block.InScript) can be evaluated at the same time
+// as the original script is.
+func (self *Friendscript) Fork(scope *Scope) *Friendscript {
+	var fork = *self
+
+	fork.runtime.scope = scope
+	fork.runtime.origin = self.Origin()
+
+	return &fork
+}
+
+// Return the script that this script was forked from, or the script itself if it is not a fork.
+func (self *Friendscript) Origin() *Friendscript {
+	if self.runtime.origin != nil {
+		return self.runtime.origin
+	}
+
+	return self
+}
+
 func (self *Friendscript) Filename() string {
 	return self.runtime.filename
 }
@@ -90,10 +130,6 @@ func (self *Friendscript) Scope() *Scope {
 	return self.runtime.scope
 }
 
-func (self *Friendscript) lcp() string {
-	return stringutil.LongestCommonPrefix(strings.Split(self.Buffer, "\n"))
-}
-
 func (self *Friendscript) errorWithContext(err error) error {
 	raw := strings.TrimSpace(err.Error())
 
@@ -107,7 +143,7 @@ func (self *Friendscript) errorWithContext(err error) error {
 		var message strings.Builder
 		message.WriteString(fmt.Sprintf("Syntax error on line %d: %v\n", line, match.Group(`message`)))
 		message.WriteString("\n")
-		lcp := stringutil.LongestCommonPrefix(lines)
+		lcp := commonIndent(lines)
 
 		for i := lbound; i < ubound; i++ {
 			message.WriteString(fmt.Sprintf("%- 4d | %v\n", i, strings.TrimPrefix(lines[i], lcp)))
@@ -125,6 +161,81 @@ func (self *Friendscript) errorWithContext(err error) error {
 	}
 }
 
+// Resolve the labels given to "break" and "continue" statements into the number of loops they exit,
+// returning an error if a label does not belong to one of the loops the statement is inside of.  Loops
+// in functions and event handlers cannot be exited from outside of them.
+func (self *Friendscript) resolveLabels(node *node32, loops []string) error {
+	if self.labels == nil {
+		self.labels = make(map[uint32]int)
+	}
+
+	for ; node != nil; node = node.next {
+		var inner = loops
+
+		switch node.rule() {
+		case ruleLoop:
+			var label string
+
+			if labelNode := node.subnode(ruleLoopLabel); labelNode != nil {
+				label = self.s(labelNode.subnode(ruleIdentifier))
+			}
+
+			inner = append(append([]string{}, loops...), label)
+
+		case ruleFunctionDefinition, ruleEventHandler:
+			inner = nil
+
+		case ruleFlowControlLabel:
+			var label = self.s(node)
+			var found bool
+
+			for i := len(loops) - 1; i >= 0; i-- {
+				if loops[i] == label {
+					self.labels[node.begin] = len(loops) - i
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				line, symbol := self.position(node.begin)
+				return fmt.Errorf("unknown loop label %q (line %d symbol %d)", label, line, symbol)
+			}
+		}
+
+		if err := self.resolveLabels(node.up, inner); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Return the number of loops exited by the "break" or "continue" statement with the given label.
+func (self *Friendscript) labelLevels(label *node32) int {
+	if levels, ok := self.labels[label.begin]; ok {
+		return levels
+	}
+
+	return 1
+}
+
+// Return the line and column (both starting from 1) of the given offset in the script.
+func (self *Friendscript) position(offset uint32) (int, int) {
+	var line, symbol = 1, 1
+
+	for _, r := range self.buffer[:offset] {
+		if r == '\n' {
+			line += 1
+			symbol = 1
+		} else {
+			symbol += 1
+		}
+	}
+
+	return line, symbol
+}
+
 // Return all top-level blocks in the current script.
 func (self *Friendscript) Blocks() []*Block {
 	blocks := make([]*Block, 0)
@@ -134,7 +245,7 @@ func (self *Friendscript) Blocks() []*Block {
 		// fmt.Printf("[% 2d] %v%v %q\n", depth, strings.Repeat(`  `, depth), node, self.s(node))
 
 		switch node.rule() {
-		case ruleStatementBlock:
+		case ruleStatementBlock, ruleEventHandler, ruleFlowControlWord:
 			blocks = append(blocks, &Block{
 				friendscript: self,
 				node:         node,
@@ -147,9 +258,10 @@ func (self *Friendscript) Blocks() []*Block {
 
 func (self *Friendscript) s(node *node32) string {
 	if node != nil {
+		// token positions are offsets into the parsed runes, not the bytes of the original buffer
 		begin := int(node.token32.begin)
 		end := int(node.token32.end)
-		return self.Buffer[begin:end]
+		return string(self.buffer[begin:end])
 	} else {
 		return ``
 	}
@@ -177,6 +289,34 @@ func (self *node32) firstChild(anyOf ...pegRule) *node32 {
 	return self.firstN(0, anyOf...)
 }
 
+// Return the immediate children of this node matching any of the given rules.  Unlike children(),
+// this does not include the node's siblings (or their children.)
+func (self *node32) subnodes(anyOf ...pegRule) []*node32 {
+	results := make([]*node32, 0)
+
+	for node := self.up; node != nil; node = node.next {
+		switch node.rule() {
+		case rule_, rule__:
+			continue
+		}
+
+		if len(anyOf) == 0 || sliceutil.Contains(anyOf, node.rule()) {
+			results = append(results, node)
+		}
+	}
+
+	return results
+}
+
+// Return the first immediate child of this node matching any of the given rules.
+func (self *node32) subnode(anyOf ...pegRule) *node32 {
+	if results := self.subnodes(anyOf...); len(results) > 0 {
+		return results[0]
+	}
+
+	return nil
+}
+
 func (self *node32) find(anyOf ...pegRule) []*node32 {
 	return self.findN(-1, anyOf...)
 }
@@ -310,13 +450,15 @@ func intIfYouCan(in any) any {
 
 // if the input is a struct, convert it into a map
 func mapifyStruct(in any) any {
-	maputil.UnmarshalStructTag = `json`
-
 	if m, ok := in.(mappable); ok {
 		return m.ToMap()
 
 	} else if b, ok := in.([]byte); ok {
 		return b
+	} else if isNumericType(in) {
+		return in
+	} else if _, ok := in.(time.Time); ok {
+		return in
 	} else if typeutil.IsArray(in) {
 		var elems = make([]any, sliceutil.Len(in))
 
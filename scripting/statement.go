@@ -2,10 +2,10 @@ package scripting
 
 import (
 	"fmt"
-	"go/constant"
-	"go/token"
+	"math/big"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/ghetzel/go-stockutil/log"
 	"github.com/ghetzel/go-stockutil/stringutil"
@@ -29,6 +29,15 @@ const (
 	LoopStatement
 	FlowControlStatement
 	NoOpStatement
+	FunctionStatement
+	TryCatchStatement
+	MatchStatement
+	ParallelStatement
+	TimeoutStatement
+	RetryStatement
+	DeferStatement
+	TransactionStatement
+	ConstantStatement
 )
 
 func (self StatementType) String() string {
@@ -49,6 +58,24 @@ func (self StatementType) String() string {
 		return `FlowControlStatement`
 	case NoOpStatement:
 		return `NoOpStatement`
+	case FunctionStatement:
+		return `FunctionStatement`
+	case TryCatchStatement:
+		return `TryCatchStatement`
+	case MatchStatement:
+		return `MatchStatement`
+	case ParallelStatement:
+		return `ParallelStatement`
+	case TimeoutStatement:
+		return `TimeoutStatement`
+	case RetryStatement:
+		return `RetryStatement`
+	case DeferStatement:
+		return `DeferStatement`
+	case TransactionStatement:
+		return `TransactionStatement`
+	case ConstantStatement:
+		return `ConstantStatement`
 	default:
 		return `UnknownStatement`
 	}
@@ -86,49 +113,50 @@ func (self *Statement) raw(node *node32) string {
 }
 
 func (self *Statement) s(node *node32) string {
+	out, _ := self.parseString(node)
+	return out
+}
+
+// Return the value of the given string (or identifier) node, interpolating it if necessary.
+func (self *Statement) parseString(node *node32) (string, error) {
 	if node != nil {
 		raw := self.raw(node)
 
 		if child := node.firstChild(); child != nil {
 			switch child.rule() {
 			case ruleIdentifier:
-				return raw
+				return raw, nil
 
 			case ruleStringLiteral:
 				raw = strings.TrimPrefix(raw, `'`)
 				raw = strings.TrimSuffix(raw, `'`)
-				return raw
+				return unescapeString(raw, false), nil
 
 			case ruleStringInterpolated:
-				// use Golang's own string literal interpreter to handle escape sequences
-				// and unicode code points in the incoming string
-				raw = constant.StringVal(constant.MakeFromLiteral(raw, token.STRING, 0))
-
-				return self.Script().Scope().Interpolate(raw)
-
-			case ruleTriquote:
-				raw = self.raw(child.firstChild(ruleTriquoteBody))
-				lcp := self.Script().lcp()
-
-				if lcp != `` {
-					lines := strings.Split(raw, "\n")
+				raw = strings.TrimPrefix(raw, `"`)
+				raw = strings.TrimSuffix(raw, `"`)
 
-					for i, line := range lines {
-						lines[i] = strings.TrimPrefix(line, lcp)
-					}
+				// escaped braces are swapped out for placeholders before interpolating so that
+				// they come out the other side as literal braces
+				raw = unescapeString(raw, true)
 
-					raw = strings.Join(lines, "\n")
+				if expanded, err := self.Script().Scope().Expand(raw); err == nil {
+					return restoreBraces(expanded), nil
+				} else {
+					var ctx = self.SourceContext()
+					return restoreBraces(expanded), NewContextError(ctx, fmt.Errorf("line %d: %v", ctx.Line(), err))
 				}
 
-				return raw
+			case ruleTriquote:
+				return dedent(self.raw(child.firstChild(ruleTriquoteBody))), nil
 
 			default:
-				return raw
+				return raw, nil
 			}
 		}
 	}
 
-	return ``
+	return ``, nil
 }
 
 func (self *Statement) IsType(stype ...StatementType) bool {
@@ -150,16 +178,34 @@ func (self *Statement) Type() StatementType {
 			return NoOpStatement
 		case ruleAssignment:
 			return AssignmentStatement
+		case ruleConstantDeclaration:
+			return ConstantStatement
 		case ruleDirective:
 			return DirectiveStatement
 		case ruleExpression:
 			return ExpressionStatement
 		case ruleLoop:
 			return LoopStatement
-		case ruleCommand, ruleInlineCommand:
+		case ruleCommand, ruleInlineCommand, ruleUndoableCommand:
 			return CommandStatement
 		case ruleConditional:
 			return ConditionalStatement
+		case ruleFunctionDefinition:
+			return FunctionStatement
+		case ruleTryCatch:
+			return TryCatchStatement
+		case ruleMatchStatement:
+			return MatchStatement
+		case ruleParallelStatement:
+			return ParallelStatement
+		case ruleTimeoutStatement:
+			return TimeoutStatement
+		case ruleRetryStatement:
+			return RetryStatement
+		case ruleDeferStatement:
+			return DeferStatement
+		case ruleTransactionStatement:
+			return TransactionStatement
 		}
 	}
 
@@ -167,7 +213,7 @@ func (self *Statement) Type() StatementType {
 }
 
 func (self *Statement) Assignment() *Assignment {
-	if self.Type() == AssignmentStatement {
+	if self.IsType(AssignmentStatement, ConstantStatement) {
 		return self.makeAssignment(self.node)
 	}
 
@@ -186,9 +232,16 @@ func (self *Statement) Directive() *Directive {
 
 func (self *Statement) Command() *Command {
 	if self.Type() == CommandStatement {
+		var node = self.node
+
+		// commands that say how to undo them are the first part of the statement
+		if node.rule() == ruleUndoableCommand {
+			node = node.subnode(ruleCommand)
+		}
+
 		return &Command{
 			statement: self,
-			node:      self.node,
+			node:      node,
 		}
 	}
 
@@ -215,15 +268,95 @@ func (self *Statement) Loop() *Loop {
 	return nil
 }
 
+func (self *Statement) Function() *Function {
+	if self.Type() == FunctionStatement {
+		return &Function{
+			statement: self,
+		}
+	}
+
+	return nil
+}
+
+func (self *Statement) TryCatch() *TryCatch {
+	if self.Type() == TryCatchStatement {
+		return &TryCatch{
+			statement: self,
+		}
+	}
+
+	return nil
+}
+
+func (self *Statement) Match() *Match {
+	if self.Type() == MatchStatement {
+		return &Match{
+			statement: self,
+		}
+	}
+
+	return nil
+}
+
+func (self *Statement) Parallel() *Parallel {
+	if self.Type() == ParallelStatement {
+		return &Parallel{
+			statement: self,
+		}
+	}
+
+	return nil
+}
+
+func (self *Statement) Timeout() *Timeout {
+	if self.Type() == TimeoutStatement {
+		return &Timeout{
+			statement: self,
+		}
+	}
+
+	return nil
+}
+
+func (self *Statement) Retry() *Retry {
+	if self.Type() == RetryStatement {
+		return &Retry{
+			statement: self,
+		}
+	}
+
+	return nil
+}
+
+func (self *Statement) Defer() *Defer {
+	if self.Type() == DeferStatement {
+		return &Defer{
+			statement: self,
+		}
+	}
+
+	return nil
+}
+
+func (self *Statement) Transaction() *Transaction {
+	if self.Type() == TransactionStatement {
+		return &Transaction{
+			statement: self,
+		}
+	}
+
+	return nil
+}
+
 func (self *Statement) parseObject(node *node32) (map[string]any, error) {
 	output := make(map[string]any)
 
 	if node != nil {
 		if pairs := node.children(ruleKeyValuePair); len(pairs) > 0 {
 			for _, pair := range pairs {
-				key := self.s(pair.first(ruleKey))
-
-				if value, err := self.parseValue(pair.first(ruleKValue)); err == nil {
+				if key, err := self.parseString(pair.first(ruleKey)); err != nil {
+					return nil, err
+				} else if value, err := self.parseValue(pair.first(ruleKValue)); err == nil {
 					output[key] = value
 				} else {
 					return nil, err
@@ -235,6 +368,27 @@ func (self *Statement) parseObject(node *node32) (map[string]any, error) {
 	return output, nil
 }
 
+// Return the range described by the given node.
+func (self *Statement) parseRange(node *node32) (*Range, error) {
+	var bounds = make([]any, 3)
+
+	if node == nil || node.rule() != ruleRange {
+		return nil, fmt.Errorf("not a range node")
+	}
+
+	for i, rule := range []pegRule{ruleRangeStart, ruleRangeEnd, ruleRangeStep} {
+		if boundNode := node.subnode(rule); boundNode != nil {
+			if value, err := NewExpression(self, boundNode.subnode(ruleExpression)).Value(); err == nil {
+				bounds[i] = value
+			} else {
+				return nil, err
+			}
+		}
+	}
+
+	return NewRange(bounds[0], bounds[1], bounds[2], (node.subnode(ruleRANGEEXCL) != nil))
+}
+
 func (self *Statement) parseRegex(node *node32) (*regexp.Regexp, error) {
 	if node.rule() == ruleRegularExpression {
 		rx := self.raw(node)
@@ -309,6 +463,9 @@ func (self *Statement) parseValue(node *node32) (any, error) {
 	case ruleScalarType:
 		value = value.first(
 			ruleNullValue,
+			ruleTimestamp,
+			ruleDuration,
+			ruleDecimal,
 			ruleInteger,
 			ruleFloat,
 			ruleBoolean,
@@ -332,13 +489,27 @@ func (self *Statement) parseValue(node *node32) (any, error) {
 		return new(emptyValue), nil
 
 	case ruleInteger:
-		return stringutil.MustInteger(self.raw(value)), nil
+		return parseIntegerLiteral(self.raw(value))
 
 	case ruleFloat:
-		return stringutil.MustFloat(self.raw(value)), nil
+		// floats without a fractional part are integers
+		if raw := self.raw(value); strings.Contains(raw, `.`) {
+			return stringutil.MustFloat(raw), nil
+		} else {
+			return parseIntegerLiteral(raw)
+		}
+
+	case ruleDecimal:
+		return NewDecimal(self.raw(value))
+
+	case ruleDuration:
+		return time.ParseDuration(self.raw(value))
+
+	case ruleTimestamp:
+		return parseTimestampLiteral(self.raw(value))
 
 	case ruleString:
-		return self.s(value), nil
+		return self.parseString(value)
 
 	case ruleArray:
 		return self.parseArray(value)
@@ -351,22 +522,59 @@ func (self *Statement) parseValue(node *node32) (any, error) {
 	}
 }
 
+// Return the scope key that the given variable refers to (e.g.: "$a.b[1]" is "a.b.1").  Slices and
+// safe navigation are not permitted, since they cannot be assigned to.
 func (self *Statement) resolveVariableKey(node *node32) (string, error) {
+	return self.variableKey(node, false)
+}
+
+// Return the scope key that the given variable refers to.  If the variable is only being read, it may
+// contain slices (which are represented as a "start:end" key) and safe navigation (represented as
+// "?." instead of ".").
+func (self *Statement) variableKey(node *node32, reading bool) (string, error) {
 	if node.rule() == ruleVariable {
 		child := node.firstChild()
 		keyparts := make([]string, 0)
 
 		switch child.rule() {
 		case ruleVariableNameSequence:
-			nameNodes := child.children(ruleVariableName)
+			for _, varpart := range child.subnodes(ruleVariableName, ruleOPTDOT) {
+				if varpart.rule() == ruleOPTDOT {
+					if !reading {
+						return ``, fmt.Errorf("cannot assign using safe navigation (%v)", self.raw(node))
+					}
+
+					keyparts[len(keyparts)-1] += `?`
+					continue
+				}
+
+				keyparts = append(keyparts, self.raw(varpart.subnode(ruleIdentifier)))
 
-			for _, varpart := range nameNodes {
-				identNode := varpart.firstChild(ruleIdentifier)
-				ident := self.raw(identNode)
-				keyparts = append(keyparts, ident)
+				for _, index := range varpart.subnodes(ruleVariableIndex) {
+					if slice := index.subnode(ruleVariableSlice); slice != nil {
+						if !reading {
+							return ``, fmt.Errorf("cannot assign to a slice (%v)", self.raw(node))
+						}
+
+						// slices are represented as a "start:end" key, either of which may be empty
+						bounds := make([]string, 2)
+
+						for i, bound := range []pegRule{ruleVariableSliceStart, ruleVariableSliceEnd} {
+							if boundNode := slice.subnode(bound); boundNode != nil {
+								if value, err := NewExpression(self, boundNode.subnode(ruleExpression)).Value(); err == nil {
+									if n, ok := toBigInt(value); ok && n.IsInt64() {
+										bounds[i] = n.String()
+									} else {
+										return ``, fmt.Errorf("slice bounds must be integers, got %T", value)
+									}
+								} else {
+									return ``, err
+								}
+							}
+						}
 
-				if index := identNode.firstChild(ruleVariableIndex); index != nil {
-					if indexNode := index.firstChild(ruleExpression); indexNode != nil {
+						keyparts = append(keyparts, strings.Join(bounds, `:`))
+					} else if indexNode := index.subnode(ruleExpression); indexNode != nil {
 						if indexValue, err := NewExpression(self, indexNode).Value(); err == nil {
 							keyparts = append(keyparts, fmt.Sprintf("%v", indexValue))
 						} else {
@@ -392,33 +600,93 @@ func (self *Statement) resolveVariableKey(node *node32) (string, error) {
 }
 
 func (self *Statement) resolveVariable(node *node32) (any, error) {
-	if key, err := self.resolveVariableKey(node); err == nil {
+	if key, err := self.variableKey(node, true); err == nil {
 		if key == `` {
 			return nil, nil
 		} else {
-			return self.Script().Scope().Get(key), nil
+			if value, err := self.Script().Scope().resolveReference(key, true); err == nil {
+				return value, nil
+			} else {
+				var ctx = self.SourceContext()
+				return nil, NewContextError(ctx, fmt.Errorf("line %d: %w", ctx.Line(), err))
+			}
 		}
 	} else {
-		return nil, fmt.Errorf("expected variable, got %v", node)
+		return nil, err
 	}
 }
 
+// Return the variable(s) described by the given assignment target.
+func (self *Statement) parseTarget(node *node32) (*Target, error) {
+	var target = new(Target)
+	var restNode *node32
+
+	if node == nil {
+		return nil, fmt.Errorf("missing assignment target")
+	} else if objectNode := node.subnode(ruleObjectTarget); objectNode != nil {
+		target.Type = ObjectTarget
+		restNode = objectNode.subnode(ruleRestVariable)
+
+		for _, field := range objectNode.subnodes(ruleObjectTargetField) {
+			if key, err := self.parseString(field.subnode(ruleKey)); err == nil {
+				var name = key
+
+				// fields without a variable are assigned to a variable with the same name as the key
+				if varNode := field.subnode(ruleVariable); varNode != nil {
+					if n, err := self.resolveVariableKey(varNode); err == nil {
+						name = n
+					} else {
+						return nil, fmt.Errorf("unable to resolve variable name: %v", err)
+					}
+				}
+
+				target.Keys = append(target.Keys, key)
+				target.Variables = append(target.Variables, name)
+			} else {
+				return nil, err
+			}
+		}
+	} else if arrayNode := node.subnode(ruleArrayTarget); arrayNode != nil {
+		target.Type = ArrayTarget
+		restNode = arrayNode.subnode(ruleRestVariable)
+
+		for _, varNode := range arrayNode.subnodes(ruleVariable) {
+			if name, err := self.resolveVariableKey(varNode); err == nil {
+				target.Variables = append(target.Variables, name)
+			} else {
+				return nil, fmt.Errorf("unable to resolve variable name: %v", err)
+			}
+		}
+	}
+
+	if restNode != nil {
+		if name, err := self.resolveVariableKey(restNode.subnode(ruleVariable)); err == nil {
+			target.Rest = name
+		} else {
+			return nil, fmt.Errorf("unable to resolve variable name: %v", err)
+		}
+	}
+
+	return target, nil
+}
+
 func (self *Statement) makeAssignment(node *node32) *Assignment {
 	lhs := node.first(ruleAssignmentLHS)
 	op := node.first(ruleAssignmentOperator)
 	rhs := node.first(ruleAssignmentRHS)
 
+	// constant declarations can only use plain assignment
+	if op == nil {
+		op = node.subnode(ruleAssignEq)
+	}
+
 	if lhs != nil && rhs != nil {
 		if aop, err := parseAssignmentOperator(op); err == nil {
-			names := make([]string, 0)
 			expressions := make([]*Expression, 0)
+			target, keyerr := self.parseTarget(lhs.subnode(ruleAssignmentTarget))
 
-			for _, varNode := range lhs.first().children(ruleVariable) {
-				if key, err := self.resolveVariableKey(varNode); err == nil {
-					names = append(names, key)
-				} else {
-					log.Panicf("unable to resolve variable name: %v", err)
-				}
+			if keyerr != nil {
+				target = new(Target)
 			}
 
 			for _, exprNode := range rhs.first().children(ruleExpression) {
@@ -426,10 +694,12 @@ func (self *Statement) makeAssignment(node *node32) *Assignment {
 			}
 
 			return &Assignment{
-				LeftHandSide:  names,
+				LeftHandSide:  target.Names(),
+				Target:        target,
 				Operator:      aop,
 				RightHandSide: expressions,
 				statement:     self,
+				err:           keyerr,
 			}
 		} else {
 			log.Panicf("invalid assignment operator: %v", err)
@@ -439,3 +709,26 @@ func (self *Statement) makeAssignment(node *node32) *Assignment {
 	log.Fatal("cannot build Assignment from given node")
 	return nil
 }
+
+// parses an integer, promoting it to a *big.Int if it is too large to fit in an int
+func parseIntegerLiteral(raw string) (any, error) {
+	if i, ok := new(big.Int).SetString(raw, 10); ok {
+		return normalizeInteger(i), nil
+	} else {
+		return nil, fmt.Errorf("invalid integer %q", raw)
+	}
+}
+
+// parses a timestamp literal (e.g.: @2006-01-02T15:04:05Z) using the first of the TimestampLayouts
+// that matches
+func parseTimestampLiteral(raw string) (time.Time, error) {
+	raw = strings.TrimPrefix(raw, `@`)
+
+	for _, layout := range TimestampLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", raw)
+}
@@ -1,12 +1,121 @@
 package scripting
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/ghetzel/go-stockutil/maputil"
+	"github.com/ghetzel/go-stockutil/sliceutil"
+	"github.com/ghetzel/go-stockutil/typeutil"
+)
+
+type TargetType int
+
+const (
+	ArrayTarget TargetType = iota
+	ObjectTarget
+)
+
+// The variable(s) that a value is assigned to.  Array targets assign successive elements of an array
+// to each variable in turn (e.g.: "$first, $second, ...$rest"), and object targets assign the values
+// of the named keys (e.g.: "{id, name: $n, ...$rest}").  In either case, the rest variable (if any)
+// receives whatever remains.
+type Target struct {
+	Type      TargetType
+	Variables []string
+	Keys      []string
+	Rest      string
+}
+
+// Return all of the variables being assigned to, including the rest variable.
+func (self *Target) Names() []string {
+	var names = append([]string{}, self.Variables...)
+
+	if self.Rest != `` {
+		names = append(names, self.Rest)
+	}
+
+	return names
+}
+
+// Return whether this target unpacks a single value in a way that a plain list of variables does not.
+func (self *Target) IsDestructuring() bool {
+	return (self.Type == ObjectTarget || self.Rest != ``)
+}
+
+// Unpack the given value into the variables of this target, returning the variable names and the values
+// they should be set to.  Values that are not arrays are unpacked by array targets as if they were the
+// sole element of one (and null as if it were an empty array.)
+func (self *Target) Unpack(value any) ([]string, []any, error) {
+	var names = self.Names()
+	var values = make([]any, len(names))
+
+	if v, err := exprToValue(value); err == nil {
+		value = v
+	} else {
+		return nil, nil, err
+	}
+
+	switch self.Type {
+	case ObjectTarget:
+		var rest = make(map[string]any)
+
+		if IsEmpty(value) {
+			value = rest
+		} else if !typeutil.IsMap(value) {
+			return nil, nil, fmt.Errorf("cannot unpack %T into an object", value)
+		}
+
+		for _, key := range maputil.StringKeys(value) {
+			rest[key] = maputil.Get(value, key)
+		}
+
+		for i, key := range self.Keys {
+			values[i] = rest[key]
+			delete(rest, key)
+		}
+
+		if self.Rest != `` {
+			values[len(values)-1] = rest
+		}
+
+	default:
+		var items []any
+
+		if len(self.Variables) == 1 && self.Rest == `` {
+			items = []any{value}
+		} else if typeutil.IsArray(value) {
+			items = sliceutil.Sliceify(value)
+		} else if !IsEmpty(value) {
+			items = []any{value}
+		}
+
+		for i := range self.Variables {
+			if i < len(items) {
+				values[i] = items[i]
+			}
+		}
+
+		if self.Rest != `` {
+			var rest = make([]any, 0)
+
+			if len(items) > len(self.Variables) {
+				rest = append(rest, items[len(self.Variables):]...)
+			}
+
+			values[len(values)-1] = rest
+		}
+	}
+
+	return names, values, nil
+}
 
 type Assignment struct {
 	LeftHandSide  []string
+	Target        *Target
 	Operator      AssignmentOperator
 	RightHandSide []*Expression
 	statement     *Statement
+	err           error
 }
 
 func (self *Assignment) String() string {
@@ -16,3 +125,14 @@ func (self *Assignment) String() string {
 func (self *Assignment) Statement() *Statement {
 	return self.statement
 }
+
+// Return any error that occurred while determining which variables are being assigned to (e.g.: an
+// index expression that failed to evaluate.)
+func (self *Assignment) Err() error {
+	return self.err
+}
+
+// Return whether this assignment declares constants (e.g.: "const $name = value").
+func (self *Assignment) IsConstant() bool {
+	return self.statement.Type() == ConstantStatement
+}
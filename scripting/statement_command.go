@@ -39,6 +39,18 @@ func (self *Command) SourceContext() *Context {
 	return self.ctx
 }
 
+// Return the clause that says how to undo this command, if it has one.
+func (self *Command) Undo() *Undo {
+	if parent := self.statement.node; parent.rule() == ruleUndoableCommand && parent.subnode(ruleCommand) == self.node {
+		return &Undo{
+			statement: self.statement,
+			node:      parent,
+		}
+	}
+
+	return nil
+}
+
 func (self *Command) SetOutputNameOverride(name string) {
 	self.overrideResultVarName = name
 }
@@ -150,13 +162,21 @@ func (self *Command) OutputName() string {
 		return self.overrideResultVarName
 	}
 
-	result := self.node.first(ruleCommandResultAssignment)
-
-	if result != nil {
-		if varname := result.first(ruleVariableNameSequence); varname != nil {
-			return self.statement.raw(varname)
+	// only results assigned to a single variable have an output name
+	if target, err := self.OutputTarget(); err == nil && target != nil {
+		if !target.IsDestructuring() && len(target.Variables) == 1 {
+			return target.Variables[0]
 		}
 	}
 
 	return ``
 }
+
+// Return the variable(s) the result of this command is assigned to, or nil if it is not assigned.
+func (self *Command) OutputTarget() (*Target, error) {
+	if result := self.node.subnode(ruleCommandResultAssignment); result != nil {
+		return self.statement.parseTarget(result.subnode(ruleAssignmentTarget))
+	}
+
+	return nil, nil
+}
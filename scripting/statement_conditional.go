@@ -3,8 +3,6 @@ package scripting
 import (
 	"fmt"
 	"regexp"
-
-	"github.com/ghetzel/go-stockutil/log"
 )
 
 type ConditionalType int
@@ -14,6 +12,7 @@ const (
 	ConditionWithCommand
 	ConditionWithRegex
 	ConditionWithComparator
+	ConditionWithLogic
 )
 
 func (self ConditionalType) String() string {
@@ -26,6 +25,8 @@ func (self ConditionalType) String() string {
 		return `ConditionWithRegex`
 	case ConditionWithComparator:
 		return `ConditionWithComparator`
+	case ConditionWithLogic:
+		return `ConditionWithLogic`
 	default:
 		return `UNKNOWN`
 	}
@@ -41,106 +42,38 @@ func (self *Conditional) String() string {
 }
 
 func (self *Conditional) Type() ConditionalType {
-	if condType := self.testStatementNode(); condType != nil {
-		switch condType.rule() {
-		case ruleConditionWithAssignment:
-			return ConditionWithAssignment
-		case ruleConditionWithCommand:
-			return ConditionWithCommand
-		case ruleConditionWithRegex:
-			return ConditionWithRegex
-		case ruleConditionWithComparator:
-			return ConditionWithComparator
-		}
+	return self.Condition().Type()
+}
+
+// Return the expression being tested by this conditional (or else-if branch).
+func (self *Conditional) Condition() *ConditionalExpression {
+	var node *node32
+
+	if stanza := self.ifNode(); stanza != nil {
+		node = stanza.subnode(ruleConditionalExpression)
 	}
 
-	return -1
+	return NewConditionalExpression(self.statement, node)
 }
 
 // Return the objects necessary to perform assignment then evaluate an expression
 func (self *Conditional) WithAssignment() (*Assignment, *ConditionalExpression) {
-	if condType := self.testStatementNode(); condType != nil {
-		assignment := self.statement.makeAssignment(condType.firstChild(ruleAssignment))
-		condition := NewConditionalExpression(self.statement, condType.firstChild(ruleConditionalExpression))
-
-		return assignment, condition
-	}
-
-	log.Fatal("malformed conditional statement")
-	return nil, nil
+	return self.Condition().WithAssignment()
 }
 
 // Return the objects necessary to execute a command then evaluate an expression
 func (self *Conditional) WithCommand() (*Command, *ConditionalExpression) {
-	if condType := self.testStatementNode(); condType != nil {
-		command := &Command{
-			statement: self.statement,
-			node:      condType.firstChild(ruleCommand),
-		}
-
-		condition := NewConditionalExpression(self.statement, condType.firstChild(ruleConditionalExpression))
-
-		return command, condition
-	}
-
-	log.Fatal("malformed conditional statement")
-	return nil, nil
+	return self.Condition().WithCommand()
 }
 
 // Return the expression, operator, and regular expression in a regex if-test
 func (self *Conditional) WithRegex() (*Expression, MatchOperator, *regexp.Regexp) {
-	if condType := self.testStatementNode(); condType != nil {
-		exprNode := condType.firstChild(ruleExpression)
-		matchNode := condType.firstChild(ruleMatchOperator)
-		regxNode := condType.firstChild(ruleRegularExpression)
-
-		expr := NewExpression(self.statement, exprNode)
-
-		if rx, err := self.statement.parseRegex(regxNode); err == nil {
-			if cmp, err := parseMatchComparator(matchNode); err == nil {
-				return expr, cmp, rx
-			} else {
-				log.Panicf("malformed match operator: %v", err)
-			}
-		} else {
-			log.Panicf("malformed regular expression: %v", err)
-		}
-	}
-
-	log.Fatal("malformed conditional statement")
-	return nil, -1, nil
+	return self.Condition().WithRegex()
 }
 
 // Return the the left- and right-hand sides of an if-test, joined by the comparator.
 func (self *Conditional) WithComparator() (*Expression, Comparator, *Expression) {
-	if condType := self.testStatementNode(); condType != nil {
-		var lhsNode, rhsNode *node32
-
-		lhsNode = condType.first(ruleConditionWithComparatorLHS)
-		rhsNode = condType.first(ruleConditionWithComparatorRHS)
-
-		if lhsNode == nil {
-			log.Fatal("malformed conditional statement: missing left-hand side expression")
-		}
-
-		if rhsNode == nil {
-			return NewExpression(self.statement, lhsNode.firstChild(ruleExpression)), -1, nil
-		} else {
-			if node := rhsNode.firstChild(ruleComparisonOperator); node != nil {
-				if cmp, err := parseComparator(node); err == nil {
-					lhs := NewExpression(self.statement, lhsNode.firstChild(ruleExpression))
-					rhs := NewExpression(self.statement, rhsNode.firstChild(ruleExpression))
-
-					return lhs, cmp, rhs
-				}
-			} else {
-				log.Fatal("malformed conditional statement: missing comparator")
-			}
-		}
-	}
-
-	log.Fatal("malformed conditional statement")
-	return nil, -1, nil
+	return self.Condition().WithComparator()
 }
 
 func (self *Conditional) node() *node32 {
@@ -152,32 +85,11 @@ func (self *Conditional) node() *node32 {
 }
 
 func (self *Conditional) IsNegated() bool {
-	if condEx := self.node().first(ruleConditionalExpression); condEx != nil {
-		if condType := condEx.firstChild(ruleNOT); condType != nil {
-			return true
-		}
-	}
-
-	return false
-}
-
-func (self *Conditional) testStatementNode() *node32 {
-	if condEx := self.node().first(ruleConditionalExpression); condEx != nil {
-		if condType := condEx.firstChild(
-			ruleConditionWithAssignment,
-			ruleConditionWithCommand,
-			ruleConditionWithRegex,
-			ruleConditionWithComparator,
-		); condType != nil {
-			return condType
-		}
-	}
-
-	return nil
+	return self.Condition().IsNegated()
 }
 
 func (self *Conditional) ifNode() *node32 {
-	return self.node().firstChild(ruleIfStanza)
+	return self.node().subnode(ruleIfStanza)
 }
 
 func (self *Conditional) elseNode() *node32 {
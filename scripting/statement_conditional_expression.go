@@ -1,6 +1,9 @@
 package scripting
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/ghetzel/go-stockutil/log"
 	"github.com/ghetzel/go-stockutil/stringutil"
 	"github.com/ghetzel/go-stockutil/typeutil"
@@ -18,30 +21,267 @@ func NewConditionalExpression(statement *Statement, node *node32) *ConditionalEx
 	}
 }
 
+func (self *ConditionalExpression) String() string {
+	return self.statement.raw(self.node)
+}
+
+// Return the type of test this expression performs.  Expressions consisting of a single regular
+// expression or comparator test (optionally negated) are reported as such, whereas any expression
+// combining tests with "and" / "or" (or grouping them) is a ConditionWithLogic.
+func (self *ConditionalExpression) Type() ConditionalType {
+	if self.node == nil {
+		return -1
+	}
+
+	if node := self.node.subnode(ruleConditionWithAssignment, ruleConditionWithCommand); node != nil {
+		switch node.rule() {
+		case ruleConditionWithAssignment:
+			return ConditionWithAssignment
+		default:
+			return ConditionWithCommand
+		}
+	}
+
+	if term := self.singleTerm(); term != nil {
+		if test := term.subnode(ruleConditionWithRegex, ruleConditionWithComparator); test != nil {
+			switch test.rule() {
+			case ruleConditionWithRegex:
+				return ConditionWithRegex
+			default:
+				return ConditionWithComparator
+			}
+		}
+	}
+
+	return ConditionWithLogic
+}
+
+// Return whether the result of the entire expression should be negated.  This only applies to expressions
+// that are a single (possibly negated) test, as individual negated terms in compound expressions are
+// accounted for by Evaluate.
+func (self *ConditionalExpression) IsNegated() bool {
+	if self.node != nil {
+		if self.node.subnode(ruleNOT) != nil {
+			return true
+		} else if term := self.singleTerm(); term != nil {
+			return (term.subnode(ruleNOT) != nil)
+		}
+	}
+
+	return false
+}
+
+// Return the assignment to perform and the expression to evaluate afterwards.
+func (self *ConditionalExpression) WithAssignment() (*Assignment, *ConditionalExpression) {
+	if node := self.node.subnode(ruleConditionWithAssignment); node != nil {
+		assignment := self.statement.makeAssignment(node.subnode(ruleAssignment))
+		return assignment, self.nested(node)
+	}
+
+	return nil, nil
+}
+
+// Return the command to execute and the expression to evaluate afterwards (which may be nil, in which
+// case the command's result is tested for truthiness.)
+func (self *ConditionalExpression) WithCommand() (*Command, *ConditionalExpression) {
+	if node := self.node.subnode(ruleConditionWithCommand); node != nil {
+		command := &Command{
+			statement: self.statement,
+			node:      node.subnode(ruleCommand),
+		}
+
+		return command, self.nested(node)
+	}
+
+	return nil, nil
+}
+
+// Return the expression, operator, and regular expression of a single regex test.
+func (self *ConditionalExpression) WithRegex() (*Expression, MatchOperator, *regexp.Regexp) {
+	if term := self.singleTerm(); term != nil {
+		if node := term.subnode(ruleConditionWithRegex); node != nil {
+			if expr, op, rx, err := self.regexTest(node); err == nil {
+				return expr, op, rx
+			} else {
+				log.Panicf("malformed conditional statement: %v", err)
+			}
+		}
+	}
+
+	return nil, -1, nil
+}
+
+// Return the left- and right-hand sides of a single comparator test, joined by the comparator.  If the
+// test has no right-hand side, the comparator is -1 and the right-hand side is nil.
+func (self *ConditionalExpression) WithComparator() (*Expression, Comparator, *Expression) {
+	if term := self.singleTerm(); term != nil {
+		if node := term.subnode(ruleConditionWithComparator); node != nil {
+			if lhs, cmp, rhs, err := self.comparatorTest(node); err == nil {
+				return lhs, cmp, rhs
+			} else {
+				log.Panicf("malformed conditional statement: %v", err)
+			}
+		}
+	}
+
+	return nil, -1, nil
+}
+
+// Evaluate a regex, comparator, or logical expression.  Terms joined by "and" / "or" are evaluated
+// left-to-right, and evaluation stops as soon as the result is known (so expressions in terms that
+// are not needed, including inline commands, are never evaluated.)  Expressions that perform an
+// assignment or execute a command must be evaluated by the environment.
+func (self *ConditionalExpression) Evaluate() (bool, error) {
+	if self.node == nil {
+		return false, fmt.Errorf("malformed conditional expression")
+	}
+
+	if disjunction := self.node.subnode(ruleConditionDisjunction); disjunction != nil {
+		return self.evaluateDisjunction(disjunction)
+	} else {
+		return false, fmt.Errorf("conditional expression %q must be evaluated by the environment", self)
+	}
+}
+
+// Evaluate the expression, panicking if evaluation fails.
 func (self *ConditionalExpression) IsTrue() bool {
-	exprNodes := self.node.findN(2, ruleExpression)
+	if result, err := self.Evaluate(); err == nil {
+		return result
+	} else {
+		log.Panicf("malformed conditional expression: %v", err)
+		return false
+	}
+}
 
-	switch len(exprNodes) {
-	case 1:
-		if value, err := NewExpression(self.statement, exprNodes[0]).Value(); err == nil {
-			return isTruthy(value)
-		} else {
-			log.Panicf("malformed conditional expression: %v", err)
+func (self *ConditionalExpression) evaluateDisjunction(node *node32) (bool, error) {
+	for _, conjunction := range node.subnodes(ruleConditionConjunction) {
+		if result, err := self.evaluateConjunction(conjunction); err != nil {
+			return false, err
+		} else if result {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (self *ConditionalExpression) evaluateConjunction(node *node32) (bool, error) {
+	for _, term := range node.subnodes(ruleConditionTerm) {
+		if result, err := self.evaluateTerm(term); err != nil {
+			return false, err
+		} else if !result {
+			return false, nil
 		}
+	}
 
-	case 2:
-		if cmp, err := parseComparator(self.node.firstN(2, ruleComparisonOperator)); err == nil {
-			return cmp.Evaluate(
-				NewExpression(self.statement, exprNodes[0]),
-				NewExpression(self.statement, exprNodes[1]),
-			)
+	return true, nil
+}
+
+func (self *ConditionalExpression) evaluateTerm(node *node32) (bool, error) {
+	var result bool
+
+	if test := node.subnode(ruleConditionGroup, ruleConditionWithRegex, ruleConditionWithComparator); test != nil {
+		switch test.rule() {
+		case ruleConditionGroup:
+			if r, err := self.evaluateDisjunction(test.subnode(ruleConditionDisjunction)); err == nil {
+				result = r
+			} else {
+				return false, err
+			}
+
+		case ruleConditionWithRegex:
+			if expr, op, rx, err := self.regexTest(test); err != nil {
+				return false, err
+			} else if value, err := expr.Value(); err == nil {
+				result = op.Evaluate(rx, value)
+			} else {
+				return false, err
+			}
+
+		default:
+			if lhs, cmp, rhs, err := self.comparatorTest(test); err != nil {
+				return false, err
+			} else if lvalue, err := lhs.Value(); err != nil {
+				return false, err
+			} else if rhs == nil {
+				result = isTruthy(lvalue)
+			} else if rvalue, err := rhs.Value(); err == nil {
+				result = cmp.compare(lvalue, rvalue)
+			} else {
+				return false, err
+			}
+		}
+	} else {
+		return false, fmt.Errorf("malformed conditional term %q", self.statement.raw(node))
+	}
+
+	if node.subnode(ruleNOT) != nil {
+		result = !result
+	}
+
+	return result, nil
+}
+
+func (self *ConditionalExpression) regexTest(node *node32) (*Expression, MatchOperator, *regexp.Regexp, error) {
+	expr := NewExpression(self.statement, node.subnode(ruleExpression))
+
+	if rx, err := self.statement.parseRegex(node.subnode(ruleRegularExpression)); err == nil {
+		if op, err := parseMatchComparator(node.subnode(ruleMatchOperator)); err == nil {
+			return expr, op, rx, nil
 		} else {
-			log.Panicf("malformed conditional expression: %v", err)
+			return nil, -1, nil, fmt.Errorf("malformed match operator: %v", err)
 		}
+	} else {
+		return nil, -1, nil, fmt.Errorf("malformed regular expression: %v", err)
 	}
+}
 
-	log.Fatal("malformed conditional expression")
-	return false
+func (self *ConditionalExpression) comparatorTest(node *node32) (*Expression, Comparator, *Expression, error) {
+	lhsNode := node.subnode(ruleConditionWithComparatorLHS)
+	rhsNode := node.subnode(ruleConditionWithComparatorRHS)
+
+	if lhsNode == nil {
+		return nil, -1, nil, fmt.Errorf("missing left-hand side expression")
+	}
+
+	lhs := NewExpression(self.statement, lhsNode.subnode(ruleExpression))
+
+	if rhsNode == nil {
+		return lhs, -1, nil, nil
+	} else if cmp, err := parseComparator(rhsNode.subnode(ruleComparisonOperator)); err == nil {
+		return lhs, cmp, NewExpression(self.statement, rhsNode.subnode(ruleExpression)), nil
+	} else {
+		return nil, -1, nil, fmt.Errorf("missing comparator: %v", err)
+	}
+}
+
+// returns the sole term of an expression that is not combined with any other terms
+func (self *ConditionalExpression) singleTerm() *node32 {
+	if disjunction := self.node.subnode(ruleConditionDisjunction); disjunction != nil {
+		if conjunctions := disjunction.subnodes(ruleConditionConjunction); len(conjunctions) == 1 {
+			if terms := conjunctions[0].subnodes(ruleConditionTerm); len(terms) == 1 {
+				if terms[0].subnode(ruleConditionGroup) == nil {
+					return terms[0]
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (self *ConditionalExpression) nested(node *node32) *ConditionalExpression {
+	if next := node.subnode(ruleConditionalExpression); next != nil {
+		return NewConditionalExpression(self.statement, next)
+	}
+
+	return nil
+}
+
+// Return whether the given value should be considered "true" in a conditional test.  Null, empty,
+// zero, and false-like values (e.g.: "false", "off", "no") are false; everything else is true.
+func IsTruthy(value any) bool {
+	return isTruthy(value)
 }
 
 func isTruthy(value any) bool {
@@ -52,7 +292,9 @@ func isTruthy(value any) bool {
 		return false
 	}
 
-	if typeutil.IsEmpty(value) || typeutil.IsZero(value) || IsEmpty(value) {
+	if d, ok := value.(*Decimal); ok {
+		return !d.IsZero()
+	} else if typeutil.IsEmpty(value) || typeutil.IsZero(value) || IsEmpty(value) {
 		return false
 	} else if stringutil.IsBooleanFalse(value) {
 		return false
@@ -0,0 +1,26 @@
+package scripting
+
+type Defer struct {
+	statement *Statement
+}
+
+func (self *Defer) String() string {
+	return `Defer`
+}
+
+// Return the command that is deferred, or nil if a block of statements is deferred instead.
+func (self *Defer) Command() *Command {
+	if node := self.statement.node.subnode(ruleCommand); node != nil {
+		return &Command{
+			statement: self.statement,
+			node:      node,
+		}
+	}
+
+	return nil
+}
+
+// Return the blocks that are deferred (if the statement defers a block of statements.)
+func (self *Defer) Blocks() []*Block {
+	return statementBlocks(self.statement)
+}
@@ -56,6 +56,19 @@ func (self *Directive) Type() DirectiveType {
 	return UnknownDirective
 }
 
+func (self *Directive) Statement() *Statement {
+	return self.statement
+}
+
+// Return the (interpolated) path of the script named by an include directive.
+func (self *Directive) IncludePath() string {
+	if node := self.statement.node.first(ruleDirectiveInclude); node != nil {
+		return self.statement.s(node.first(ruleString))
+	}
+
+	return ``
+}
+
 func (self *Directive) VariableNames() []string {
 	names := make([]string, 0)
 
@@ -66,6 +79,22 @@ func (self *Directive) VariableNames() []string {
 	return names
 }
 
+// Return the fully-resolved scope keys of the variables named by the directive, including
+// any index expressions (e.g.: "$a.b[1]" yields "a.b.1").
+func (self *Directive) VariableKeys() ([]string, error) {
+	keys := make([]string, 0)
+
+	for _, node := range self.statement.node.find(ruleVariable) {
+		if key, err := self.statement.resolveVariableKey(node); err == nil {
+			keys = append(keys, key)
+		} else {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
 func (self *Directive) String() string {
 	return fmt.Sprintf("%v", self.Type())
 }
@@ -1,6 +1,7 @@
 package scripting
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -34,28 +35,160 @@ func (self *Expression) String() string {
 }
 
 func (self *Expression) Value() (any, error) {
-	// example: if "x" == "y"
-
-	if lhs := self.node.first(ruleExpressionLHS); lhs != nil { // if "x"
-		if value, err := self.resolveValue(
-			lhs.firstChild(ruleValueYielding),
-		); err == nil { // "x"
-			if rhs := self.node.first(ruleExpressionRHS); rhs != nil { // == "y"
-				if op, err := parseOperator(rhs.firstChild(ruleOperator)); err == nil { // ==
-					if exprNode := rhs.firstChild(ruleExpression); exprNode != nil { // "y"
-						return op.evaluate(value, NewExpression(self.statement, exprNode))
+	if node := self.node.subnode(ruleExpressionTernary); node != nil {
+		return self.evaluate(node)
+	} else {
+		return nil, fmt.Errorf("expression did not yield a value")
+	}
+}
+
+// Evaluate one level of an expression.  Each level consists of one or more operands (which are
+// themselves expressions at the next-highest level of precedence) separated by the operators for
+// that level.
+func (self *Expression) evaluate(node *node32) (any, error) {
+	switch node.rule() {
+	case ruleExpressionTernary:
+		var condition = node.subnode(ruleExpressionCoalesce)
+		var branches = node.subnodes(ruleExpression)
+
+		if len(branches) == 0 {
+			return self.evaluate(condition)
+		} else if len(branches) != 2 {
+			return nil, fmt.Errorf("malformed expression %q", self.statement.raw(node))
+		}
+
+		// only the branch that is chosen is evaluated
+		if value, err := self.evaluate(condition); err == nil {
+			var result bool
+
+			if cmpNode := node.subnode(ruleExpressionTernaryComparison); cmpNode != nil {
+				if cmp, err := parseComparator(cmpNode.subnode(ruleComparisonOperator)); err == nil {
+					if rhs, err := self.evaluate(cmpNode.subnode(ruleExpressionCoalesce)); err == nil {
+						result = cmp.compare(value, rhs)
+					} else {
+						return nil, err
 					}
-				} else if op != opNull {
-					return new(emptyValue), err
+				} else {
+					return nil, err
+				}
+			} else {
+				result = isTruthy(value)
+			}
+
+			if result {
+				return NewExpression(self.statement, branches[0]).Value()
+			} else {
+				return NewExpression(self.statement, branches[1]).Value()
+			}
+		} else {
+			return nil, err
+		}
+
+	case ruleExpressionCoalesce:
+		// yields the first operand that is not null; operands after that are not evaluated, and
+		// undefined variables are treated as null even in strict mode
+		var operands = node.subnodes(ruleExpressionBitwise)
+
+		for i, operand := range operands {
+			var last = (i == len(operands)-1)
+
+			if value, err := self.evaluate(operand); err == nil {
+				if last || !IsEmpty(value) {
+					return value, nil
 				}
+			} else if last || !errors.Is(err, ErrUndefinedVariable) {
+				return nil, err
 			}
+		}
+
+	case ruleExpressionBitwise, ruleExpressionAdditive, ruleExpressionMultiplicative:
+		var operands = node.subnodes(
+			ruleExpressionAdditive,
+			ruleExpressionMultiplicative,
+			ruleExpressionUnary,
+		)
+
+		var operators = node.subnodes(
+			ruleAdditiveOperator,
+			ruleMultiplicativeOperator,
+			ruleBitwiseOperator,
+		)
+
+		if len(operands) == 0 || len(operators) != len(operands)-1 {
+			return nil, fmt.Errorf("malformed expression %q", self.statement.raw(node))
+		}
+
+		// left-associative: (((a op b) op c) op d)
+		if value, err := self.evaluate(operands[0]); err == nil {
+			for i, opNode := range operators {
+				if op, err := parseOperator(opNode); err == nil {
+					if rhs, err := self.evaluate(operands[i+1]); err == nil {
+						if v, err := op.evaluate(value, rhs); err == nil {
+							value = v
+						} else {
+							return nil, err
+						}
+					} else {
+						return nil, err
+					}
+				} else {
+					return nil, err
+				}
+			}
+
 			return value, nil
 		} else {
-			return nil, fmt.Errorf("invalid value: %v", err)
+			return nil, err
+		}
+
+	case ruleExpressionUnary:
+		if opNode := node.subnode(ruleUnaryOperator); opNode != nil {
+			if op, err := parseOperator(opNode); err == nil {
+				if value, err := self.evaluate(node.subnode(ruleExpressionUnary)); err == nil {
+					return op.evaluateUnary(value)
+				} else {
+					return nil, err
+				}
+			} else {
+				return nil, err
+			}
+		} else if exponent := node.subnode(ruleExpressionExponent); exponent != nil {
+			return self.evaluate(exponent)
+		}
+
+	case ruleExpressionExponent:
+		// right-associative: (a ** (b ** c))
+		if value, err := self.evaluate(node.subnode(ruleExpressionOperand)); err == nil {
+			if rhsNode := node.subnode(ruleExpressionUnary); rhsNode != nil {
+				if op, err := parseOperator(node.subnode(ruleExponentOperator)); err == nil {
+					if rhs, err := self.evaluate(rhsNode); err == nil {
+						return op.evaluate(value, rhs)
+					} else {
+						return nil, err
+					}
+				} else {
+					return nil, err
+				}
+			}
+
+			return value, nil
+		} else {
+			return nil, err
+		}
+
+	case ruleExpressionOperand:
+		if group := node.subnode(ruleExpressionGroup); group != nil {
+			return NewExpression(self.statement, group.subnode(ruleExpression)).Value()
+		} else if value := node.subnode(ruleValueYielding); value != nil {
+			if v, err := self.resolveValue(value); err == nil {
+				return v, nil
+			} else {
+				return nil, fmt.Errorf("invalid value: %w", err)
+			}
 		}
-	} else {
-		return nil, fmt.Errorf("left-hand side of expression did not yield a value")
 	}
+
+	return nil, fmt.Errorf("malformed expression %q", self.statement.raw(node))
 }
 
 func (self *Expression) resolveValue(node *node32) (any, error) {
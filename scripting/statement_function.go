@@ -0,0 +1,74 @@
+package scripting
+
+import (
+	"fmt"
+)
+
+type Function struct {
+	statement *Statement
+}
+
+func (self *Function) String() string {
+	return fmt.Sprintf("Function %v", self.Name())
+}
+
+// Return the name the function will be callable as.
+func (self *Function) Name() string {
+	if node := self.statement.node.firstChild(ruleIdentifier); node != nil {
+		return self.statement.raw(node)
+	}
+
+	return ``
+}
+
+// Return the name of the variable that will receive the function's first argument (if any).
+func (self *Function) ArgumentName() (string, error) {
+	if node := self.parameter(ruleFunctionArgument); node != nil {
+		return self.statement.resolveVariableKey(node.firstChild(ruleVariable))
+	}
+
+	return ``, nil
+}
+
+// Return whether the function declares any named options.
+func (self *Function) HasOptions() bool {
+	return self.parameter(ruleFunctionOptions) != nil
+}
+
+// Return the names and default values of the options the function accepts.  Defaults are
+// evaluated in the current scope each time this is called.
+func (self *Function) Options() (map[string]any, error) {
+	if node := self.parameter(ruleFunctionOptions); node != nil {
+		return self.statement.parseObject(node.firstChild(ruleObject))
+	}
+
+	return make(map[string]any), nil
+}
+
+// Return the blocks that make up the body of the function.
+func (self *Function) Blocks() []*Block {
+	var blocks = make([]*Block, 0)
+
+	for _, node := range self.statement.node.children(ruleBlock) {
+		blocks = append(blocks, &Block{
+			friendscript: self.statement.block.friendscript,
+			node:         node.first(),
+			parent:       self.statement,
+		})
+	}
+
+	return blocks
+}
+
+// only consider this function's own parameters, not those of any functions defined in its body
+func (self *Function) parameter(rule pegRule) *node32 {
+	if params := self.statement.node.firstChild(ruleFunctionParameters); params != nil {
+		return params.firstChild(rule)
+	}
+
+	return nil
+}
+
+func (self *Function) Statement() *Statement {
+	return self.statement
+}
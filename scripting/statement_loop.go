@@ -24,11 +24,14 @@ type FlowControlType int
 const (
 	FlowBreak FlowControlType = iota
 	FlowContinue
+	FlowReturn
+	FlowExit
 )
 
 type FlowControlErr struct {
 	Type  FlowControlType
 	Level int
+	Value any
 }
 
 func NewFlowControl(flowType FlowControlType, levels int) *FlowControlErr {
@@ -46,6 +49,10 @@ func (self FlowControlErr) Error() string {
 		msg = `break`
 	case FlowContinue:
 		msg = `continue`
+	case FlowReturn:
+		return `return`
+	case FlowExit:
+		return fmt.Sprintf("exit %v", self.Value)
 	default:
 		self.Level = 0
 		return `invalid flow control statement`
@@ -163,7 +170,8 @@ func (self *Loop) Iterate() (int, bool) {
 			return self.iterations, true
 		}
 
-	case IteratorLoop:
+	case IteratorLoop, ConditionBoundedLoop, WhileLoop:
+		// the loop condition for these is evaluated by the environment
 		return self.iterations, true
 
 	default:
@@ -173,6 +181,86 @@ func (self *Loop) Iterate() (int, bool) {
 	return self.iterations, false
 }
 
+// Return whether the iterations of this loop run concurrently.
+func (self *Loop) IsParallel() bool {
+	return self.statement.node.subnode(ruleLoopParallel) != nil
+}
+
+// Return the maximum number of iterations of a parallel loop that may run at the same time, or 0 if
+// this loop is not parallel.  Parallel loops that do not specify a limit use the given fallback.
+func (self *Loop) Parallelism(fallback int) (int, error) {
+	if node := self.statement.node.subnode(ruleLoopParallel); node != nil {
+		if limitNode := node.subnode(ruleLoopParallelLimit); limitNode != nil {
+			var limit any
+
+			if varNode := limitNode.subnode(ruleVariable); varNode != nil {
+				if v, err := self.statement.resolveVariable(varNode); err == nil {
+					limit = v
+				} else {
+					return 0, err
+				}
+			} else {
+				limit = self.statement.raw(limitNode)
+			}
+
+			if n, err := stringutil.ConvertToInteger(limit); err == nil && n > 0 {
+				return int(n), nil
+			} else {
+				return 0, fmt.Errorf("invalid parallel loop limit %v: must be a positive integer", limit)
+			}
+		}
+
+		return fallback, nil
+	}
+
+	return 0, nil
+}
+
+// Return the names of the variables that the result and error of each iteration of a parallel loop are
+// collected into (either of which may be empty.)
+func (self *Loop) ParallelResults() (string, string, error) {
+	var names = make([]string, 2)
+
+	if node := self.statement.node.subnode(ruleLoopParallelResults); node != nil {
+		for i, varNode := range node.subnodes(ruleVariable) {
+			if key, err := self.statement.variableKey(varNode, false); err == nil {
+				names[i] = key
+			} else {
+				return ``, ``, err
+			}
+		}
+	}
+
+	return names[0], names[1], nil
+}
+
+// Return the condition that must be true for a while loop or condition-bounded loop to continue.
+func (self *Loop) Condition() *ConditionalExpression {
+	if node := self.statement.node.subnode(ruleLoopConditionBounded, ruleLoopConditionTruthy); node != nil {
+		return NewConditionalExpression(self.statement, node.subnode(ruleConditionalExpression))
+	}
+
+	return nil
+}
+
+// Return the command to run before the first iteration of a condition-bounded loop, and the command
+// to run after each iteration.
+func (self *Loop) BoundingCommands() (*Command, *Command) {
+	if node := self.statement.node.subnode(ruleLoopConditionBounded); node != nil {
+		if commands := node.subnodes(ruleCommand); len(commands) == 2 {
+			return &Command{
+				statement: self.statement,
+				node:      commands[0],
+			}, &Command{
+				statement: self.statement,
+				node:      commands[1],
+			}
+		}
+	}
+
+	return nil, nil
+}
+
 func (self *Loop) Blocks() []*Block {
 	var blocks = make([]*Block, 0)
 
@@ -187,6 +275,40 @@ func (self *Loop) Blocks() []*Block {
 	return blocks
 }
 
+// Return the variable(s) that each item being iterated over is assigned to.
+func (self *Loop) IterationTarget() (*Target, error) {
+	if self.Type() == IteratorLoop {
+		if node := self.statement.node.firstChild(ruleLoopConditionIterable); node != nil {
+			if lhs := node.subnode(ruleLoopIterableLHS); lhs != nil {
+				return self.statement.parseTarget(lhs.subnode(ruleAssignmentTarget))
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// Return the range being iterated over, or nil if this loop does not iterate over a range.
+func (self *Loop) IterationRange() (*Range, error) {
+	if self.Type() == IteratorLoop {
+		if node := self.statement.node.firstChild(ruleLoopConditionIterable); node != nil {
+			if rhs := node.subnode(ruleLoopIterableRHS); rhs != nil {
+				if rangeNode := rhs.subnode(ruleRange); rangeNode != nil {
+					if rng, err := self.statement.parseRange(rangeNode); err != nil {
+						return nil, err
+					} else if !rng.IsIterable() {
+						return nil, fmt.Errorf("cannot iterate over range %v: only ranges of numbers can be iterated over", rng)
+					} else {
+						return rng, nil
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
 func (self *Loop) IteratableParts() ([]string, any) {
 	if self.Type() == IteratorLoop {
 		if node := self.statement.node.firstChild(ruleLoopConditionIterable); node != nil {
@@ -194,18 +316,19 @@ func (self *Loop) IteratableParts() ([]string, any) {
 			var rhs = node.first(ruleLoopIterableRHS)
 
 			if lhs != nil && rhs != nil {
-				var names = make([]string, 0)
+				var names []string
 				var rightHand any
 
 				// handle left-hand side of assignment(s)
 				// $x, $y, $z = 1, 2, 3
 				//
-				for _, varNode := range lhs.first().children(ruleVariable) {
-					if key, err := self.statement.resolveVariableKey(varNode); err == nil {
-						names = append(names, key)
-					} else {
-						log.Panicf("unable to resolve variable name: %v", err)
-					}
+				if target, err := self.IterationTarget(); err == nil && target != nil {
+					names = target.Names()
+				}
+
+				// ranges are retrieved with IterationRange
+				if rhs.subnode(ruleRange) != nil {
+					return names, nil
 				}
 
 				if rhsNode := rhs.first(0, ruleCommand, ruleVariable); rhsNode != nil {
@@ -214,7 +337,7 @@ func (self *Loop) IteratableParts() ([]string, any) {
 							statement: self.statement,
 							node:      rhsNode,
 						}
-					} else if key, err := self.statement.resolveVariableKey(rhsNode); err == nil {
+					} else if key, err := self.statement.variableKey(rhsNode, true); err == nil {
 						rightHand = key
 					} else {
 						log.Panicf("unable to resolve variable name: %v", err)
@@ -0,0 +1,151 @@
+package scripting
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/ghetzel/go-stockutil/stringutil"
+	"github.com/ghetzel/go-stockutil/typeutil"
+)
+
+// The type names that can be tested for in a match arm (e.g.: "is array").
+var matchTypeTests = map[string]func(value any) bool{
+	`null`: IsEmpty,
+	`array`: func(value any) bool {
+		return !IsEmpty(value) && typeutil.IsArray(value)
+	},
+	`object`: func(value any) bool {
+		return !IsEmpty(value) && typeutil.IsMap(value)
+	},
+	`string`: func(value any) bool {
+		_, ok := value.(string)
+		return ok
+	},
+	`bool`: func(value any) bool {
+		_, ok := value.(bool)
+		return ok
+	},
+	`integer`: isInteger,
+	`float`: func(value any) bool {
+		switch value.(type) {
+		case float32, float64:
+			return true
+		default:
+			return false
+		}
+	},
+	`decimal`: func(value any) bool {
+		_, ok := value.(*Decimal)
+		return ok
+	},
+	`number`: isNumber,
+	`time`: func(value any) bool {
+		_, ok := value.(time.Time)
+		return ok
+	},
+	`duration`: func(value any) bool {
+		_, ok := value.(time.Duration)
+		return ok
+	},
+}
+
+type Match struct {
+	statement *Statement
+}
+
+func (self *Match) String() string {
+	return `Match`
+}
+
+// Evaluate the value being matched and return the blocks of the first arm with a pattern that matches
+// it.  If no arms match, no blocks are returned.
+func (self *Match) Branch() ([]*Block, error) {
+	var value any
+
+	if v, err := NewExpression(self.statement, self.statement.node.subnode(ruleExpression)).Value(); err == nil {
+		value = v
+	} else {
+		return nil, err
+	}
+
+	for _, arm := range self.statement.node.subnodes(ruleMatchArm) {
+		for _, pattern := range arm.subnodes(ruleMatchPattern) {
+			if ok, err := self.matches(value, pattern); err != nil {
+				return nil, err
+			} else if ok {
+				var blocks = make([]*Block, 0)
+
+				for _, node := range arm.subnodes(ruleBlock) {
+					blocks = append(blocks, &Block{
+						friendscript: self.statement.Script(),
+						node:         node.first(),
+						parent:       self.statement,
+					})
+				}
+
+				return blocks, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (self *Match) matches(value any, pattern *node32) (bool, error) {
+	switch node := pattern.subnode(ruleMatchWildcard, ruleMatchType, ruleRange, ruleRegularExpression, ruleExpression); node.rule() {
+	case ruleMatchWildcard:
+		return true, nil
+
+	case ruleMatchType:
+		var name = self.statement.raw(node.subnode(ruleIdentifier))
+
+		if test, ok := matchTypeTests[name]; ok {
+			return test(value), nil
+		} else {
+			return false, fmt.Errorf("unknown type %q", name)
+		}
+
+	case ruleRange:
+		if rng, err := self.statement.parseRange(node); err == nil {
+			return rng.Contains(value), nil
+		} else {
+			return false, err
+		}
+
+	case ruleRegularExpression:
+		if rx, err := self.statement.parseRegex(node); err == nil {
+			return !IsEmpty(value) && matchOp.Evaluate(rx, value), nil
+		} else {
+			return false, err
+		}
+
+	default:
+		if want, err := NewExpression(self.statement, node).Value(); err == nil {
+			if rx, ok := want.(*regexp.Regexp); ok {
+				return !IsEmpty(value) && matchOp.Evaluate(rx, value), nil
+			}
+
+			return matchEqual(value, want), nil
+		} else {
+			return false, err
+		}
+	}
+}
+
+// Return whether two values are equal in the same way the "==" operator would consider them to be,
+// except that values that cannot be compared (e.g.: an array and a number) are simply not equal.
+func matchEqual(lhs any, rhs any) bool {
+	if c, ok := compareTime(lhs, rhs); ok {
+		return c == 0
+	} else if c, ok := compareExact(lhs, rhs); ok {
+		return c == 0
+	} else if IsEmpty(lhs) || IsEmpty(rhs) {
+		return IsEmpty(lhs) && IsEmpty(rhs)
+	} else if eq, err := stringutil.RelaxedEqual(lhs, rhs); err == nil {
+		return eq
+	} else {
+		return reflect.DeepEqual(lhs, rhs)
+	}
+}
@@ -0,0 +1,15 @@
+package scripting
+
+type Parallel struct {
+	statement *Statement
+}
+
+func (self *Parallel) String() string {
+	return `Parallel`
+}
+
+// Return the blocks in the body of the statement, each of which is evaluated at the same time as the
+// others.
+func (self *Parallel) Blocks() []*Block {
+	return statementBlocks(self.statement)
+}
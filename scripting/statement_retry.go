@@ -0,0 +1,173 @@
+package scripting
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/ghetzel/go-stockutil/stringutil"
+)
+
+type BackoffType string
+
+const (
+	ExponentialBackoff BackoffType = `exponential`
+	LinearBackoff      BackoffType = `linear`
+	ConstantBackoff    BackoffType = `constant`
+)
+
+// Describes how long to wait between the attempts of a retry statement.  The first wait is Min, and
+// later ones grow (linearly or exponentially) up to Max, if it is given.  Jitter is the fraction of
+// each wait (from 0 to 1) that is randomized, so that several retrying scripts do not all try again at
+// the same moment.
+type Backoff struct {
+	Type   BackoffType
+	Min    time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+// Return how long to wait after the given (1-based) attempt fails.
+func (self *Backoff) Delay(attempt int) time.Duration {
+	var delay = self.Min
+
+	switch self.Type {
+	case LinearBackoff:
+		delay = self.Min * time.Duration(max(attempt, 1))
+	case ExponentialBackoff:
+		for i := 1; i < attempt && delay > 0; i++ {
+			// stop doubling once the limit (or the largest possible duration) is reached
+			if (self.Max > 0 && delay >= self.Max) || delay > (1<<62) {
+				break
+			}
+
+			delay *= 2
+		}
+	}
+
+	if self.Max > 0 && delay > self.Max {
+		delay = self.Max
+	}
+
+	if self.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * self.Jitter * float64(delay))
+	}
+
+	return delay
+}
+
+type Retry struct {
+	statement *Statement
+}
+
+func (self *Retry) String() string {
+	return `Retry`
+}
+
+func (self *Retry) SourceContext() *Context {
+	return self.statement.SourceContext()
+}
+
+// Return the number of times the body of the statement is attempted before giving up.
+func (self *Retry) Attempts() (int, error) {
+	var node = self.statement.node.subnode(ruleRetryAttempts)
+	var attempts any
+
+	if varNode := node.subnode(ruleVariable); varNode != nil {
+		if v, err := self.statement.resolveVariable(varNode); err == nil {
+			attempts = v
+		} else {
+			return 0, err
+		}
+	} else {
+		attempts = self.statement.raw(node)
+	}
+
+	if n, err := stringutil.ConvertToInteger(attempts); err == nil && n > 0 {
+		return int(n), nil
+	} else {
+		return 0, fmt.Errorf("invalid number of retry attempts %v: must be a positive integer", attempts)
+	}
+}
+
+// Return how long to wait between attempts.  Statements without a backoff do not wait at all.  Backoffs
+// are exponential unless another type is given, and jitter randomizes all of each wait unless a fraction
+// is given.
+func (self *Retry) Backoff() (*Backoff, error) {
+	var backoff = &Backoff{
+		Type: ExponentialBackoff,
+	}
+
+	if node := self.statement.node.subnode(ruleRetryBackoff); node != nil {
+		var bounds = make([]any, 0)
+
+		if typeNode := node.subnode(ruleRetryBackoffType); typeNode != nil {
+			backoff.Type = BackoffType(strings.TrimSpace(self.statement.raw(typeNode)))
+		}
+
+		if rangeNode := node.subnode(ruleRange); rangeNode != nil {
+			if rng, err := self.statement.parseRange(rangeNode); err == nil {
+				bounds = append(bounds, rng.Start, rng.End)
+			} else {
+				return nil, err
+			}
+		} else if value, err := NewExpression(self.statement, node.subnode(ruleExpression)).Value(); err == nil {
+			bounds = append(bounds, value)
+		} else {
+			return nil, err
+		}
+
+		for i, bound := range bounds {
+			if d, err := toDuration(bound); err == nil && d >= 0 {
+				if i == 0 {
+					backoff.Min = d
+				} else {
+					backoff.Max = d
+				}
+			} else {
+				return nil, fmt.Errorf("invalid retry backoff %v: must be a duration", bound)
+			}
+		}
+
+		if backoff.Max > 0 && backoff.Max < backoff.Min {
+			return nil, fmt.Errorf("invalid retry backoff: %v is less than %v", backoff.Max, backoff.Min)
+		}
+	}
+
+	if node := self.statement.node.subnode(ruleRetryJitter); node != nil {
+		var jitter any = 1
+
+		if varNode := node.subnode(ruleVariable); varNode != nil {
+			if v, err := self.statement.resolveVariable(varNode); err == nil {
+				jitter = v
+			} else {
+				return nil, err
+			}
+		} else if floatNode := node.subnode(ruleFloat); floatNode != nil {
+			jitter = self.statement.raw(floatNode)
+		}
+
+		if f, err := stringutil.ConvertToFloat(jitter); err == nil && f >= 0 && f <= 1 {
+			backoff.Jitter = f
+		} else {
+			return nil, fmt.Errorf("invalid retry jitter %v: must be a number from 0 to 1", jitter)
+		}
+	}
+
+	return backoff, nil
+}
+
+// Return the condition that must be true for an attempt to succeed, if the statement has one.
+func (self *Retry) Condition() *ConditionalExpression {
+	if node := self.statement.node.subnode(ruleRetryUntil); node != nil {
+		return NewConditionalExpression(self.statement, node.subnode(ruleConditionalExpression))
+	}
+
+	return nil
+}
+
+// Return the blocks in the body of the statement.
+func (self *Retry) Blocks() []*Block {
+	return statementBlocks(self.statement)
+}
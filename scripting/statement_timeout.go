@@ -0,0 +1,67 @@
+package scripting
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ghetzel/go-stockutil/stringutil"
+	"github.com/ghetzel/go-stockutil/timeutil"
+)
+
+type Timeout struct {
+	statement *Statement
+}
+
+func (self *Timeout) String() string {
+	return `Timeout`
+}
+
+func (self *Timeout) SourceContext() *Context {
+	return self.statement.SourceContext()
+}
+
+// Return how long the body of the statement is allowed to run for.
+func (self *Timeout) Duration() (time.Duration, error) {
+	if value, err := NewExpression(self.statement, self.statement.node.subnode(ruleExpression)).Value(); err == nil {
+		if d, err := toDuration(value); err == nil && d > 0 {
+			return d, nil
+		} else {
+			return 0, fmt.Errorf("invalid timeout %v: must be a positive duration", value)
+		}
+	} else {
+		return 0, err
+	}
+}
+
+// Return the blocks in the body of the statement.
+func (self *Timeout) Blocks() []*Block {
+	return statementBlocks(self.statement)
+}
+
+// Return the blocks that are immediate children of the given statement (i.e.: its body.)
+func statementBlocks(statement *Statement) []*Block {
+	var blocks = make([]*Block, 0)
+
+	for _, node := range statement.node.subnodes(ruleBlock) {
+		blocks = append(blocks, &Block{
+			friendscript: statement.Script(),
+			node:         node.first(),
+			parent:       statement,
+		})
+	}
+
+	return blocks
+}
+
+// Convert the given value to a duration.  Numbers are taken to be a number of milliseconds.
+func toDuration(value any) (time.Duration, error) {
+	if d, ok := value.(time.Duration); ok {
+		return d, nil
+	} else if ms, err := stringutil.ConvertToInteger(value); err == nil {
+		return time.Duration(ms) * time.Millisecond, nil
+	} else if d, err := timeutil.ParseDuration(fmt.Sprintf("%v", value)); err == nil {
+		return d, nil
+	} else {
+		return 0, fmt.Errorf("invalid duration: %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package scripting
+
+type Transaction struct {
+	statement *Statement
+}
+
+func (self *Transaction) String() string {
+	return `Transaction`
+}
+
+// Return the blocks that make up the body of the "transaction" stanza.
+func (self *Transaction) Blocks() []*Block {
+	return self.stanzaBlocks(ruleTransactionStanza)
+}
+
+// Return whether the statement has a "rollback" stanza.
+func (self *Transaction) HasRollback() bool {
+	return self.statement.node.firstChild(ruleRollbackStanza) != nil
+}
+
+// Return the name of the variable that will receive the rollback report (if one was specified).
+func (self *Transaction) RollbackVariable() (string, error) {
+	if stanza := self.statement.node.firstChild(ruleRollbackStanza); stanza != nil {
+		if varNode := stanza.firstChild(ruleVariable); varNode != nil {
+			return self.statement.resolveVariableKey(varNode)
+		}
+	}
+
+	return ``, nil
+}
+
+// Return the blocks that make up the body of the "rollback" stanza.
+func (self *Transaction) RollbackBlocks() []*Block {
+	return self.stanzaBlocks(ruleRollbackStanza)
+}
+
+func (self *Transaction) stanzaBlocks(rule pegRule) []*Block {
+	var blocks = make([]*Block, 0)
+
+	if stanza := self.statement.node.firstChild(rule); stanza != nil {
+		for _, node := range stanza.subnodes(ruleBlock) {
+			blocks = append(blocks, &Block{
+				friendscript: self.statement.Script(),
+				node:         node.first(),
+				parent:       self.statement,
+			})
+		}
+	}
+
+	return blocks
+}
+
+// The clause of a command that says how to undo it (e.g.: "create 'x' undo delete 'x'").
+type Undo struct {
+	statement *Statement
+	node      *node32
+}
+
+// Return the command that undoes the command, or nil if a block of statements does instead.
+func (self *Undo) Command() *Command {
+	if commands := self.node.subnodes(ruleCommand); len(commands) == 2 {
+		return &Command{
+			statement: self.statement,
+			node:      commands[1],
+		}
+	}
+
+	return nil
+}
+
+// Return the blocks that undo the command (if a block of statements does.)
+func (self *Undo) Blocks() []*Block {
+	var blocks = make([]*Block, 0)
+
+	for _, node := range self.node.subnodes(ruleBlock) {
+		blocks = append(blocks, &Block{
+			friendscript: self.statement.Script(),
+			node:         node.first(),
+			parent:       self.statement,
+		})
+	}
+
+	return blocks
+}
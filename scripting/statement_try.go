@@ -0,0 +1,56 @@
+package scripting
+
+type TryCatch struct {
+	statement *Statement
+}
+
+func (self *TryCatch) String() string {
+	return `TryCatch`
+}
+
+// Return the blocks that make up the body of the "try" stanza.
+func (self *TryCatch) TryBlocks() []*Block {
+	return self.stanzaBlocks(ruleTryStanza)
+}
+
+// Return whether the statement has a "catch" stanza.
+func (self *TryCatch) HasCatch() bool {
+	return self.statement.node.firstChild(ruleCatchStanza) != nil
+}
+
+// Return the name of the variable that will receive the caught error (if one was specified).
+func (self *TryCatch) CatchVariable() (string, error) {
+	if stanza := self.statement.node.firstChild(ruleCatchStanza); stanza != nil {
+		if varNode := stanza.firstChild(ruleVariable); varNode != nil {
+			return self.statement.resolveVariableKey(varNode)
+		}
+	}
+
+	return ``, nil
+}
+
+// Return the blocks that make up the body of the "catch" stanza.
+func (self *TryCatch) CatchBlocks() []*Block {
+	return self.stanzaBlocks(ruleCatchStanza)
+}
+
+// Return the blocks that make up the body of the "finally" stanza.
+func (self *TryCatch) FinallyBlocks() []*Block {
+	return self.stanzaBlocks(ruleFinallyStanza)
+}
+
+func (self *TryCatch) stanzaBlocks(rule pegRule) []*Block {
+	var blocks = make([]*Block, 0)
+
+	if stanza := self.statement.node.firstChild(rule); stanza != nil {
+		for _, node := range stanza.findUntil(0, ruleCLOSE, ruleBlock) {
+			blocks = append(blocks, &Block{
+				friendscript: self.statement.Script(),
+				node:         node.first(),
+				parent:       self.statement,
+			})
+		}
+	}
+
+	return blocks
+}
@@ -0,0 +1,143 @@
+package scripting
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ghetzel/go-stockutil/stringutil"
+)
+
+// placeholders used to protect escaped braces from being treated as interpolation sequences
+const (
+	escapedBraceOpen  = '\uE000'
+	escapedBraceClose = '\uE001'
+)
+
+var simpleEscapes = map[byte]string{
+	'0':  "\x00",
+	'a':  "\a",
+	'b':  "\b",
+	'e':  "\x1b",
+	'f':  "\f",
+	'n':  "\n",
+	'r':  "\r",
+	't':  "\t",
+	'v':  "\v",
+	'\\': `\`,
+	'\'': `'`,
+	'"':  `"`,
+	'/':  `/`,
+}
+
+// Replace the escape sequences in the given string with the characters they represent.  Supported
+// sequences are \n, \t, \r, \a, \b, \e, \f, \v, \0, \\, \', \", \/, \xHH, \uHHHH, and \UHHHHHHHH.
+// If keepBraces is true, \{ and \} are replaced with placeholders that will not be interpolated
+// (see restoreBraces).  Any other backslash sequence is left as-is, so regular expressions like \d+
+// survive unchanged, but a backslash that happens to start a supported sequence (e.g.: the \t in
+// C:\temp) is replaced unless it is doubled.  This applies to single-quoted strings as well.
+func unescapeString(in string, keepBraces bool) string {
+	if !strings.Contains(in, `\`) {
+		return in
+	}
+
+	var out strings.Builder
+
+	for i := 0; i < len(in); i++ {
+		if in[i] != '\\' || i+1 >= len(in) {
+			out.WriteByte(in[i])
+			continue
+		}
+
+		var next = in[i+1]
+
+		if replacement, ok := simpleEscapes[next]; ok {
+			out.WriteString(replacement)
+			i += 1
+			continue
+		}
+
+		switch next {
+		case '{', '}':
+			if keepBraces {
+				if next == '{' {
+					out.WriteRune(escapedBraceOpen)
+				} else {
+					out.WriteRune(escapedBraceClose)
+				}
+			} else {
+				out.WriteByte(next)
+			}
+
+			i += 1
+			continue
+
+		case 'x', 'u', 'U':
+			var width = map[byte]int{'x': 2, 'u': 4, 'U': 8}[next]
+
+			if i+2+width <= len(in) {
+				if code, err := strconv.ParseUint(in[i+2:i+2+width], 16, 32); err == nil {
+					if next == 'x' {
+						out.WriteByte(byte(code))
+						i += 1 + width
+						continue
+					} else if utf8.ValidRune(rune(code)) {
+						out.WriteRune(rune(code))
+						i += 1 + width
+						continue
+					}
+				}
+			}
+		}
+
+		out.WriteByte(in[i])
+	}
+
+	return out.String()
+}
+
+// Replace the brace placeholders inserted by unescapeString with literal braces.
+func restoreBraces(in string) string {
+	in = strings.ReplaceAll(in, string(escapedBraceOpen), `{`)
+	in = strings.ReplaceAll(in, string(escapedBraceClose), `}`)
+
+	return in
+}
+
+// Remove the leading blank line, trailing whitespace, and the indentation common to every non-blank line
+// of the given text.
+func dedent(in string) string {
+	var lines = strings.Split(strings.TrimRight(in, " \t\r\n"), "\n")
+
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == `` {
+		lines = lines[1:]
+	}
+
+	if indent := commonIndent(lines); indent != `` {
+		for i, line := range lines {
+			lines[i] = strings.TrimPrefix(line, indent)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Return the longest run of leading whitespace shared by all of the given lines that are not blank.
+func commonIndent(lines []string) string {
+	var indents []string
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) != `` {
+			indents = append(indents, line[:len(line)-len(strings.TrimLeft(line, " \t"))])
+		}
+	}
+
+	switch len(indents) {
+	case 0:
+		return ``
+	case 1:
+		return indents[0]
+	default:
+		return stringutil.LongestCommonPrefix(indents)
+	}
+}
@@ -2,13 +2,23 @@ package friendscript
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/ghetzel/friendscript/scripting"
 	"github.com/ghetzel/friendscript/utils"
+	"github.com/ghetzel/go-stockutil/fileutil"
 	"github.com/ghetzel/go-stockutil/httputil"
 	"github.com/ghetzel/go-stockutil/maputil"
 	"github.com/ghetzel/go-stockutil/typeutil"
@@ -19,7 +29,12 @@ import (
 
 type testCommands struct {
 	utils.Module
-	env utils.Runtime
+	env     utils.Runtime
+	created []string
+	removed []string
+	running int
+	peak    int
+	clock   sync.Mutex
 }
 
 func newTestCommands(env utils.Runtime) *testCommands {
@@ -32,14 +47,103 @@ func newTestCommands(env utils.Runtime) *testCommands {
 }
 
 func (self *testCommands) MapArg(key string, m map[string]any) error {
-	self.env.Scope().Set(strings.TrimSpace(key), m)
-	return nil
+	return self.env.Scope().Assign(strings.TrimSpace(key), m)
 }
 
 func (self *testCommands) Noop() error {
 	return nil
 }
 
+func (self *testCommands) Create(name string) (map[string]any, error) {
+	self.clock.Lock()
+	defer self.clock.Unlock()
+
+	self.created = append(self.created, name)
+
+	return map[string]any{
+		`name`: name,
+		`id`:   len(self.created),
+	}, nil
+}
+
+func (self *testCommands) UndoCreate(name string, _ map[string]any, result map[string]any) error {
+	self.clock.Lock()
+	defer self.clock.Unlock()
+
+	if name == `stuck` {
+		return fmt.Errorf("cannot remove %v (id %v)", name, result[`id`])
+	}
+
+	self.created = slices.DeleteFunc(self.created, func(n string) bool {
+		return n == name
+	})
+
+	self.removed = append(self.removed, name)
+
+	return nil
+}
+
+func (self *testCommands) Remove(name string) error {
+	self.clock.Lock()
+	defer self.clock.Unlock()
+
+	self.created = slices.DeleteFunc(self.created, func(n string) bool {
+		return n == name
+	})
+
+	self.removed = append(self.removed, name)
+
+	return nil
+}
+
+func (self *testCommands) UndoRemove(name string) error {
+	self.clock.Lock()
+	defer self.clock.Unlock()
+
+	self.created = append(self.created, name)
+
+	return nil
+}
+
+// Waits until the given number of callers have been running at the same time, which proves that they
+// run concurrently.  Gives up (and fails) if they have not all arrived after a few seconds.
+func (self *testCommands) Rendezvous(parties int) error {
+	self.clock.Lock()
+	self.running += 1
+	self.peak = max(self.peak, self.running)
+	self.clock.Unlock()
+
+	defer func() {
+		self.clock.Lock()
+		self.running -= 1
+		self.clock.Unlock()
+	}()
+
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		self.clock.Lock()
+		var peak = self.peak
+		self.clock.Unlock()
+
+		if peak >= parties {
+			return nil
+		} else if time.Now().After(deadline) {
+			return fmt.Errorf("only %d of %d callers were running at once", peak, parties)
+		}
+	}
+}
+
+type scheduleArgs struct {
+	Delay time.Duration `json:"delay"`
+	At    time.Time     `json:"at"`
+}
+
+func (self *testCommands) Schedule(args *scheduleArgs) (map[string]any, error) {
+	return map[string]any{
+		`delay`: args.Delay,
+		`at`:    args.At,
+	}, nil
+}
+
 func eval(script string, items ...any) (map[string]any, error) {
 	env := NewEnvironment()
 	env.RegisterModule(`testing`, newTestCommands(env))
@@ -68,6 +172,8 @@ func TestListCommands(t *testing.T) {
 	assert.Contains(commands, `fmt::trim`)
 	assert.Contains(commands, `testing::map_arg`)
 	assert.Contains(commands, `testing::noop`)
+	assert.Contains(commands, `testing::create`)
+	assert.NotContains(commands, `testing::undo_create`)
 	assert.Contains(commands, `vars::get`)
 }
 
@@ -83,12 +189,12 @@ func TestAssignments(t *testing.T) {
 		`c2`:   "Test {c}",
 		`d`:    3.14159,
 		`e`: []any{
-			float64(1),
+			1,
 			true,
 			"Test",
 			3.14159,
 			[]any{
-				float64(1),
+				1,
 				true,
 				"Test",
 				3.14159,
@@ -118,7 +224,7 @@ func TestAssignments(t *testing.T) {
 		`x`: "Test",
 		`y`: 3.14159,
 		`z`: []any{
-			float64(1),
+			1,
 			true,
 			"Test",
 			3.14159,
@@ -180,17 +286,17 @@ func TestAssignments(t *testing.T) {
 		`put_1`: `test 1`,
 		`put_2`: `test {a}`,
 		`put_3`: []any{
-			float64(1),
-			float64(2),
-			float64(3),
+			1,
+			2,
+			3,
 		},
-		`put_4`: "put test four\n\t\tput test\n\t\tput end\n\t\tend friend end",
+		`put_4`: "put test four\nput test\nput end\nend friend end",
 		`t_maparg`: map[string]any{
 			`one`:   `first`,
 			`two`:   `second`,
 			`three`: `third`,
 		},
-		`ulit1`: `\u2211`,
+		`ulit1`: "\u2211",
 		`ulit2`: "\u2211",
 		`vars_set_1`: []any{
 			`set1`,
@@ -467,6 +573,72 @@ func TestConditionals(t *testing.T) {
 	assert.Equal(expected, actual)
 }
 
+func TestConditionalLogic(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+        $a = 5
+        $b = "x"
+        $c = false
+        $branches = null
+        $whiles = null
+        $bounded = null
+
+        if $a > 1 and ($b == "x" or not $c) { $if_and_group = true }
+        if $a > 10 or $b == "x" and $c      { $if_precedence = true }
+        if ($a > 10 or $b == "x") and $c    { $if_group_first = true }
+        if not ($a > 1 and $b == "y")       { $if_not_group = true }
+        if $a < 1 or $b =~ /^X$/i           { $if_or_regex = true }
+        if (($a == 5))                      { $if_nested = true }
+        if ($a + 1) * 2 == 12 and ($a > 1)  { $if_arith_group = true }
+
+        # inline commands on the right side are never evaluated if the left decides the result
+        if $a == 1 and (nosuchmodule::explode) { $if_short_and = true }
+        if $a == 5 or (nosuchmodule::explode)  { $if_short_or = true }
+
+        if put 1 { $if_bare_command = true }
+
+        if $a == 1 {
+            $branches << 'if'
+        } else if $a == 5 and $b == "x" {
+            $branches << 'elif'
+        } else {
+            $branches << 'else'
+        }
+
+        loop $a < 8 and $b == "x" {
+            $whiles << $a
+            $a = $a + 1
+        }
+
+        def next($n) {
+            return $n + 1
+        }
+
+        loop put 0 -> $i; $i < 10 and not $i == 4; next $i -> $i {
+            if $i == 1 {
+                continue
+            }
+
+            $bounded << $i
+        }`)
+
+	assert.NoError(err)
+	assert.Equal(true, actual[`if_and_group`])
+	assert.Nil(actual[`if_precedence`])
+	assert.Nil(actual[`if_group_first`])
+	assert.Equal(true, actual[`if_not_group`])
+	assert.Equal(true, actual[`if_or_regex`])
+	assert.Equal(true, actual[`if_nested`])
+	assert.Equal(true, actual[`if_arith_group`])
+	assert.Nil(actual[`if_short_and`])
+	assert.Equal(true, actual[`if_short_or`])
+	assert.Equal(true, actual[`if_bare_command`])
+	assert.Equal([]any{`elif`}, actual[`branches`])
+	assert.Equal([]any{5, 6, 7}, actual[`whiles`])
+	assert.Equal([]any{0, 2, 3}, actual[`bounded`])
+}
+
 func TestExpressions(t *testing.T) {
 	assert := require.New(t)
 
@@ -479,9 +651,10 @@ func TestExpressions(t *testing.T) {
 		`bb`:    6,
 		`cc`:    20,
 		`dd`:    5,
+		`e`:     -610,
 		`f`:     `This 2 is {b} and done`,
 		`put_a`: `this is some stuff`,
-		`put_b`: "buncha\n    muncha\n    cruncha\n    lines",
+		`put_b`: "buncha\nmuncha\ncruncha\nlines",
 	}
 
 	script := `
@@ -489,7 +662,7 @@ func TestExpressions(t *testing.T) {
         $b = 9 - 3
         $c = 5 * 4
         $d = 50 / 10
-        #$e = 4 * -6 * (3 * 7 + 5) + 2 * 7
+        $e = 4 * -6 * (3 * 7 + 5) + 2 * 7
         $aa = 1
         $aa += 1
         $bb = 9
@@ -516,6 +689,251 @@ func TestExpressions(t *testing.T) {
 	assert.Equal(expected, actual)
 }
 
+func TestExpressionPrecedence(t *testing.T) {
+	assert := require.New(t)
+
+	for _, tc := range []struct {
+		expr     string
+		expected any
+	}{
+		{`1 + 2 * 3`, 7},
+		{`(1 + 2) * 3`, 9},
+		{`2 * 3 + 4 * 5`, 26},
+		{`10 - 2 - 3`, 5},
+		{`10 - (2 - 3)`, 11},
+		{`100 / 10 / 5`, 2},
+		{`7 / 2`, 3.5},
+		{`2 ** 3 ** 2`, 512},
+		{`(2 ** 3) ** 2`, 64},
+		{`2 * 3 ** 2`, 18},
+		{`10 % 4 * 3`, 6},
+		{`10 - 4 % 3`, 9},
+		{`6 & 3 + 1`, 4},
+		{`12 ^ 2 * 3`, 10},
+		{`(6 & 3) + 1`, 3},
+		{`1 | 2 + 4`, 7},
+		{`((1 + 2) * (3 + 4))`, 21},
+		{`4 * -6 * (3 * 7 + 5) + 2 * 7`, -610},
+		{`$x * 2 + 3`, 13},
+		{`2 + 3 * $x - 1`, 16},
+		{`($x + 1) * ($x - 1)`, 24},
+		{`"a" + "b" + 1 * 2`, `ab2`},
+		{`-2 ** 2`, -4},
+		{`2 ** -1`, 0.5},
+		{`-$x * 2`, -10},
+		{`10 - -$x`, 15},
+		{`~5 & 7`, 2},
+		{`-(1 + 2) * 3`, -9},
+	} {
+		actual, err := eval("$x = 5\n$result = " + tc.expr)
+		assert.NoError(err, tc.expr)
+		assert.EqualValues(tc.expected, actual[`result`], tc.expr)
+	}
+}
+
+func TestUnaryOperators(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+        $x = 5
+        $f = 2.5
+        $d = 1.25d
+        $zero = 0
+        $neg_int = -$x
+        $neg_float = -$f
+        $neg_dec = -$d
+        $double_neg = - -$x
+        $inverted = ~$x
+        $inverted_zero = ~0
+        $flag = not $x
+        $bang = !$zero
+        $bang_str = !"false"
+        $not_not = not not $x
+        $neg_group = -($x + 1)
+
+        if not $x == 4 { $not_cond = true }
+        if $x != 4 and !$zero { $bang_cond = true }
+        if -$x < 0 { $neg_cond = true }`)
+
+	assert.NoError(err)
+	assert.Equal(-5, actual[`neg_int`])
+	assert.Equal(-2.5, actual[`neg_float`])
+	assert.Equal(`-1.25`, fmt.Sprintf("%v", actual[`neg_dec`]))
+	assert.Equal(5, actual[`double_neg`])
+	assert.Equal(-6, actual[`inverted`])
+	assert.Equal(-1, actual[`inverted_zero`])
+	assert.Equal(false, actual[`flag`])
+	assert.Equal(true, actual[`bang`])
+	assert.Equal(true, actual[`bang_str`])
+	assert.Equal(true, actual[`not_not`])
+	assert.Equal(-6, actual[`neg_group`])
+	assert.Equal(true, actual[`not_cond`])
+	assert.Equal(true, actual[`bang_cond`])
+	assert.Equal(true, actual[`neg_cond`])
+
+	_, err = eval(`$x = ~1.5`)
+	assert.Error(err)
+
+	_, err = eval(`$x = -"abc"`)
+	assert.Error(err)
+}
+
+func TestNumericTypes(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+        $int_sum = 2 + 2
+        $int_product = 6 * 7
+        $int_quotient = 10 / 2
+        $frac_quotient = 7 / 2
+        $int_mod = 10 % 3
+        $int_pow = 2 ** 10
+        $float_sum = 1.5 + 1
+        $float_drift = 0.1 + 0.2
+        $big_id = 9007199254740993
+        $big_next = $big_id + 1
+        $overflow = 9223372036854775807 + 1
+        $huge = 2 ** 100
+        $shrunk = $overflow - 1
+
+        $price = 19.99d
+        $total = $price * 3
+        $money = 0.1d + 0.2d
+        $money_eq = null
+        if $money == 0.3d { $money_eq = true }
+        if 0.1 + 0.2 != 0.3 { $float_ne = true }
+        if $big_next > $big_id { $big_gt = true }
+        if 2.50d == 2.5 { $dec_float_eq = true }
+        $third = 1d / 3
+        $split = 10d / 4
+        $dec_int = 3 * 2d
+        $dec_str = "total: {total}"
+        $dec_zero = null
+        if not 0.00d { $dec_zero = true }
+
+        $counter = 5
+        $counter += 2
+        $counter *= 3
+        $cost = 1.10d
+        $cost += 2.20d`)
+
+	assert.NoError(err)
+	assert.Equal(4, actual[`int_sum`])
+	assert.Equal(42, actual[`int_product`])
+	assert.Equal(5, actual[`int_quotient`])
+	assert.Equal(3.5, actual[`frac_quotient`])
+	assert.Equal(1, actual[`int_mod`])
+	assert.Equal(1024, actual[`int_pow`])
+	assert.Equal(2.5, actual[`float_sum`])
+	assert.IsType(float64(0), actual[`float_drift`])
+	assert.Equal(9007199254740993, actual[`big_id`])
+	assert.Equal(9007199254740994, actual[`big_next`])
+	assert.Equal(`9223372036854775808`, fmt.Sprintf("%v", actual[`overflow`]))
+	assert.Equal(`1267650600228229401496703205376`, fmt.Sprintf("%v", actual[`huge`]))
+	assert.Equal(math.MaxInt64, actual[`shrunk`])
+
+	assert.Equal(`19.99`, fmt.Sprintf("%v", actual[`price`]))
+	assert.Equal(`59.97`, fmt.Sprintf("%v", actual[`total`]))
+	assert.Equal(`0.3`, fmt.Sprintf("%v", actual[`money`]))
+	assert.Equal(true, actual[`money_eq`])
+	assert.Equal(true, actual[`float_ne`])
+	assert.Equal(true, actual[`big_gt`])
+	assert.Equal(true, actual[`dec_float_eq`])
+	assert.Equal(`0.33333333333333333333333333333333`, fmt.Sprintf("%v", actual[`third`]))
+	assert.Equal(`2.5`, fmt.Sprintf("%v", actual[`split`]))
+	assert.Equal(`6`, fmt.Sprintf("%v", actual[`dec_int`]))
+	assert.Equal(`total: 59.97`, actual[`dec_str`])
+	assert.Equal(true, actual[`dec_zero`])
+	assert.Equal(21, actual[`counter`])
+	assert.Equal(`3.3`, fmt.Sprintf("%v", actual[`cost`]))
+
+	_, ok := actual[`total`].(*scripting.Decimal)
+	assert.True(ok)
+
+	data, err := json.Marshal(actual[`total`])
+	assert.NoError(err)
+	assert.Equal(`59.97`, string(data))
+
+	_, err = eval(`$x = 1 / 0`)
+	assert.Error(err)
+
+	_, err = eval(`$x = 1.5d ** 100000000`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `1.5 ** 100000000 is too large to calculate`)
+
+	actual, err = eval(`
+        $small = 1.5d ** 2
+        $one = 1d ** 100000000`)
+
+	assert.NoError(err)
+	assert.Equal(`2.25`, fmt.Sprintf("%v", actual[`small`]))
+	assert.Equal(`1`, fmt.Sprintf("%v", actual[`one`]))
+
+	_, err = eval(`$x = 1.5d / 0`)
+	assert.Error(err)
+}
+
+func TestTimeValues(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+        $timeout = 1h30m
+        $short = 250ms
+        $start = @2026-01-02T15:04:05Z
+        $date = @2026-01-02
+        $later = $start + $timeout
+        $earlier = $start - 30m
+        $elapsed = $later - $start
+        $total = $timeout + $short
+        $doubled = $short * 2
+        $half = $timeout / 2
+        $ratio = $timeout / 30m
+        $neg = -$short
+
+        if $later > $start  { $later_gt = true }
+        if $elapsed == 90m  { $elapsed_eq = true }
+        if $short < 1s      { $short_lt = true }
+        if $date < $start   { $date_lt = true }
+
+        testing::schedule {
+            delay: 5s,
+            at:    @2026-01-02T15:04:05Z,
+        } -> $scheduled
+
+        wait 1ms`)
+
+	assert.NoError(err)
+
+	start := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	assert.Equal(90*time.Minute, actual[`timeout`])
+	assert.Equal(250*time.Millisecond, actual[`short`])
+	assert.Equal(start, actual[`start`])
+	assert.Equal(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), actual[`date`])
+	assert.Equal(start.Add(90*time.Minute), actual[`later`])
+	assert.Equal(start.Add(-30*time.Minute), actual[`earlier`])
+	assert.Equal(90*time.Minute, actual[`elapsed`])
+	assert.Equal(90*time.Minute+250*time.Millisecond, actual[`total`])
+	assert.Equal(500*time.Millisecond, actual[`doubled`])
+	assert.Equal(45*time.Minute, actual[`half`])
+	assert.Equal(3, actual[`ratio`])
+	assert.Equal(-250*time.Millisecond, actual[`neg`])
+	assert.Equal(true, actual[`later_gt`])
+	assert.Equal(true, actual[`elapsed_eq`])
+	assert.Equal(true, actual[`short_lt`])
+	assert.Equal(true, actual[`date_lt`])
+	assert.Equal(map[string]any{
+		`delay`: 5 * time.Second,
+		`at`:    start,
+	}, actual[`scheduled`])
+
+	_, err = eval(`$x = @2026-01-02T15:04:05Z + @2026-01-02T15:04:05Z`)
+	assert.Error(err)
+
+	_, err = eval(`$x = @2026-13-45`)
+	assert.Error(err)
+}
+
 func TestLoops(t *testing.T) {
 	assert := require.New(t)
 
@@ -525,17 +943,17 @@ func TestLoops(t *testing.T) {
 		`double_continue`: []any{8, 9},
 		`iterations`:      4,
 		`things`: []any{
-			float64(1),
-			float64(2),
-			float64(3),
-			float64(4),
-			float64(5),
+			1,
+			2,
+			3,
+			4,
+			5,
 		},
 		`topindex`: 9,
 		`map`: map[string]any{
-			`first`:  float64(1),
-			`second`: float64(2),
-			`third`:  float64(3),
+			`first`:  1,
+			`second`: 2,
+			`third`:  3,
 		},
 		`m1`:   `first:1`,
 		`m2`:   `second:2`,
@@ -822,6 +1240,416 @@ func TestHttp(t *testing.T) {
 	assert.EqualValues(`yes`, maputil.DeepGet(actual[`post_json_object`], []string{`body`, `value`}))
 }
 
+func TestInclude(t *testing.T) {
+	assert := require.New(t)
+	dir := t.TempDir()
+
+	for name, src := range map[string]string{
+		`main.fs`:            "$greeting = 'hello'\ninclude \"common/setup\"\n$after = $name",
+		`common/setup.fs`:    "$name = 'world'\n$message = \"{greeting} {name}\"\ninclude 'more'",
+		`common/more.fs`:     "$seen << 'more'",
+		`twice.fs`:           "include 'common/more'\ninclude 'common/more.fs'",
+		`cycle/a.fs`:         "include 'b'",
+		`cycle/b.fs`:         "include 'a'",
+		`globbed/1-first.fs`: "$order << 1",
+		`glob.fs`:            "include 'globbed/*.fs'",
+		`shadowed.fs`:        "include 'virtual/setup'\n$after = $name",
+		`virtual/setup.fs`:   "$name = 'from disk'",
+	} {
+		path := filepath.Join(dir, name)
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(os.WriteFile(path, []byte(src), 0644))
+	}
+
+	env := NewEnvironment()
+	scope, err := env.EvaluateFile(filepath.Join(dir, `main.fs`))
+	assert.NoError(err)
+	assert.Equal(`world`, scope.Get(`name`))
+	assert.Equal(`world`, scope.Get(`after`))
+	assert.Equal(`hello world`, scope.Get(`message`))
+	assert.Equal([]any{`more`}, scope.Get(`seen`))
+
+	env = NewEnvironment()
+	scope, err = env.EvaluateFile(filepath.Join(dir, `twice.fs`))
+	assert.NoError(err)
+	assert.Equal([]any{`more`, `more`}, scope.Get(`seen`))
+	assert.Len(env.includes, 1)
+
+	// glob patterns are not expanded
+	env = NewEnvironment()
+	_, err = env.EvaluateFile(filepath.Join(dir, `glob.fs`))
+	assert.Error(err)
+	assert.Contains(err.Error(), `could not locate script "globbed/*.fs"`)
+
+	// registered path readers are consulted before the filesystem, for any name
+	var requested []string
+
+	env = NewEnvironment()
+	env.RegisterPathReader(func(path string) (io.ReadCloser, error) {
+		requested = append(requested, path)
+
+		switch path {
+		case `virtual/setup`:
+			return io.NopCloser(strings.NewReader("$name = 'from reader'")), nil
+		case `mem:broken`:
+			return nil, fmt.Errorf("reader failed")
+		}
+
+		return nil, nil
+	})
+
+	scope, err = env.EvaluateFile(filepath.Join(dir, `shadowed.fs`))
+	assert.NoError(err)
+	assert.Equal(`from reader`, scope.Get(`after`))
+
+	scope, err = env.EvaluateString(fmt.Sprintf("include %q", filepath.Join(dir, `common`, `more`)))
+	assert.NoError(err)
+	assert.Equal([]any{`more`}, scope.Get(`seen`))
+
+	_, err = env.EvaluateString("include 'mem:broken'")
+	assert.Error(err)
+	assert.Contains(err.Error(), `reader failed`)
+	assert.Equal([]string{
+		`virtual/setup`,
+		filepath.Join(dir, `common`, `more`),
+		filepath.Join(dir, `common`, `more.fs`),
+		`mem:broken`,
+	}, requested)
+
+	env = NewEnvironment()
+	_, err = env.EvaluateFile(filepath.Join(dir, `cycle`, `a.fs`))
+	assert.Error(err)
+	assert.Contains(err.Error(), `include cycle detected`)
+	assert.Contains(err.Error(), `a.fs -> `+filepath.Join(dir, `cycle`, `b.fs`)+` -> `+filepath.Join(dir, `cycle`, `a.fs`))
+
+	_, err = eval(`include 'does-not-exist'`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `could not locate script "does-not-exist"`)
+}
+
+func TestEvents(t *testing.T) {
+	assert := require.New(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(`/status/201`, func(w http.ResponseWriter, req *http.Request) {
+		httputil.RespondJSON(w, map[string]any{`ok`: true}, http.StatusCreated)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	env := NewEnvironment()
+	var goEvents []string
+
+	env.RegisterEventHandler(`custom.*`, func(event *Event) error {
+		goEvents = append(goEvents, event.Name)
+		return nil
+	})
+
+	scope, err := env.EvaluateString(fmt.Sprintf(`
+        $statuses = null
+        $custom = null
+        $exited = false
+
+        loop count 2 {
+            on "http.response" {
+                $statuses << $event.data.status
+                $last_ok = $event.data.body.ok
+            }
+        }
+
+        on "custom.thing" {
+            $custom = $event.data.value
+        }
+
+        on "script.exit" {
+            $exited = $event.data.success
+        }
+
+        http::get %q`, server.URL+`/status/201`))
+
+	assert.NoError(err)
+	assert.Equal([]any{201}, scope.Get(`statuses`))
+	assert.Equal(true, scope.Get(`last_ok`))
+	assert.Equal(true, scope.Get(`exited`))
+
+	assert.NoError(env.Emit(`custom.thing`, map[string]any{`value`: 42}))
+	assert.Equal(42, scope.Get(`custom`))
+	assert.Equal([]string{`custom.thing`}, goEvents)
+
+	env = NewEnvironment()
+	scope, err = env.EvaluateString(`
+        $failure = null
+        on "script.error" {
+            $failure = $event.data.error
+        }
+
+        nosuchmodule::command`)
+
+	assert.Error(err)
+	assert.Contains(scope.Get(`failure`), `Cannot locate module "nosuchmodule"`)
+
+	// events emitted by several branches at once are all handled, even while handlers for the other
+	// branches are still running; handlers only skip events emitted by their own call chain
+	env = NewEnvironment()
+	cmds := newTestCommands(env)
+	env.RegisterModule(`testing`, cmds)
+
+	var responses int
+	var rlock sync.Mutex
+
+	env.RegisterEventHandler(`http.response`, func(event *Event) error {
+		rlock.Lock()
+		responses += 1
+		rlock.Unlock()
+
+		return cmds.Rendezvous(4)
+	})
+
+	scope, err = env.EvaluateString(fmt.Sprintf(`
+        $url = %q
+
+        on "http.response" {
+            http::get $url
+        }
+
+        loop parallel 4 $i in 1..4 {
+            http::get $url
+        }`, server.URL+`/status/201`))
+
+	// every request made by the loop is handled, as is the request made by the script's handler for
+	// each of them (which it does not handle itself)
+	assert.NoError(err)
+	assert.Equal(8, responses)
+}
+
+func TestFunctions(t *testing.T) {
+	assert := require.New(t)
+
+	env := NewEnvironment()
+	scope, err := env.EvaluateString(`
+        $punctuation = '!'
+
+        def greet($name, {loud: false, greeting: 'hello'}) {
+            $message = "{greeting}, {name}{punctuation}"
+
+            if $loud {
+                fmt::upper $message -> $message
+            }
+
+            return $message
+        }
+
+        def fib($n) {
+            if $n < 2 {
+                return $n
+            }
+
+            $n1 = $n - 1
+            $n2 = $n - 2
+            fib $n1 -> $a
+            fib $n2 -> $b
+            return $a + $b
+        }
+
+        def first_even($items) {
+            loop $item in $items {
+                if $item % 2 == 0 {
+                    return $item
+                }
+            }
+        }
+
+        def defaults({x: 1, y: 2}) {
+            return $x, $y
+        }
+
+        def nothing() {
+            $leaked = true
+        }
+
+        greet 'friend' -> $a
+        greet 'friend' {loud: true, greeting: 'hey'} -> $b
+        script::greet 'there' -> $c
+        fib 10 -> $d
+        first_even [1, 3, 4, 5, 6] -> $e
+        defaults {y: 5} -> $f
+        nothing -> $g`)
+
+	assert.NoError(err)
+	assert.Equal(`hello, friend!`, scope.Get(`a`))
+	assert.Equal(`HEY, FRIEND!`, scope.Get(`b`))
+	assert.Equal(`hello, there!`, scope.Get(`c`))
+	assert.EqualValues(55, scope.Get(`d`))
+	assert.EqualValues(4, scope.Get(`e`))
+	assert.Equal([]any{1, 5}, scope.Get(`f`))
+	assert.Nil(scope.Get(`g`))
+	assert.Nil(scope.Get(`leaked`))
+	assert.Nil(scope.Get(`message`))
+
+	commands := env.Commands()
+	assert.Contains(commands, `script::greet`)
+	assert.Contains(commands, `script::fib`)
+
+	env.DisableCommand(ScriptModuleName, `greet`)
+	assert.NotContains(env.Commands(), `script::greet`)
+
+	_, err = env.EvaluateString(`greet 'again'`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `script::greet command has been disabled`)
+
+	_, err = eval(`
+        def greet({loud: false}) {}
+        greet {quiet: true}`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `unknown option "quiet"`)
+
+	_, err = eval(`
+        def forever() {
+            forever
+        }
+
+        forever`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `maximum call depth`)
+}
+
+func TestTryCatch(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+        $steps = null
+        $caught = null
+
+        try {
+            $steps << 'try'
+            assert::null 'not null' {message: 'oops'}
+            $steps << 'unreachable'
+        } catch $err {
+            $steps << 'catch'
+            $caught = $err
+        } finally {
+            $steps << 'finally'
+        }
+
+        try {
+            $steps << 'try2'
+        } finally {
+            $steps << 'finally2'
+        }
+
+        def flaky() {
+            nosuchmodule::explode
+        }
+
+        try {
+            flaky
+        } catch $fnerr {
+            $fn_command = $fnerr.command
+            $fn_module = $fnerr.module
+        }
+
+        loop count 5 {
+            try {
+                if $index > 2 {
+                    break
+                }
+            } finally {
+                $last_index = $index
+            }
+        }
+
+        try {
+            put 1
+        } catch {
+            $never = true
+        }`)
+
+	assert.NoError(err)
+	assert.Equal([]any{`try`, `catch`, `finally`, `try2`, `finally2`}, actual[`steps`])
+	assert.Equal(`explode`, actual[`fn_command`])
+	assert.Equal(`nosuchmodule`, actual[`fn_module`])
+	assert.EqualValues(3, actual[`last_index`])
+	assert.Nil(actual[`never`])
+
+	caught, ok := actual[`caught`].(map[string]any)
+	assert.True(ok)
+	assert.Contains(caught[`message`], `oops`)
+	assert.Equal(`assert`, caught[`module`])
+	assert.Equal(`null`, caught[`command`])
+	assert.Equal(`assert::null 'not null' {message: 'oops'}`, caught[`snippet`])
+	assert.EqualValues(7, caught[`line`])
+
+	_, err = eval(`
+        try {
+            put 1
+        } finally {
+            nosuchmodule::explode
+        }`)
+
+	assert.Error(err)
+	assert.Contains(err.Error(), `Cannot locate module "nosuchmodule"`)
+
+	_, err = eval(`
+        try {
+            nosuchmodule::first
+        } catch {
+            nosuchmodule::second
+        }`)
+
+	assert.Error(err)
+	assert.Contains(err.Error(), `"nosuchmodule"`)
+}
+
+func TestUnset(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+        $a = 1
+        $b = {c: true, d: 'dee', e: [1, 2, 3]}
+        $f = 'eff'
+        $g = 'gee'
+        $outer = 'outer'
+
+        unset $a, $b.c
+        unset $b.e[1]
+        unset $b.e[-1]
+        vars::clear 'f'
+
+        if 1 == 1 {
+            unset $outer
+            unset $never_set
+        }
+
+        vars::keys -> $keys
+        unset $keys`)
+
+	assert.NoError(err)
+	assert.Equal(map[string]any{
+		`b`: map[string]any{
+			`d`: `dee`,
+			`e`: []any{1},
+		},
+		`g`: `gee`,
+	}, actual)
+
+	actual, err = eval(`
+        $x = 1
+        $y = {z: true}
+        unset $y.z
+        vars::keys -> $keys`)
+
+	assert.NoError(err)
+	assert.Equal([]any{`x`}, actual[`keys`])
+
+	_, err = eval(`
+        $s = 'string'
+        unset $s.nope`)
+
+	assert.Error(err)
+	assert.Contains(err.Error(), `not an object or array`)
+}
+
 func jsondiff(expected any, actual any) string {
 	if expectedJ, err := json.MarshalIndent(expected, ``, `  `); err == nil {
 		if actualJ, err := json.MarshalIndent(actual, ``, `  `); err == nil {
@@ -847,3 +1675,1294 @@ func jsondiff(expected any, actual any) string {
 		return fmt.Sprintf("ERROR: expected: %v", err)
 	}
 }
+
+func TestStringEscapes(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$name = 'world'
+	$json = "\{\"hello\": \"{name}\"\}"
+	$quoted = 'it\'s a "test"'
+	$tabbed = "a\tb\nc"
+	$unicode = '∑ \U0001F600 \x41'
+	$braces = "\{name\} is {name}"
+	$literal = '\{name\}'
+	$regexy = '\d+\.\w*'
+	$slash = "back\\slash"
+	$winpath = 'C:\Users\me'
+	$wintemp = 'C:\temp'
+	$wintempEscaped = 'C:\\temp'
+	put """
+		first
+		  second
+		third
+	""" -> $heredoc
+	`)
+
+	assert.NoError(err)
+	assert.Equal(`{"hello": "world"}`, actual[`json`])
+	assert.Equal(`it's a "test"`, actual[`quoted`])
+	assert.Equal("a\tb\nc", actual[`tabbed`])
+	assert.Equal("∑ 😀 A", actual[`unicode`])
+	assert.Equal(`{name} is world`, actual[`braces`])
+	assert.Equal(`{name}`, actual[`literal`])
+	assert.Equal(`\d+\.\w*`, actual[`regexy`])
+	assert.Equal(`back\slash`, actual[`slash`])
+	assert.Equal("first\n  second\nthird", actual[`heredoc`])
+
+	// single-quoted strings interpret escape sequences too, so a backslash that starts one must be doubled
+	assert.Equal(`C:\Users\me`, actual[`winpath`])
+	assert.Equal("C:\temp", actual[`wintemp`])
+	assert.Equal(`C:\temp`, actual[`wintempEscaped`])
+}
+
+func TestInterpolationFilters(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$user = {name: 'friend'}
+	$items = ['a', 'b', 'c']
+	$price = 19.995d
+	$a = "Hello {user.name|upper}!"
+	$b = "{items|join:', '}"
+	$c = "Total: {price|printf:'%.2f'}"
+	$d = "{user.email ?? 'anonymous'}"
+	$e = "{user.email ?? \"no\" | upper}"
+	`)
+
+	assert.NoError(err)
+	assert.Equal(`Hello FRIEND!`, actual[`a`])
+	assert.Equal(`a, b, c`, actual[`b`])
+	assert.Equal(`Total: 20.00`, actual[`c`])
+	assert.Equal(`anonymous`, actual[`d`])
+	assert.Equal(`NO`, actual[`e`])
+
+	_, err = eval(`$x = "{items|nope}"`)
+	assert.Error(err)
+
+	env := NewEnvironment()
+	env.SetStrict(true)
+
+	_, err = env.EvaluateString("$name = 'friend'\n$ok = \"hi {name}\"\n$bad = \"hi {nmae}\"")
+	assert.Error(err)
+	assert.Contains(err.Error(), `line 3`)
+	assert.Contains(err.Error(), `$nmae`)
+	assert.Equal(`hi friend`, env.Get(`ok`))
+
+	var cerr *scripting.ContextError
+	assert.True(errors.As(err, &cerr))
+}
+
+func TestSlicesAndNestedAssignment(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$l = [1, 2, 3, 4, 5]
+	$middle = $l[1:3]
+	$head = $l[:-2]
+	$tail = $l[-2:]
+	$last = $l[-1]
+	$l[-1] = 50
+	$name = 'friendscript'
+	$prefix = $name[0:6]
+	$final = $name[-1]
+	$grid = [[1, 2], [3, 4]]
+	$corner = $grid[-1][-1]
+	$grid[1] << 5
+	$obj = {}
+	$obj.items[2].name = 'x'
+	$tree.branches[1].leaves << 'leaf'
+	$interp = "{l[-1]} {l[1:3]|join:'-'}"
+	loop $v in $l[3:] {
+		$looped << $v
+	}
+	`)
+
+	assert.NoError(err)
+	assert.Equal([]any{2, 3}, actual[`middle`])
+	assert.Equal([]any{1, 2, 3}, actual[`head`])
+	assert.Equal([]any{4, 5}, actual[`tail`])
+	assert.Equal(5, actual[`last`])
+	assert.Equal([]any{1, 2, 3, 4, 50}, actual[`l`])
+	assert.Equal(`friend`, actual[`prefix`])
+	assert.Equal(`t`, actual[`final`])
+	assert.Equal(4, actual[`corner`])
+	assert.Equal([]any{[]any{1, 2}, []any{3, 4, 5}}, actual[`grid`])
+	assert.Equal(map[string]any{
+		`items`: []any{nil, nil, map[string]any{`name`: `x`}},
+	}, actual[`obj`])
+	assert.Equal(map[string]any{
+		`branches`: []any{nil, map[string]any{`leaves`: []any{`leaf`}}},
+	}, actual[`tree`])
+	assert.Equal(`50 2-3`, actual[`interp`])
+	assert.Equal([]any{4, 50}, actual[`looped`])
+
+	_, err = eval("$l = [1, 2]\n$l[-3] = 1")
+	assert.Error(err)
+
+	_, err = eval("$l = [1, 2]\n$l[0:1] = 1")
+	assert.Error(err)
+}
+
+func TestDestructuring(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$user = {id: 42, name: 'friend', roles: ['admin'], active: true}
+	{id, name: $n} = $user
+	{roles, ...$others} = $user
+	$first, ...$rest = [1, 2, 3, 4]
+	...$everything = [5, 6]
+	$only, ...$none = ['one']
+
+	$people = [{id: 1, name: 'a'}, {id: 2, name: 'b'}]
+
+	loop {id: $uid, name} in $people {
+		$pairs << "{uid}={name}"
+	}
+
+	def pair() {
+		return {left: 'L', right: 'R', extra: 1}
+	}
+
+	def three() {
+		return [7, 8, 9]
+	}
+
+	pair -> {left, right: $r, ...$remainder}
+	three -> $x, ...$xs
+	`)
+
+	assert.NoError(err)
+	assert.Equal(42, actual[`id`])
+	assert.Equal(`friend`, actual[`n`])
+	assert.Equal([]any{`admin`}, actual[`roles`])
+	assert.Equal(map[string]any{`active`: true, `id`: 42, `name`: `friend`}, actual[`others`])
+	assert.Equal(1, actual[`first`])
+	assert.Equal([]any{2, 3, 4}, actual[`rest`])
+	assert.Equal([]any{5, 6}, actual[`everything`])
+	assert.Equal(`one`, actual[`only`])
+	assert.Equal([]any{}, actual[`none`])
+	assert.Equal([]any{`1=a`, `2=b`}, actual[`pairs`])
+	assert.Equal(`L`, actual[`left`])
+	assert.Equal(`R`, actual[`r`])
+	assert.Equal(map[string]any{`extra`: 1}, actual[`remainder`])
+	assert.Equal(7, actual[`x`])
+	assert.Equal([]any{8, 9}, actual[`xs`])
+
+	_, err = eval(`{a, b} = [1, 2]`)
+	assert.Error(err)
+
+	_, err = eval(`{a} = {a: 1}, {a: 2}`)
+	assert.Error(err)
+}
+
+func TestCoalesceAndTernary(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$resp = {body: {items: [1, 2, 3]}, empty: null}
+	$n = 3
+	$a = $missing ?? 'default'
+	$b = $resp.empty ?? $missing ?? 42
+	$c = $resp.body.items ?? [0]
+	$d = $resp?.body?.items
+	$e = $resp?.headers?.type ?? 'text/plain'
+	$f = $n > 1 ? 'many' : 'one'
+	$g = $resp.empty ? 'yes' : 'no'
+	$h = $n == 1 ? 'one' : $n == 2 ? 'two' : 'lots'
+	$i = [$n > 2 ? 'x' : 'y', $missing ?? 0]
+	$j = $resp.body.items[$n > 2 ? -1 : 0]
+	$k = ($n ?? 0) * 2
+	$l = $resp.body.items[$n > 2 ? 1 : 0:]
+
+	if ($missing ?? 5) == 5 {
+		$m = true
+	}
+	`)
+
+	assert.NoError(err)
+	assert.Equal(`default`, actual[`a`])
+	assert.Equal(42, actual[`b`])
+	assert.Equal([]any{1, 2, 3}, actual[`c`])
+	assert.Equal([]any{1, 2, 3}, actual[`d`])
+	assert.Equal(`text/plain`, actual[`e`])
+	assert.Equal(`many`, actual[`f`])
+	assert.Equal(`no`, actual[`g`])
+	assert.Equal(`lots`, actual[`h`])
+	assert.Equal([]any{`x`, 0}, actual[`i`])
+	assert.Equal(3, actual[`j`])
+	assert.EqualValues(6, actual[`k`])
+	assert.Equal([]any{2, 3}, actual[`l`])
+	assert.Equal(true, actual[`m`])
+
+	// in strict mode, only safe navigation and null-coalescing tolerate missing values
+	env := NewEnvironment()
+	env.SetStrict(true)
+
+	_, err = env.EvaluateString(`
+	$resp = {body: {}}
+	$a = $resp?.body?.items?.first
+	$b = $resp.body?.type
+	$c = $nope ?? 'fallback'
+	$d = "{resp?.headers?.type ?? 'none'}"
+	$e = $c ? 'ok' : $nope`)
+
+	assert.NoError(err)
+	assert.Nil(env.Get(`a`))
+	assert.Nil(env.Get(`b`))
+	assert.Equal(`fallback`, env.Get(`c`))
+	assert.Equal(`none`, env.Get(`d`))
+	assert.Equal(`ok`, env.Get(`e`))
+
+	for _, script := range []string{
+		`$x = $nope`,
+		`$x = $resp.headers.type`,
+		`$x = $resp?.body.items.name`,
+		`if $nope == 1 {}`,
+	} {
+		_, err = env.EvaluateString("$resp = {body: {}}\n" + script)
+		assert.Error(err, script)
+		assert.True(errors.Is(err, scripting.ErrUndefinedVariable), script)
+	}
+
+	_, err = eval(`$a?.b = 1`)
+	assert.Error(err)
+}
+
+func TestMatch(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$values = [200, 201, 404, 503, 'hello', [1], {a: 1}, null, 42.5, 7, 2s]
+
+	loop $value in $values {
+		match $value {
+			200, 201 -> {
+				$results << 'ok'
+			}
+			/^5\d\d$/ -> {
+				$results << 'server error'
+			}
+			400..<500 -> {
+				$results << 'client error'
+			}
+			is string -> {
+				$results << 'string'
+			}
+			is array, is object -> {
+				$results << 'container'
+			}
+			is null -> {
+				$results << 'null'
+			}
+			10..1 -> {
+				$results << 'small'
+			}
+			is duration -> {
+				$results << 'duration'
+			}
+			_ -> {
+				$results << 'other'
+			}
+		}
+	}
+
+	$limit = 5
+
+	match $limit * 2 {
+		$limit -> {
+			$which = 'limit'
+		}
+		($limit * 2) -> {
+			$which = 'double'
+		}
+	}
+
+	match 'nothing' {
+		'something' -> {
+			$unmatched = true
+		}
+	}
+	`)
+
+	assert.NoError(err)
+	assert.Equal([]any{
+		`ok`,
+		`ok`,
+		`client error`,
+		`server error`,
+		`string`,
+		`container`,
+		`container`,
+		`null`,
+		`other`,
+		`small`,
+		`duration`,
+	}, actual[`results`])
+	assert.Equal(`double`, actual[`which`])
+	assert.Nil(actual[`unmatched`])
+
+	_, err = eval(`
+	match 1 {
+		is spaceship -> {}
+	}`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `unknown type "spaceship"`)
+}
+
+func TestRanges(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$n = 20
+
+	loop $i in 1..5 {
+		$inclusive << $i
+	}
+
+	loop $i in 0..<$n step 5 {
+		$stepped << $i
+	}
+
+	loop $i in 5..1 {
+		$reversed << $i
+	}
+
+	loop $i in 10..<0 step 4 {
+		$countdown << $i
+	}
+
+	loop $x in 0..1 step 0.25 {
+		$floats << $x
+	}
+
+	loop $i in 3..<3 {
+		$empty << $i
+	}
+
+	loop $i in 0..1000000000000 {
+		if $i >= 3 {
+			break
+		}
+
+		$huge << $i
+	}
+
+	match 15 {
+		1..10 step 2 -> {
+			$matched = 'odd'
+		}
+		0..20 step 5 -> {
+			$matched = 'fives'
+		}
+	}
+	`)
+
+	assert.NoError(err)
+	assert.Equal([]any{1, 2, 3, 4, 5}, actual[`inclusive`])
+	assert.Equal([]any{0, 5, 10, 15}, actual[`stepped`])
+	assert.Equal([]any{5, 4, 3, 2, 1}, actual[`reversed`])
+	assert.Equal([]any{10, 6, 2}, actual[`countdown`])
+	assert.Equal([]any{0, 0.25, 0.5, 0.75, 1}, actual[`floats`])
+	assert.Nil(actual[`empty`])
+	assert.Equal([]any{0, 1, 2}, actual[`huge`])
+	assert.Equal(`fives`, actual[`matched`])
+
+	_, err = eval(`loop $i in 1..10 step 0 {}`)
+	assert.Error(err)
+
+	_, err = eval(`loop $i in 1s..10s {}`)
+	assert.Error(err)
+}
+
+func TestLoopLabels(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$xs = [1, 2, 3]
+	$ys = ['a', 'b', 'c']
+
+	loop:outer $x in $xs {
+		loop $y in $ys {
+			if $y == 'b' {
+				if $x == 2 {
+					continue outer
+				}
+
+				if $x == 3 {
+					break outer
+				}
+			}
+
+			$pairs << "{x}{y}"
+		}
+	}
+
+	loop:rows count 2 {
+		loop:cols count 3 {
+			if $index > 1 {
+				continue cols
+			}
+
+			match $index {
+				_ -> {
+					$cells << $index
+					break cols
+				}
+			}
+		}
+	}
+	`)
+
+	assert.NoError(err)
+	assert.Equal([]any{`1a`, `1b`, `1c`, `2a`, `3a`}, actual[`pairs`])
+	assert.Equal([]any{0, 0}, actual[`cells`])
+
+	// labels are checked when the script is parsed, before anything runs
+	_, err = eval(`
+	$ran = true
+
+	loop:outer count 2 {
+		break inner
+	}`)
+
+	assert.Error(err)
+	assert.Contains(err.Error(), `unknown loop label "inner"`)
+	assert.Contains(err.Error(), `line 5`)
+
+	_, err = eval(`
+	loop:outer count 2 {
+		def f() {
+			break outer
+		}
+	}`)
+
+	assert.Error(err)
+}
+
+func TestParallelLoops(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	def fib($n) {
+		if $n < 2 {
+			return $n
+		}
+
+		$n1 = $n - 1
+		$n2 = $n - 2
+		fib $n1 -> $a
+		fib $n2 -> $b
+		return $a + $b
+	}
+
+	$words = ['alpha', 'beta', 'gamma', 'delta']
+	$prefix = 'w:'
+
+	loop parallel 2 $word in $words into $upper {
+		fmt::upper "{prefix}{word}" -> $u
+	}
+
+	loop parallel $n in 1..10 into $fibs {
+		fib $n -> $f
+	}
+
+	$letters = {a: 1, b: 2, c: 3}
+
+	loop parallel 3 $k, $v in $letters into $pairs {
+		$pair = "{k}={v}@{index}"
+	}
+
+	loop parallel 4 $i in 1..6 into $odds, $errors {
+		if $i % 2 == 0 {
+			fail "{i} is even"
+		}
+
+		$odd = $i
+	}
+
+	declare $partial
+
+	try {
+		loop parallel 1 $i in 1..5 into $partial {
+			if $i == 2 {
+				fail "stopped at {i}"
+			}
+
+			$value = $i * 10
+		}
+	} catch $err {
+		$message = $err.message
+	}
+	`)
+
+	assert.NoError(err)
+	assert.Equal([]any{`W:ALPHA`, `W:BETA`, `W:GAMMA`, `W:DELTA`}, actual[`upper`])
+	assert.Equal([]any{1, 1, 2, 3, 5, 8, 13, 21, 34, 55}, actual[`fibs`])
+	assert.Equal([]any{`a=1@0`, `b=2@1`, `c=3@2`}, actual[`pairs`])
+	assert.Equal([]any{1, nil, 3, nil, 5, nil}, actual[`odds`])
+	assert.Len(actual[`errors`], 3)
+	assert.EqualValues(1, maputil.M(actual[`errors`].([]any)[0]).Int(`index`))
+	assert.Equal(`2 is even`, maputil.M(actual[`errors`].([]any)[0]).String(`message`))
+	assert.EqualValues(5, maputil.M(actual[`errors`].([]any)[2]).Int(`index`))
+
+	// the first failure stops the iterations that have not started yet
+	assert.Equal([]any{10, nil}, actual[`partial`])
+	assert.Equal(`stopped at 2`, actual[`message`])
+
+	// iterations run at the same time, up to the loop's limit
+	var env = NewEnvironment()
+	var cmds = newTestCommands(env)
+
+	env.RegisterModule(`testing`, cmds)
+
+	_, err = env.EvaluateString(`
+	loop parallel 3 $i in 1..9 {
+		testing::rendezvous 3
+	}`)
+
+	assert.NoError(err)
+	assert.Equal(3, cmds.peak)
+
+	// breaking out of a parallel loop stops any more iterations from starting
+	actual, err = eval(`
+	loop parallel 1 $i in 1..5 into $seen {
+		if $i == 3 {
+			break
+		}
+
+		$v = $i
+	}`)
+
+	assert.NoError(err)
+	assert.Equal([]any{1, 2, nil}, actual[`seen`])
+
+	// results are only kept for the iterations that ran, so stopping early doesn't need room for every
+	// value in a large range
+	actual, err = eval(`
+	loop parallel 1 $i in 0..<2000000000 into $first {
+		if $i > 2 {
+			break
+		}
+
+		$v = $i
+	}
+
+	loop parallel 2 $i in 0..<2000000000 {
+		if $i > 2 {
+			break
+		}
+	}`)
+
+	assert.NoError(err)
+	assert.Equal([]any{0, 1, 2, nil}, actual[`first`])
+
+	_, err = eval(`loop parallel 0 $i in 1..5 {}`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `must be a positive integer`)
+}
+
+func TestParallelBlocks(t *testing.T) {
+	assert := require.New(t)
+
+	actual, err := eval(`
+	$existing = 'before'
+
+	parallel {
+		fmt::upper 'first' -> $a
+		fmt::lower 'SECOND' -> $b
+		$existing = 'after'
+
+		if $existing {
+			$nested = 'inner'
+		}
+
+		loop parallel $i in 1..3 into $squares {
+			$square = $i * $i
+		}
+	}
+
+	declare $message, $line, $ok
+
+	try {
+		parallel {
+			fail 'one'
+			$ok = true
+			fail 'two'
+		}
+	} catch $err {
+		$message = $err.message
+		$line = $err.line
+	}
+	`)
+
+	assert.NoError(err)
+	assert.Equal(`FIRST`, actual[`a`])
+	assert.Equal(`second`, actual[`b`])
+	assert.Equal(`after`, actual[`existing`])
+	assert.Equal(`inner`, actual[`nested`])
+	assert.Equal([]any{1, 4, 9}, actual[`squares`])
+	assert.Equal(true, actual[`ok`])
+	assert.Equal(`2 of 3 parallel branches failed: one; two`, actual[`message`])
+	assert.EqualValues(22, actual[`line`])
+
+	// statements in a parallel block run at the same time, and only see the variables that existed
+	// before the block; the values they set are copied out of it in the order of the statements
+	var env = NewEnvironment()
+	var cmds = newTestCommands(env)
+
+	env.RegisterModule(`testing`, cmds)
+
+	scope, err := env.EvaluateString(`
+	$go = true
+	$list = ['start']
+	$config = {name: 'friend', retries: 1}
+
+	parallel {
+		if $go {
+			testing::rendezvous 3
+			$list << 'a'
+			$config.retries = 2
+		}
+
+		if $go {
+			testing::rendezvous 3
+			$list << 'b'
+			$config.timeout = 30
+		}
+
+		if $go {
+			testing::rendezvous 3
+			$seen = $list
+		}
+	}`)
+
+	assert.NoError(err)
+	assert.Equal(3, cmds.peak)
+	assert.Equal([]any{`start`, `b`}, scope.Get(`list`))
+	assert.Equal([]any{`start`}, scope.Get(`seen`))
+	assert.Equal(map[string]any{`name`: `friend`, `retries`: 1, `timeout`: 30}, scope.Get(`config`))
+
+	// context handlers are told which branch each command ran in
+	env = NewEnvironment()
+	var branches = make(map[string]string)
+	var block sync.Mutex
+
+	env.RegisterContextHandler(func(ctx *scripting.Context, isCompleted bool) {
+		if isCompleted {
+			block.Lock()
+			defer block.Unlock()
+
+			branches[ctx.Snippet()] = ctx.Branch
+		}
+	})
+
+	_, err = env.EvaluateString(`
+	fmt::upper 'outside'
+	$letters = ['a', 'b']
+
+	parallel {
+		fmt::upper 'zero'
+
+		loop parallel $x in $letters {
+			fmt::upper $x
+		}
+	}`)
+
+	assert.NoError(err)
+	assert.Equal(``, branches[`fmt::upper 'outside'`])
+	assert.Equal(`0`, branches[`fmt::upper 'zero'`])
+	assert.Contains([]string{`1.0`, `1.1`}, branches[`fmt::upper $x`])
+
+	// commands failing in several branches at once each report their own command and branch
+	env = NewEnvironment()
+	env.RegisterModule(`testing`, newTestCommands(env))
+
+	_, err = env.EvaluateString(`
+	def broken($i) {
+		testing::rendezvous 4
+		fmt::nope $i
+	}
+
+	parallel {
+		loop parallel $i in 0..1 {
+			broken $i
+		}
+
+		loop parallel $i in 2..3 {
+			testing::rendezvous 4
+			vars::nope $i
+		}
+	}`)
+
+	var perr *ParallelError
+
+	assert.True(errors.As(err, &perr))
+	assert.Len(perr.Errors, 2)
+
+	for i, label := range []string{`fmt::nope`, `vars::nope`} {
+		var cerr *scripting.ContextError
+
+		assert.True(errors.As(perr.Errors[i], &cerr))
+		assert.Equal(label, cerr.Context.Label)
+		assert.Contains([]string{fmt.Sprintf("%d.0", i), fmt.Sprintf("%d.1", i)}, cerr.Context.Branch)
+	}
+}
+
+func TestTimeoutAndRetry(t *testing.T) {
+	assert := require.New(t)
+
+	// commands running when a timeout expires are cancelled
+	var started = time.Now()
+
+	actual, err := eval(`
+	declare $message, $line, $after
+
+	try {
+		timeout 50ms {
+			wait 5s
+			$after = true
+		}
+	} catch $err {
+		$message = $err.message
+		$line = $err.line
+	}
+
+	timeout 1s {
+		$finished = true
+	}
+	`)
+
+	assert.NoError(err)
+	assert.True(time.Since(started) < time.Second)
+	assert.Equal(`timed out after 50ms`, actual[`message`])
+	assert.EqualValues(5, actual[`line`])
+	assert.Nil(actual[`after`])
+	assert.Equal(true, actual[`finished`])
+
+	// ...including HTTP requests
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-time.After(5 * time.Second):
+		case <-req.Context().Done():
+		}
+	}))
+
+	defer server.Close()
+
+	started = time.Now()
+
+	_, err = eval(`timeout 50ms {
+		http::get '%s'
+	}`, server.URL)
+
+	assert.Error(err)
+	assert.True(errors.Is(err, ErrTimeout))
+	assert.True(time.Since(started) < time.Second)
+
+	// the body is attempted until it succeeds
+	actual, err = eval(`
+	$attempts = null
+	$errors = null
+
+	retry 5 {
+		$attempts << $attempt
+
+		if $last_error {
+			$errors << $last_error.message
+		}
+
+		if $attempt < 3 {
+			fail "attempt {attempt} failed"
+		}
+	}
+
+	retry 5 until $n >= 2 {
+		$n = $attempt
+	}
+	`)
+
+	assert.NoError(err)
+	assert.Equal([]any{1, 2, 3}, actual[`attempts`])
+	assert.Equal([]any{`attempt 1 failed`, `attempt 2 failed`}, actual[`errors`])
+	assert.EqualValues(2, actual[`n`])
+	assert.Nil(actual[`attempt`])
+	assert.Nil(actual[`last_error`])
+
+	// ...or until it runs out of attempts
+	actual, err = eval(`
+	declare $message, $count
+
+	try {
+		retry 3 backoff constant 10ms {
+			$count = $attempt
+			fail 'nope'
+		}
+	} catch $err {
+		$message = $err.message
+	}
+
+	$done = false
+
+	try {
+		retry 2 until $done {
+			testing::noop
+		}
+	} catch $err {
+		$unmet = $err.message
+	}
+	`)
+
+	assert.NoError(err)
+	assert.EqualValues(3, actual[`count`])
+	assert.Equal(`failed after 3 attempts: nope`, actual[`message`])
+	assert.Equal(`failed after 2 attempts: retry condition was not met`, actual[`unmet`])
+
+	// waits between attempts back off, and are cancelled by enclosing timeouts
+	started = time.Now()
+
+	_, err = eval(`
+	timeout 100ms {
+		retry 5 backoff exponential 20ms..1s jitter 0.5 {
+			fail 'nope'
+		}
+	}`)
+
+	assert.True(errors.Is(err, ErrTimeout))
+	assert.True(time.Since(started) < time.Second)
+
+	var backoff = &scripting.Backoff{
+		Type: scripting.ExponentialBackoff,
+		Min:  200 * time.Millisecond,
+		Max:  time.Second,
+	}
+
+	assert.Equal(200*time.Millisecond, backoff.Delay(1))
+	assert.Equal(400*time.Millisecond, backoff.Delay(2))
+	assert.Equal(800*time.Millisecond, backoff.Delay(3))
+	assert.Equal(time.Second, backoff.Delay(4))
+	assert.Equal(time.Second, backoff.Delay(1000))
+
+	backoff.Type = scripting.LinearBackoff
+	assert.Equal(600*time.Millisecond, backoff.Delay(3))
+
+	backoff.Type = scripting.ConstantBackoff
+	assert.Equal(200*time.Millisecond, backoff.Delay(3))
+
+	backoff.Jitter = 1
+
+	for i := 0; i < 10; i++ {
+		assert.True(backoff.Delay(1) <= 200*time.Millisecond)
+	}
+}
+
+func TestDefer(t *testing.T) {
+	assert := require.New(t)
+
+	// deferred statements run in reverse order when the blocks they are in finish
+	actual, err := eval(`
+	$order = null
+	declare $message
+
+	def work() {
+		defer { testing::noop }
+		return 42
+	}
+
+	def cleanup() {
+		defer fail 'cleanup failed'
+		return 42
+	}
+
+	defer {
+		$order << 'script'
+	}
+
+	loop $i in 1..2 {
+		defer { $order << "iteration {i}" }
+		$order << "loop {i}"
+	}
+
+	if $order {
+		defer { $order << 'if deferred' }
+		defer { $order << 'if deferred first' }
+	}
+
+	work -> $result
+	$order << 'end'
+
+	try {
+		cleanup
+	} catch $err {
+		$message = $err.message
+	}
+	`)
+
+	assert.NoError(err)
+	assert.EqualValues(42, actual[`result`])
+	assert.Equal([]any{
+		`loop 1`, `iteration 1`,
+		`loop 2`, `iteration 2`,
+		`if deferred first`, `if deferred`,
+		`end`, `script`,
+	}, actual[`order`])
+
+	// functions run the statements they defer before they return
+	assert.Equal(`cleanup failed`, actual[`message`])
+
+	// ...including when they fail
+	var tmp, _ = os.CreateTemp(``, `friendscript-defer-`)
+	tmp.Close()
+
+	actual, err = eval(`
+	$cleaned = false
+	declare $message
+
+	try {
+		defer { $cleaned = true }
+		defer file::remove '%s'
+		fail 'broken'
+	} catch $err {
+		$message = $err.message
+	}`, tmp.Name())
+
+	assert.NoError(err)
+	assert.Equal(true, actual[`cleaned`])
+	assert.Equal(`broken`, actual[`message`])
+	assert.False(fileutil.Exists(tmp.Name()))
+
+	// failing deferred statements fail the block they are in, unless it already failed
+	_, err = eval(`
+	defer fail 'deferred'
+	fail 'original'`)
+
+	assert.Error(err)
+	assert.Contains(err.Error(), `original`)
+
+	_, err = eval(`defer fail 'deferred'`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `deferred`)
+
+	// ...and they run when the script is interrupted
+	var env = NewEnvironment()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		env.Interrupt()
+	}()
+
+	var started = time.Now()
+
+	scope, err := env.EvaluateString(`
+	$cleaned = false
+	defer { $cleaned = true }
+
+	wait 5s
+	$finished = true`)
+
+	assert.True(errors.Is(err, ErrInterrupted))
+	assert.True(time.Since(started) < time.Second)
+	assert.Equal(true, scope.Get(`cleaned`))
+	assert.Nil(scope.Get(`finished`))
+}
+
+func TestTransactions(t *testing.T) {
+	assert := require.New(t)
+	var env = NewEnvironment()
+	var testing = newTestCommands(env)
+
+	env.RegisterModule(`testing`, testing)
+
+	// the steps of a failed transaction are undone in reverse order
+	scope, err := env.EvaluateString(`
+	$log = null
+	declare $report
+
+	transaction {
+		testing::create 'a'
+
+		testing::create 'b' -> $b undo {
+			$log << "undid {b.name}"
+		}
+
+		transaction {
+			testing::create 'c'
+		}
+
+		# an undo clause takes the place of the module's undo method
+		testing::create 'd' undo {
+			$log << 'undid d'
+		}
+
+		fail 'step 5 failed'
+		testing::create 'e'
+	} rollback $r {
+		$report = $r
+	}`)
+
+	assert.NoError(err)
+	assert.Equal([]string{`b`, `d`}, testing.created)
+	assert.Equal([]any{`undid d`, `undid b`}, scope.Get(`log`))
+
+	var report = scope.Get(`report`).(map[string]any)
+
+	assert.Equal(true, report[`rolled_back`])
+	assert.Equal(`step 5 failed`, report[`error`].(map[string]any)[`message`])
+	assert.Len(report[`steps`], 4)
+
+	for i, command := range []string{
+		`testing::create 'd'`,
+		`testing::create 'c'`,
+		`testing::create 'b' -> $b`,
+		`testing::create 'a'`,
+	} {
+		var step = report[`steps`].([]any)[i].(map[string]any)
+
+		assert.Equal(command, step[`command`])
+		assert.Equal(true, step[`undone`])
+		assert.Nil(step[`error`])
+	}
+
+	// transactions that succeed are left alone
+	_, err = env.EvaluateString(`
+	transaction {
+		testing::create 'kept'
+	}`)
+
+	assert.NoError(err)
+	assert.Equal([]string{`b`, `d`, `kept`}, testing.created)
+
+	// failed transactions without a rollback stanza fail, and report which steps could not be undone
+	_, err = env.EvaluateString(`
+	transaction {
+		testing::create 'stuck'
+		testing::create 'f'
+		fail 'broken'
+	}`)
+
+	var terr *TransactionError
+
+	assert.True(errors.As(err, &terr))
+	assert.Equal(`transaction failed: broken (undid 1 of 2 steps)`, terr.Error())
+	assert.False(terr.RolledBack())
+	assert.Equal(`cannot remove stuck (id 4)`, terr.Compensations[1].Error.Error())
+	assert.Equal([]string{`b`, `d`, `kept`, `stuck`}, testing.created)
+
+	// undoing the steps of a failed transaction is not a step of the transaction around it, so each
+	// step is only ever undone once
+	env = NewEnvironment()
+	testing = newTestCommands(env)
+	env.RegisterModule(`testing`, testing)
+
+	_, err = env.EvaluateString(`
+	transaction {
+		testing::create 'outer'
+
+		try {
+			transaction {
+				testing::create 'inner' undo {
+					testing::remove 'inner'
+				}
+
+				fail 'inner failed'
+			}
+		} catch {
+		}
+
+		fail 'outer failed'
+	}`)
+
+	assert.True(errors.As(err, &terr))
+	assert.Len(terr.Compensations, 1)
+	assert.Empty(testing.created)
+	assert.Equal([]string{`inner`, `outer`}, testing.removed)
+}
+
+func TestReturnAndExit(t *testing.T) {
+	assert := require.New(t)
+
+	// "return" ends a script successfully
+	actual, err := eval(`
+	$a = 1
+	return
+	$a = 2`)
+
+	assert.NoError(err)
+	assert.EqualValues(1, actual[`a`])
+
+	// "exit" ends a script with an exit status, from anywhere in it
+	var env = NewEnvironment()
+
+	scope, err := env.EvaluateString(`
+	$cleaned = false
+	$caught = false
+
+	def stop($code) {
+		exit $code
+	}
+
+	defer {
+		$cleaned = true
+	}
+
+	try {
+		loop $i in 1..10 {
+			$last = $i
+
+			if $i == 3 {
+				stop 3
+			}
+		}
+	} catch {
+		$caught = true
+	}
+
+	$after = true`)
+
+	var exit *ExitError
+
+	assert.True(errors.As(err, &exit))
+	assert.Equal(3, exit.Code)
+	assert.Equal(`exit status 3`, err.Error())
+	assert.EqualValues(3, scope.Get(`last`))
+	assert.Equal(true, scope.Get(`cleaned`))
+	assert.Equal(false, scope.Get(`caught`))
+	assert.Nil(scope.Get(`after`))
+
+	_, err = eval(`exit`)
+	assert.True(errors.As(err, &exit))
+	assert.Equal(0, exit.Code)
+
+	_, err = eval(`exit 256`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `invalid exit code 256`)
+
+	// scripts called with "run" give the value they return to the caller, and stop it if they exit
+	var dir = t.TempDir()
+
+	assert.NoError(os.WriteFile(filepath.Join(dir, `double.fs`), []byte("return $n * 2\n$n = 0"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, `quit.fs`), []byte("exit 4"), 0644))
+
+	actual, err = eval(`
+	run '%s' {data: {n: 21}} -> $doubled
+	$after = true`, filepath.Join(dir, `double.fs`))
+
+	assert.NoError(err)
+	assert.EqualValues(42, actual[`doubled`])
+	assert.Equal(true, actual[`after`])
+
+	actual, err = eval(`
+	try {
+		run '%s'
+	} catch {
+		$caught = true
+	}
+	$after = true`, filepath.Join(dir, `quit.fs`))
+
+	assert.True(errors.As(err, &exit))
+	assert.Equal(4, exit.Code)
+}
+
+func TestConstants(t *testing.T) {
+	assert := require.New(t)
+
+	// constants can be read like any other variable, including by loops and functions
+	actual, err := eval(`
+	const $api_base = "https://example.com/api"
+	const $retries, $limits = [3, {max: 10}]
+	const {name, ...$rest} = {name: "friend", greeting: "hi"}
+
+	def url($path) {
+		return "{api_base}/{path}"
+	}
+
+	$urls = null
+
+	loop $i in 1..2 {
+		url "v{i}" -> $url
+		$urls << $url
+	}
+
+	$shadowed = null
+
+	loop $api_base in 1..1 {
+		$shadowed << $api_base
+	}`)
+
+	assert.NoError(err)
+	assert.Equal(`https://example.com/api`, actual[`api_base`])
+	assert.EqualValues(3, actual[`retries`])
+	assert.EqualValues(10, actual[`limits`].(map[string]any)[`max`])
+	assert.Equal(`friend`, actual[`name`])
+	assert.Equal(map[string]any{`greeting`: `hi`}, actual[`rest`])
+	assert.Equal([]any{`https://example.com/api/v1`, `https://example.com/api/v2`}, actual[`urls`])
+	assert.EqualValues([]any{1}, actual[`shadowed`])
+
+	// constants cannot be changed in any way
+	for _, script := range []string{
+		`$api_base = "https://other.example.com"`,
+		`$api_base += "/v2"`,
+		`$api_base, $other = ["a", "b"]`,
+		`loop $i in 1..2 { $api_base = "x" }`,
+		`if $api_base { $api_base = "x" }`,
+		`unset $api_base`,
+		`const $api_base = "x"`,
+		`fmt::trim "x" -> $api_base`,
+		`$limits.max = 100`,
+		`unset $limits.max`,
+		`vars::set "api_base" {value: 5}`,
+		`vars::set "limits.max" {value: 5}`,
+		`vars::push "api_base" {value: 5}`,
+		`vars::pop "api_base"`,
+	} {
+		_, err = eval(`
+		const $api_base = "https://example.com/api"
+		const $limits = {max: 10}
+		` + script)
+
+		var cerr *scripting.ContextError
+
+		assert.Error(err, script)
+		assert.True(errors.Is(err, scripting.ErrConstant), script)
+		assert.True(errors.As(err, &cerr), script)
+		assert.Contains(err.Error(), `line 4: cannot reassign constant $`, script)
+	}
+
+	// the error includes the statement that tried to change the constant
+	_, err = eval(`
+	const $api_base = "https://example.com/api"
+
+	loop $i in 1..2 {
+		$api_base = "{api_base}/v{i}"
+	}`)
+
+	assert.Error(err)
+	assert.Equal(`line 5: cannot reassign constant $api_base: $api_base = "{api_base}/v{i}"`, err.Error())
+
+	// constants are declared once, and can only be assigned with "="
+	_, err = eval(`const $a += 1`)
+	assert.Error(err)
+
+	_, err = eval(`const $a.b = 1`)
+	assert.Error(err)
+	assert.Contains(err.Error(), `constants must be top-level variables`)
+
+	// embedders can inject read-only variables
+	var env = NewEnvironment(map[string]any{
+		`name`: `friend`,
+	})
+
+	assert.NoError(env.SetConstant(`api_base`, `https://example.com/api`))
+	assert.Error(env.SetConstant(`api_base`, `https://other.example.com`))
+
+	scope, err := env.EvaluateString(`$url = "{api_base}/users/{name}"`)
+	assert.NoError(err)
+	assert.Equal(`https://example.com/api/users/friend`, scope.Get(`url`))
+
+	_, err = env.EvaluateString(`$api_base = "https://other.example.com"`)
+	assert.True(errors.Is(err, scripting.ErrConstant))
+	assert.True(errors.Is(env.Assign(`api_base`, `https://other.example.com`), scripting.ErrConstant))
+	env.Set(`api_base`, `https://other.example.com`)
+	assert.Equal(`https://example.com/api`, env.Get(`api_base`))
+}
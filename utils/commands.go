@@ -1,6 +1,10 @@
 package utils
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+
 	"github.com/ghetzel/go-stockutil/stringutil"
 )
 
@@ -27,3 +31,80 @@ func (self *DefaultExecutor) FormatCommandName(name string) string {
 func (self *DefaultExecutor) ExecuteCommand(name string, arg any, objargs map[string]any) (any, error) {
 	return CallCommandFunction(self.from, self.FormatCommandName(name), arg, objargs)
 }
+
+func (self *DefaultExecutor) ExecuteCommandContext(ctx context.Context, name string, arg any, objargs map[string]any) (any, error) {
+	return CallCommandFunctionContext(ctx, self.from, self.FormatCommandName(name), arg, objargs)
+}
+
+func (self *DefaultExecutor) CanUndoCommand(name string) bool {
+	var _, err = GetFunctionByName(self.from, UndoPrefix+self.FormatCommandName(name))
+	return (err == nil)
+}
+
+func (self *DefaultExecutor) UndoCommand(ctx context.Context, name string, arg any, objargs map[string]any, result any) error {
+	var _, err = callCommandFunction(ctx, self.from, UndoPrefix+self.FormatCommandName(name), arg, objargs, result)
+	return err
+}
+
+// Execute the named command of the given module.  If the module can be cancelled (see ContextModule), it
+// is given the provided context.
+func ExecuteCommandContext(ctx context.Context, module Module, name string, arg any, objargs map[string]any) (any, error) {
+	if cmodule, ok := moduleAs[ContextModule](module); ok {
+		return cmodule.ExecuteCommandContext(ctx, name, arg, objargs)
+	}
+
+	return module.ExecuteCommand(name, arg, objargs)
+}
+
+// Return whether the given module can undo the named command (see UndoModule).
+func CanUndoCommand(module Module, name string) bool {
+	if umodule, ok := moduleAs[UndoModule](module); ok {
+		return umodule.CanUndoCommand(name)
+	}
+
+	return false
+}
+
+// Undo the named command of the given module, which was called with the given arguments and returned
+// the given result (see UndoModule).
+func UndoCommand(ctx context.Context, module Module, name string, arg any, objargs map[string]any, result any) error {
+	if umodule, ok := moduleAs[UndoModule](module); ok {
+		return umodule.UndoCommand(ctx, name, arg, objargs, result)
+	}
+
+	return fmt.Errorf("module %T cannot undo commands", module)
+}
+
+// Emit the named event from the given runtime on behalf of a command that was given the provided context.
+// Runtimes that track the event handlers running on each call chain (see ContextEmitter) are given the
+// context, others that can emit events (see EventEmitter) emit it as usual, and for the rest this does
+// nothing.
+func EmitContext(ctx context.Context, runtime Runtime, name string, payload any) error {
+	if emitter, ok := runtime.(ContextEmitter); ok {
+		return emitter.EmitContext(ctx, name, payload)
+	} else if emitter, ok := runtime.(EventEmitter); ok {
+		return emitter.Emit(name, payload)
+	}
+
+	return nil
+}
+
+// Return the given module as an implementation of an optional interface (e.g.: ContextModule).  This
+// includes modules that embed a Module (e.g.: a DefaultExecutor) that implements it, as the built-in
+// modules do.
+func moduleAs[T any](module Module) (T, bool) {
+	if impl, ok := module.(T); ok {
+		return impl, true
+	}
+
+	if modV := reflect.Indirect(reflect.ValueOf(module)); modV.Kind() == reflect.Struct {
+		if field := modV.FieldByName(`Module`); field.IsValid() && field.CanInterface() {
+			if impl, ok := field.Interface().(T); ok {
+				return impl, true
+			}
+		}
+	}
+
+	var zero T
+	return zero, false
+}
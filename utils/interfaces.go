@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"io"
 
 	"github.com/ghetzel/friendscript/scripting"
@@ -19,8 +20,51 @@ type Runtime interface {
 	Open(fileOrReader any) (io.ReadCloser, error)
 }
 
+// Runtimes that can emit events to the handlers registered with them implement this interface.
+type EventEmitter interface {
+	Emit(name string, payload any) error
+}
+
+// Runtimes that keep track of which event handlers are running on the call chain that emitted an event
+// (e.g.: so that a handler does not recurse into itself) implement this interface.  The context should
+// be the one given to the command that is emitting the event.
+type ContextEmitter interface {
+	EmitContext(ctx context.Context, name string, payload any) error
+}
+
 type Module interface {
 	ExecuteCommand(name string, arg any, objargs map[string]any) (any, error)
 	FormatCommandName(string) string
 	SetInstance(any)
 }
+
+// Modules whose commands can be cancelled (e.g.: when a timeout expires) can implement this interface
+// to be given a context that is cancelled when the command should stop.  Modules that embed a
+// DefaultExecutor implement it already; a command receives the context if the module has a variant of
+// its method named with ContextSuffix (e.g.: "GetContext" for "Get") that accepts a context.Context as
+// its first parameter.
+type ContextModule interface {
+	ExecuteCommandContext(ctx context.Context, name string, arg any, objargs map[string]any) (any, error)
+}
+
+// The suffix of the methods that implement a module's commands with a context (e.g.: "GetContext"
+// implements "get", and is called instead of "Get" when there is a context to give it.)
+const ContextSuffix = `Context`
+
+// The prefix of the methods that undo a module's commands (e.g.: "UndoCreate" undoes "create").
+const UndoPrefix = `Undo`
+
+// Modules whose commands can be undone (e.g.: when a transaction fails) can implement this interface.
+// Modules that embed a DefaultExecutor implement it already: a command is undone by calling the
+// module's method named after it with UndoPrefix (if there is one), which is given the same arguments as
+// the command, followed by its result.
+type UndoModule interface {
+	CanUndoCommand(name string) bool
+	UndoCommand(ctx context.Context, name string, arg any, objargs map[string]any, result any) error
+}
+
+// Modules whose commands are not implemented as methods (e.g.: functions defined in scripts)
+// can implement this interface to list the commands they provide.
+type CommandLister interface {
+	CommandNames() []string
+}
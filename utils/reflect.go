@@ -1,8 +1,10 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/ghetzel/go-stockutil/stringutil"
 
@@ -12,10 +14,21 @@ import (
 )
 
 var errorInterface = reflect.TypeFor[error]()
+var contextInterface = reflect.TypeFor[context.Context]()
 
 func ListModuleCommands(module Module, skipNames ...string) []string {
 	commands := make([]string, 0)
 
+	if lister, ok := module.(CommandLister); ok {
+		for _, name := range lister.CommandNames() {
+			if !sliceutil.ContainsString(skipNames, name) {
+				commands = append(commands, name)
+			}
+		}
+
+		return commands
+	}
+
 	modV := reflect.ValueOf(module)
 
 	if modV.IsValid() {
@@ -23,11 +36,17 @@ func ListModuleCommands(module Module, skipNames ...string) []string {
 
 		for i := 0; i < modT.NumMethod(); i++ {
 			switch name := modT.Method(i).Name; name {
-			case `ExecuteCommand`, `FormatCommandName`, `SetInstance`:
+			case `ExecuteCommand`, `ExecuteCommandContext`, `FormatCommandName`, `SetInstance`, `CanUndoCommand`, `UndoCommand`:
 				continue
 			default:
 				if sliceutil.ContainsString(skipNames, name) {
 					continue
+				} else if undone, ok := strings.CutPrefix(name, UndoPrefix); ok && modV.MethodByName(undone).IsValid() {
+					// methods that undo other commands are not commands themselves
+					continue
+				} else if plain, ok := strings.CutSuffix(name, ContextSuffix); ok && modV.MethodByName(plain).IsValid() {
+					// neither are the variants of commands that accept a context
+					continue
 				} else {
 					commands = append(commands, stringutil.Underscore(name))
 				}
@@ -73,10 +92,36 @@ func GetFunctionByName(from any, name string) (reflect.Value, error) {
 //	  },
 //	})
 func CallCommandFunction(from any, name string, first any, rest map[string]any) (any, error) {
+	return CallCommandFunctionContext(context.Background(), from, name, first, rest)
+}
+
+// Same as CallCommandFunction, but if there is a variant of the named function with ContextSuffix (e.g.:
+// "GetContext" for "Get") whose first parameter is a context.Context, it is called instead and given the
+// provided context (and the command's arguments are passed as the parameters that follow it.)
+func CallCommandFunctionContext(ctx context.Context, from any, name string, first any, rest map[string]any) (any, error) {
+	var fromV, ok = from.(reflect.Value)
+
+	if !ok {
+		fromV = reflect.ValueOf(from)
+	}
+
+	if fn := fromV.MethodByName(name + ContextSuffix); fn.IsValid() && fn.Type().NumIn() > 0 && fn.Type().In(0) == contextInterface {
+		name += ContextSuffix
+	}
+
+	return callCommandFunction(ctx, from, name, first, rest)
+}
+
+// Call the named function with the given inputs, which are converted to the types of its parameters.
+// Functions whose first parameter is a context.Context are given the provided context first.
+func callCommandFunction(ctx context.Context, from any, name string, inputs ...any) (any, error) {
 	if fn, err := GetFunctionByName(from, name); err == nil {
-		var inputs = []any{first, rest}
 		var arguments = make([]reflect.Value, fn.Type().NumIn())
 
+		if len(arguments) > 0 && fn.Type().In(0) == contextInterface {
+			inputs = append([]any{ctx}, inputs...)
+		}
+
 		// loop through the arguments the target function takes, building an equally-sized list
 		// of reflect.Value instances containing the Golang value we work out using various magicks.
 		for i := range arguments {